@@ -0,0 +1,13 @@
+CREATE TABLE `receipt_accesses` (
+    `id` INT(11) unsigned auto_increment,
+    `receipt_id` INT(11) unsigned NOT NULL,
+    `user_id` INT(11) NOT NULL,
+    `role` VARCHAR(50) NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` DATETIME DEFAULT NULL,
+    CONSTRAINT `fk_receipts_receipt_accesses` FOREIGN KEY (`receipt_id`) REFERENCES receipts(`id`),
+    CONSTRAINT `fk_users_receipt_accesses` FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+    UNIQUE KEY `uk_receipt_user_receipt_accesses` (`receipt_id`, `user_id`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
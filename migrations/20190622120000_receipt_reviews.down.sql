@@ -0,0 +1,6 @@
+ALTER TABLE `receipts`
+    DROP INDEX `idx_average_rating_receipts`,
+    DROP COLUMN `ratings_count`,
+    DROP COLUMN `average_rating`;
+
+DROP TABLE `receipt_reviews`;
@@ -0,0 +1,19 @@
+CREATE TABLE `receipt_reviews` (
+    `id` INT(11) unsigned auto_increment,
+    `receipt_id` INT(11) unsigned NOT NULL,
+    `user_id` INT(11) NOT NULL,
+    `rating` TINYINT(1) unsigned NOT NULL,
+    `review` TEXT DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` DATETIME DEFAULT NULL,
+    CONSTRAINT `fk_receipts_receipt_reviews` FOREIGN KEY (`receipt_id`) REFERENCES receipts(`id`),
+    CONSTRAINT `fk_users_receipt_reviews` FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+    UNIQUE KEY `uk_receipt_user_receipt_reviews` (`receipt_id`, `user_id`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+ALTER TABLE `receipts`
+    ADD COLUMN `average_rating` DECIMAL(3,2) NOT NULL DEFAULT 0 AFTER `cooking_time`,
+    ADD COLUMN `ratings_count` INT(11) unsigned NOT NULL DEFAULT 0 AFTER `average_rating`,
+    ADD INDEX `idx_average_rating_receipts` (`average_rating`);
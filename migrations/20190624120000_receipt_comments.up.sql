@@ -0,0 +1,17 @@
+CREATE TABLE `receipt_comments` (
+    `id` INT(11) unsigned auto_increment,
+    `receipt_id` INT(11) unsigned NOT NULL,
+    `user_id` INT(11) NOT NULL,
+    `parent_id` INT(11) unsigned DEFAULT NULL,
+    `root_id` INT(11) unsigned DEFAULT NULL,
+    `body` TEXT NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` DATETIME DEFAULT NULL,
+    CONSTRAINT `fk_receipts_receipt_comments` FOREIGN KEY (`receipt_id`) REFERENCES receipts(`id`),
+    CONSTRAINT `fk_users_receipt_comments` FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+    CONSTRAINT `fk_parent_receipt_comments` FOREIGN KEY (`parent_id`) REFERENCES receipt_comments(`id`),
+    CONSTRAINT `fk_root_receipt_comments` FOREIGN KEY (`root_id`) REFERENCES receipt_comments(`id`),
+    INDEX `idx_receipt_root_receipt_comments` (`receipt_id`, `root_id`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
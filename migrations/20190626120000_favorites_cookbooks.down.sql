@@ -0,0 +1,3 @@
+DROP TABLE `cookbook_receipts`;
+DROP TABLE `cookbooks`;
+DROP TABLE `receipt_favorites`;
@@ -0,0 +1,34 @@
+CREATE TABLE `receipt_favorites` (
+    `id` INT(11) unsigned auto_increment,
+    `user_id` INT(11) NOT NULL,
+    `receipt_id` INT(11) unsigned NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT `fk_users_receipt_favorites` FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+    CONSTRAINT `fk_receipts_receipt_favorites` FOREIGN KEY (`receipt_id`) REFERENCES receipts(`id`),
+    UNIQUE KEY `uk_user_receipt_receipt_favorites` (`user_id`, `receipt_id`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE `cookbooks` (
+    `id` INT(11) unsigned auto_increment,
+    `user_id` INT(11) NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `description` VARCHAR(255) DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` DATETIME DEFAULT NULL,
+    CONSTRAINT `fk_users_cookbooks` FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE `cookbook_receipts` (
+    `id` INT(11) unsigned auto_increment,
+    `cookbook_id` INT(11) unsigned NOT NULL,
+    `receipt_id` INT(11) unsigned NOT NULL,
+    `position` INT(11) unsigned NOT NULL DEFAULT 0,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT `fk_cookbooks_cookbook_receipts` FOREIGN KEY (`cookbook_id`) REFERENCES cookbooks(`id`),
+    CONSTRAINT `fk_receipts_cookbook_receipts` FOREIGN KEY (`receipt_id`) REFERENCES receipts(`id`),
+    UNIQUE KEY `uk_cookbook_receipt_cookbook_receipts` (`cookbook_id`, `receipt_id`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
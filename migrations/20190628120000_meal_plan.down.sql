@@ -0,0 +1,5 @@
+ALTER TABLE `users`
+    DROP INDEX `uk_calendar_token_users`,
+    DROP COLUMN `calendar_token`;
+
+DROP TABLE `meal_plan_entries`;
@@ -0,0 +1,19 @@
+CREATE TABLE `meal_plan_entries` (
+    `id` INT(11) unsigned auto_increment,
+    `user_id` INT(11) NOT NULL,
+    `receipt_id` INT(11) unsigned NOT NULL,
+    `date` DATE NOT NULL,
+    `slot` VARCHAR(50) NOT NULL,
+    `servings` INT(11) unsigned NOT NULL DEFAULT 1,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` DATETIME DEFAULT NULL,
+    CONSTRAINT `fk_users_meal_plan_entries` FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+    CONSTRAINT `fk_receipts_meal_plan_entries` FOREIGN KEY (`receipt_id`) REFERENCES receipts(`id`),
+    INDEX `idx_user_date_meal_plan_entries` (`user_id`, `date`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+ALTER TABLE `users`
+    ADD COLUMN `calendar_token` VARCHAR(64) DEFAULT NULL AFTER `password`,
+    ADD UNIQUE KEY `uk_calendar_token_users` (`calendar_token`);
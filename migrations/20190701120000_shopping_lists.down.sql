@@ -0,0 +1,5 @@
+DROP TABLE `shopping_list_items`;
+DROP TABLE `shopping_lists`;
+
+ALTER TABLE `ingredients`
+    DROP COLUMN `category`;
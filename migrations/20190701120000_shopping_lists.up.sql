@@ -0,0 +1,30 @@
+ALTER TABLE `ingredients`
+    ADD COLUMN `category` VARCHAR(255) DEFAULT NULL AFTER `name`;
+
+CREATE TABLE `shopping_lists` (
+    `id` INT(11) unsigned auto_increment,
+    `user_id` INT(11) NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` DATETIME DEFAULT NULL,
+    CONSTRAINT `fk_users_shopping_lists` FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE `shopping_list_items` (
+    `id` INT(11) unsigned auto_increment,
+    `shopping_list_id` INT(11) unsigned NOT NULL,
+    `ingredient_id` INT(11) unsigned DEFAULT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `category` VARCHAR(255) DEFAULT NULL,
+    `quantity` VARCHAR(255) DEFAULT NULL,
+    `checked` TINYINT(1) NOT NULL DEFAULT 0,
+    `manual` TINYINT(1) NOT NULL DEFAULT 0,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` DATETIME DEFAULT NULL,
+    CONSTRAINT `fk_shopping_lists_shopping_list_items` FOREIGN KEY (`shopping_list_id`) REFERENCES shopping_lists(`id`),
+    CONSTRAINT `fk_ingredients_shopping_list_items` FOREIGN KEY (`ingredient_id`) REFERENCES ingredients(`id`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
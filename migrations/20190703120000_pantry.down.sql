@@ -0,0 +1 @@
+DROP TABLE `pantry_items`;
@@ -0,0 +1,14 @@
+CREATE TABLE `pantry_items` (
+    `id` INT(11) unsigned auto_increment,
+    `user_id` INT(11) NOT NULL,
+    `ingredient_id` INT(11) unsigned NOT NULL,
+    `quantity` VARCHAR(255) DEFAULT NULL,
+    `expires_at` DATE DEFAULT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` DATETIME DEFAULT NULL,
+    CONSTRAINT `fk_users_pantry_items` FOREIGN KEY (`user_id`) REFERENCES users(`id`),
+    CONSTRAINT `fk_ingredients_pantry_items` FOREIGN KEY (`ingredient_id`) REFERENCES ingredients(`id`),
+    INDEX `idx_user_expires_at_pantry_items` (`user_id`, `expires_at`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
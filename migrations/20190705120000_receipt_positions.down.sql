@@ -0,0 +1,7 @@
+ALTER TABLE `receipt_directions`
+    DROP INDEX `idx_receipt_position_receipt_directions`,
+    DROP COLUMN `position`;
+
+ALTER TABLE `receipt_ingredients`
+    DROP INDEX `idx_receipt_position_receipt_ingredients`,
+    DROP COLUMN `position`;
@@ -0,0 +1,32 @@
+ALTER TABLE `receipt_ingredients`
+    ADD COLUMN `position` INT(11) unsigned NOT NULL DEFAULT 0 AFTER `ingredient_id`;
+
+ALTER TABLE `receipt_directions`
+    ADD COLUMN `position` INT(11) unsigned NOT NULL DEFAULT 0 AFTER `receipt_id`;
+
+-- keep the current order of existing rows, which is the order of creation
+UPDATE `receipt_ingredients` ri
+    JOIN (
+        SELECT a.`id`, COUNT(b.`id`) AS `position`
+        FROM `receipt_ingredients` a
+        LEFT JOIN `receipt_ingredients` b
+            ON b.`receipt_id` = a.`receipt_id` AND b.`id` < a.`id` AND b.`deleted_at` IS NULL
+        GROUP BY a.`id`
+    ) p ON p.`id` = ri.`id`
+SET ri.`position` = p.`position`;
+
+UPDATE `receipt_directions` rd
+    JOIN (
+        SELECT a.`id`, COUNT(b.`id`) AS `position`
+        FROM `receipt_directions` a
+        LEFT JOIN `receipt_directions` b
+            ON b.`receipt_id` = a.`receipt_id` AND b.`id` < a.`id` AND b.`deleted_at` IS NULL
+        GROUP BY a.`id`
+    ) p ON p.`id` = rd.`id`
+SET rd.`position` = p.`position`;
+
+ALTER TABLE `receipt_ingredients`
+    ADD INDEX `idx_receipt_position_receipt_ingredients` (`receipt_id`, `position`);
+
+ALTER TABLE `receipt_directions`
+    ADD INDEX `idx_receipt_position_receipt_directions` (`receipt_id`, `position`);
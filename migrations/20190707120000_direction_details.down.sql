@@ -0,0 +1,10 @@
+ALTER TABLE `receipts`
+    DROP COLUMN `passive_time`,
+    DROP COLUMN `active_time`;
+
+ALTER TABLE `receipt_directions`
+    DROP FOREIGN KEY `fk_media_receipt_directions`,
+    DROP COLUMN `media_id`,
+    DROP COLUMN `temperature`,
+    DROP COLUMN `passive`,
+    DROP COLUMN `duration`;
@@ -0,0 +1,10 @@
+ALTER TABLE `receipt_directions`
+    ADD COLUMN `duration` INT(11) unsigned DEFAULT NULL AFTER `description`,
+    ADD COLUMN `passive` TINYINT(1) NOT NULL DEFAULT 0 AFTER `duration`,
+    ADD COLUMN `temperature` INT(11) DEFAULT NULL AFTER `passive`,
+    ADD COLUMN `media_id` INT(11) unsigned DEFAULT NULL AFTER `temperature`,
+    ADD CONSTRAINT `fk_media_receipt_directions` FOREIGN KEY (`media_id`) REFERENCES media(`id`);
+
+ALTER TABLE `receipts`
+    ADD COLUMN `active_time` INT(11) unsigned NOT NULL DEFAULT 0 AFTER `cooking_time`,
+    ADD COLUMN `passive_time` INT(11) unsigned NOT NULL DEFAULT 0 AFTER `active_time`;
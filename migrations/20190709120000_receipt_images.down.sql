@@ -0,0 +1 @@
+DROP TABLE `receipt_images`;
@@ -0,0 +1,20 @@
+CREATE TABLE `receipt_images` (
+    `id` INT(11) unsigned auto_increment,
+    `receipt_id` INT(11) unsigned NOT NULL,
+    `media_id` INT(11) unsigned NOT NULL,
+    `caption` VARCHAR(255) DEFAULT NULL,
+    `position` INT(11) unsigned NOT NULL DEFAULT 0,
+    `cover` TINYINT(1) NOT NULL DEFAULT 0,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `deleted_at` DATETIME DEFAULT NULL,
+    CONSTRAINT `fk_receipts_receipt_images` FOREIGN KEY (`receipt_id`) REFERENCES receipts(`id`),
+    CONSTRAINT `fk_media_receipt_images` FOREIGN KEY (`media_id`) REFERENCES media(`id`),
+    INDEX `idx_receipt_position_receipt_images` (`receipt_id`, `position`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- the only image of the receipt becomes the cover of its gallery,
+-- receipts.media_id keeps pointing to the cover
+INSERT INTO `receipt_images` (`receipt_id`, `media_id`, `position`, `cover`)
+SELECT `id`, `media_id`, 0, 1 FROM `receipts` WHERE `media_id` IS NOT NULL;
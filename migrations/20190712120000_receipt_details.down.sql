@@ -0,0 +1,16 @@
+ALTER TABLE `receipts`
+    ADD COLUMN `cooking_time` INT(11) NOT NULL DEFAULT 0 AFTER `category`;
+
+UPDATE `receipts` SET `cooking_time` = `total_time`;
+
+ALTER TABLE `receipts`
+    DROP INDEX `idx_course_receipts`,
+    DROP INDEX `idx_cuisine_receipts`,
+    DROP INDEX `idx_total_time_receipts`,
+    DROP COLUMN `course`,
+    DROP COLUMN `cuisine`,
+    DROP COLUMN `difficulty`,
+    DROP COLUMN `total_time`,
+    DROP COLUMN `rest_time`,
+    DROP COLUMN `cook_time`,
+    DROP COLUMN `prep_time`;
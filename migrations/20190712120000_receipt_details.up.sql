@@ -0,0 +1,17 @@
+ALTER TABLE `receipts`
+    ADD COLUMN `prep_time` INT(11) unsigned NOT NULL DEFAULT 0 AFTER `category`,
+    ADD COLUMN `cook_time` INT(11) unsigned NOT NULL DEFAULT 0 AFTER `prep_time`,
+    ADD COLUMN `rest_time` INT(11) unsigned NOT NULL DEFAULT 0 AFTER `cook_time`,
+    ADD COLUMN `total_time` INT(11) unsigned NOT NULL DEFAULT 0 AFTER `rest_time`,
+    ADD COLUMN `difficulty` VARCHAR(50) NOT NULL DEFAULT '' AFTER `total_time`,
+    ADD COLUMN `cuisine` VARCHAR(50) NOT NULL DEFAULT '' AFTER `difficulty`,
+    ADD COLUMN `course` VARCHAR(50) NOT NULL DEFAULT '' AFTER `cuisine`,
+    ADD INDEX `idx_total_time_receipts` (`total_time`),
+    ADD INDEX `idx_cuisine_receipts` (`cuisine`),
+    ADD INDEX `idx_course_receipts` (`course`);
+
+-- the only time receipts had was the cooking time
+UPDATE `receipts` SET `cook_time` = `cooking_time`, `total_time` = `cooking_time` WHERE `cooking_time` > 0;
+
+ALTER TABLE `receipts`
+    DROP COLUMN `cooking_time`;
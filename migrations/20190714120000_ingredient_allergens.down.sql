@@ -0,0 +1,12 @@
+ALTER TABLE `ingredients`
+    DROP COLUMN `gluten`,
+    DROP COLUMN `dairy`,
+    DROP COLUMN `eggs`,
+    DROP COLUMN `nuts`,
+    DROP COLUMN `peanuts`,
+    DROP COLUMN `soy`,
+    DROP COLUMN `fish`,
+    DROP COLUMN `shellfish`,
+    DROP COLUMN `sesame`,
+    DROP COLUMN `meat`,
+    DROP COLUMN `animal_product`;
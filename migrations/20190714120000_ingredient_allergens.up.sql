@@ -0,0 +1,12 @@
+ALTER TABLE `ingredients`
+    ADD COLUMN `gluten` TINYINT(1) NOT NULL DEFAULT 0 AFTER `category`,
+    ADD COLUMN `dairy` TINYINT(1) NOT NULL DEFAULT 0 AFTER `gluten`,
+    ADD COLUMN `eggs` TINYINT(1) NOT NULL DEFAULT 0 AFTER `dairy`,
+    ADD COLUMN `nuts` TINYINT(1) NOT NULL DEFAULT 0 AFTER `eggs`,
+    ADD COLUMN `peanuts` TINYINT(1) NOT NULL DEFAULT 0 AFTER `nuts`,
+    ADD COLUMN `soy` TINYINT(1) NOT NULL DEFAULT 0 AFTER `peanuts`,
+    ADD COLUMN `fish` TINYINT(1) NOT NULL DEFAULT 0 AFTER `soy`,
+    ADD COLUMN `shellfish` TINYINT(1) NOT NULL DEFAULT 0 AFTER `fish`,
+    ADD COLUMN `sesame` TINYINT(1) NOT NULL DEFAULT 0 AFTER `shellfish`,
+    ADD COLUMN `meat` TINYINT(1) NOT NULL DEFAULT 0 AFTER `sesame`,
+    ADD COLUMN `animal_product` TINYINT(1) NOT NULL DEFAULT 0 AFTER `meat`;
@@ -0,0 +1,6 @@
+DROP TABLE `ingredient_translations`;
+DROP TABLE `receipt_direction_translations`;
+DROP TABLE `receipt_translations`;
+
+ALTER TABLE `receipts`
+    DROP COLUMN `language`;
@@ -0,0 +1,41 @@
+ALTER TABLE `receipts`
+    ADD COLUMN `language` VARCHAR(10) NOT NULL DEFAULT '' AFTER `description`;
+
+CREATE TABLE `receipt_translations` (
+    `id` INT(11) unsigned auto_increment,
+    `receipt_id` INT(11) unsigned NOT NULL,
+    `language` VARCHAR(10) NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `description` VARCHAR(255) NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT `fk_receipts_receipt_translations` FOREIGN KEY (`receipt_id`) REFERENCES receipts(`id`),
+    UNIQUE KEY `uk_receipt_language_receipt_translations` (`receipt_id`, `language`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE `receipt_direction_translations` (
+    `id` INT(11) unsigned auto_increment,
+    `receipt_id` INT(11) unsigned NOT NULL,
+    `direction_id` INT(11) unsigned NOT NULL,
+    `language` VARCHAR(10) NOT NULL,
+    `description` TEXT NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT `fk_receipts_receipt_direction_translations` FOREIGN KEY (`receipt_id`) REFERENCES receipts(`id`),
+    CONSTRAINT `fk_receipt_directions_receipt_direction_translations` FOREIGN KEY (`direction_id`) REFERENCES receipt_directions(`id`),
+    UNIQUE KEY `uk_direction_language_receipt_direction_translations` (`direction_id`, `language`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE `ingredient_translations` (
+    `id` INT(11) unsigned auto_increment,
+    `ingredient_id` INT(11) unsigned NOT NULL,
+    `language` VARCHAR(10) NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `created_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT `fk_ingredients_ingredient_translations` FOREIGN KEY (`ingredient_id`) REFERENCES ingredients(`id`),
+    UNIQUE KEY `uk_ingredient_language_ingredient_translations` (`ingredient_id`, `language`),
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,3 @@
+-- receipts were visible to everyone before sharing, so the existing ones stay public
+ALTER TABLE `receipts`
+    ADD COLUMN `private` TINYINT(1) NOT NULL DEFAULT 0 AFTER `course`;
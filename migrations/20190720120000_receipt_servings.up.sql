@@ -0,0 +1,3 @@
+-- number of servings the ingredient quantities are given for, 0 when unknown
+ALTER TABLE `receipts`
+    ADD COLUMN `servings` INT(11) unsigned NOT NULL DEFAULT 0 AFTER `course`;
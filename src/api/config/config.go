@@ -5,8 +5,13 @@ import (
 	"github.com/go-sql-driver/mysql"
 	"log"
 	"os"
+	"strconv"
+	"time"
 )
 
+// deleted receipts are kept in trash for 30 days unless $TRASH_RETENTION_DAYS is set
+const defaultTrashRetentionDays = 30
+
 var conf Config
 
 type Config struct {
@@ -15,6 +20,10 @@ type Config struct {
 	Port            string
 	JwtKey          string
 	DSN             string
+	// lets receipt import fetch pages from loopback and private networks
+	ImportAllowPrivateHosts bool
+	// time after which deleted receipts are removed from trash permanently
+	TrashRetention time.Duration
 	initialized     bool
 }
 
@@ -74,6 +83,25 @@ func GetConfig() (config *Config) {
 		log.Fatal("$MYSQL_PORT should be set")
 	}
 
+	importAllowPrivateHosts := os.Getenv("IMPORT_ALLOW_PRIVATE_HOSTS")
+	if len(importAllowPrivateHosts) > 0 {
+		allow, err := strconv.ParseBool(importAllowPrivateHosts)
+		if err != nil {
+			log.Fatal("$IMPORT_ALLOW_PRIVATE_HOSTS should be a boolean")
+		}
+		conf.ImportAllowPrivateHosts = allow
+	}
+
+	conf.TrashRetention = defaultTrashRetentionDays * 24 * time.Hour
+	trashRetentionDays := os.Getenv("TRASH_RETENTION_DAYS")
+	if len(trashRetentionDays) > 0 {
+		days, err := strconv.Atoi(trashRetentionDays)
+		if err != nil || days < 1 {
+			log.Fatal("$TRASH_RETENTION_DAYS should be a positive number")
+		}
+		conf.TrashRetention = time.Duration(days) * 24 * time.Hour
+	}
+
 	dbConf := mysql.NewConfig()
 	dbConf.User = dbUser
 	dbConf.Passwd = dbPassword
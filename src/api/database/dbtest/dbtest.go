@@ -0,0 +1,81 @@
+// Package dbtest connects tests to the MySQL database given by TEST_MYSQL_DSN.
+// The database should have all migrations applied, tests are skipped when it is not set.
+package dbtest
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"food/src/api/models/user"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/mysql"
+)
+
+const DSNEnv = "TEST_MYSQL_DSN"
+
+// Open returns the connection to the empty test database, all tables except migrations are truncated.
+// The caller closes the connection.
+func Open(t *testing.T) *gorm.DB {
+	dsn := os.Getenv(DSNEnv)
+	if len(dsn) == 0 {
+		t.Skipf("%s is not set", DSNEnv)
+	}
+
+	db, err := gorm.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("cannot connect to test database: %v", err)
+	}
+	// foreign key checks are disabled for the session, so it should be the only one
+	db.DB().SetMaxOpenConns(1)
+
+	err = truncate(db)
+	if err != nil {
+		db.Close()
+		t.Fatalf("cannot clean test database: %v", err)
+	}
+	return db
+}
+
+func truncate(db *gorm.DB) (err error) {
+	rows, err := db.Raw("SHOW TABLES").Rows()
+	if err != nil {
+		return
+	}
+	var tables []string
+	for rows.Next() {
+		var table string
+		err = rows.Scan(&table)
+		if err != nil {
+			rows.Close()
+			return
+		}
+		if table != "schema_migrations" {
+			tables = append(tables, table)
+		}
+	}
+	rows.Close()
+
+	err = db.Exec("SET FOREIGN_KEY_CHECKS = 0").Error
+	if err != nil {
+		return
+	}
+	for _, table := range tables {
+		err = db.Exec(fmt.Sprintf("TRUNCATE TABLE `%s`", table)).Error
+		if err != nil {
+			return
+		}
+	}
+	err = db.Exec("SET FOREIGN_KEY_CHECKS = 1").Error
+	return
+}
+
+// CreateUser adds the user with the given name and returns its id.
+func CreateUser(t *testing.T, db *gorm.DB, username string) uint {
+	profile := user.Profile{Username: username, Password: "secret"}
+	err := db.Create(&profile).Error
+	if err != nil {
+		t.Fatalf("cannot create user `%s`: %v", username, err)
+	}
+	return profile.Id
+}
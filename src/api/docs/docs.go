@@ -1,6 +1,6 @@
 // GENERATED BY THE COMMAND ABOVE; DO NOT EDIT
 // This file was generated by swaggo/swag at
-// 2019-06-18 23:40:44.5300995 +0000 UTC m=+0.220815801
+// 2026-10-19 14:14:18.035154317 +0000 UTC m=+0.179885510
 
 package docs
 
@@ -23,6 +23,49 @@ var doc = `{
     "host": "api.food.test",
     "basePath": "/",
     "paths": {
+        "/calendar/{token}/meal-plan.ics": {
+            "get": {
+                "description": "authenticated by the secret token from the feed URL",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "meal plan"
+                ],
+                "summary": "Get meal plan iCalendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Calendar token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/user/signIn": {
             "post": {
                 "description": "get a user by params",
@@ -130,27 +173,27 @@ var doc = `{
                 }
             }
         },
-        "/v1/ingredients/": {
+        "/v1/calendar/meal-plan": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "find ingredients by params",
+                "description": "get secret URL of the iCalendar feed to subscribe to",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "receipts"
+                    "meal plan"
                 ],
-                "summary": "Get ingredients",
+                "summary": "Get meal plan calendar URL",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/handler.ListIngredientsAPIResponse"
+                            "$ref": "#/definitions/handler.MealPlanCalendarAPIResponse"
                         }
                     },
                     "400": {
@@ -175,38 +218,29 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/calendar/meal-plan/reset": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "generate new secret URL, the previous one stops working",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "receipts"
-                ],
-                "summary": "Create ingredient",
-                "parameters": [
-                    {
-                        "description": "params",
-                        "name": "ingredient",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/services.CreateIngredientRequest"
-                        }
-                    }
+                    "meal plan"
                 ],
+                "summary": "Reset meal plan calendar URL",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/handler.IngredientAPIResponse"
+                            "$ref": "#/definitions/handler.MealPlanCalendarAPIResponse"
                         }
                     },
                     "400": {
@@ -233,45 +267,27 @@ var doc = `{
                 }
             }
         },
-        "/v1/ingredients/{id}": {
-            "put": {
+        "/v1/cookbooks": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "find cookbooks of the current user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "receipts"
-                ],
-                "summary": "Update ingredient",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Ingredient id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "params",
-                        "name": "ingredient",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/services.UpdateIngredientRequest"
-                        }
-                    }
+                    "cookbooks"
                 ],
+                "summary": "Get cookbooks",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/handler.IngredientAPIResponse"
+                            "$ref": "#/definitions/handler.ListCookbookAPIResponse"
                         }
                     },
                     "400": {
@@ -296,50 +312,38 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/media/{folder}/{filename}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "get media",
                 "produces": [
-                    "image/png",
-                    "image/jpeg",
-                    "image/gif",
                     "application/json"
                 ],
                 "tags": [
-                    "media"
+                    "cookbooks"
                 ],
-                "summary": "Get media from DB",
+                "summary": "Create cookbook",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "1ac4f9a135d204e71ed41fa8accfbe42",
-                        "name": "folder",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "avatar.png",
-                        "name": "filename",
-                        "in": "path",
-                        "required": true
+                        "description": "params",
+                        "name": "cookbook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.CreateCookbookRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "integer"
-                            }
+                            "type": "object",
+                            "$ref": "#/definitions/handler.CookbookAPIResponse"
                         }
                     },
                     "400": {
@@ -366,27 +370,28 @@ var doc = `{
                 }
             }
         },
-        "/v1/receipts/": {
+        "/v1/cookbooks/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "find receipts by params",
+                "description": "get cookbook with its receipts in order",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "receipts"
+                    "cookbooks"
                 ],
-                "summary": "Get receipts",
+                "summary": "Get cookbook",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "category",
-                        "name": "category",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Cookbook id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -394,7 +399,7 @@ var doc = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/handler.ListAPIResponse"
+                            "$ref": "#/definitions/handler.CookbookAPIResponse"
                         }
                     },
                     "400": {
@@ -411,57 +416,8 @@ var doc = `{
                             "$ref": "#/definitions/handler.APIResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/handler.APIResponse"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "receipts"
-                ],
-                "summary": "Create receipt",
-                "parameters": [
-                    {
-                        "description": "params",
-                        "name": "ingredient",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/services.CreateReceiptRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/handler.ReceiptAPIResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/handler.APIResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "$ref": "#/definitions/handler.APIResponse"
@@ -475,9 +431,7 @@ var doc = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/receipts/{id}": {
+            },
             "put": {
                 "security": [
                     {
@@ -488,25 +442,25 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "receipts"
+                    "cookbooks"
                 ],
-                "summary": "Update receipt",
+                "summary": "Update cookbook",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Receipt id",
+                        "description": "Cookbook id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "description": "params",
-                        "name": "ingredient",
+                        "name": "cookbook",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/services.UpdateReceiptRequest"
+                            "$ref": "#/definitions/services.UpdateCookbookRequest"
                         }
                     }
                 ],
@@ -515,7 +469,7 @@ var doc = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/handler.ReceiptAPIResponse"
+                            "$ref": "#/definitions/handler.CookbookAPIResponse"
                         }
                     },
                     "400": {
@@ -558,13 +512,13 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "receipts"
+                    "cookbooks"
                 ],
-                "summary": "Delete receipt",
+                "summary": "Delete cookbook",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Receipt id",
+                        "description": "Cookbook id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -586,6 +540,13 @@ var doc = `{
                             "$ref": "#/definitions/handler.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -596,25 +557,25 @@ var doc = `{
                 }
             }
         },
-        "/v1/receipts/{id}/directions": {
+        "/v1/cookbooks/{id}/export.pdf": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "find receipt directions by params",
+                "description": "printable cookbook receipts with a table of contents",
                 "produces": [
-                    "application/json"
+                    "application/pdf"
                 ],
                 "tags": [
-                    "receipts"
+                    "cookbooks"
                 ],
-                "summary": "Get receipt directions",
+                "summary": "Export cookbook as PDF",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Receipt id",
+                        "description": "Cookbook id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -624,8 +585,10 @@ var doc = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "$ref": "#/definitions/handler.ListReceiptDirectionAPIResponse"
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
                         }
                     },
                     "400": {
@@ -642,6 +605,13 @@ var doc = `{
                             "$ref": "#/definitions/handler.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -650,36 +620,39 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/cookbooks/{id}/receipts": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "description": "insert receipt at given position or append it to the end",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "receipts"
+                    "cookbooks"
                 ],
-                "summary": "Create receipt direction",
+                "summary": "Add receipt to cookbook",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Receipt id",
+                        "description": "Cookbook id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "description": "params",
-                        "name": "ingredient",
+                        "name": "receipt",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/services.CreateReceiptDirectionRequest"
+                            "$ref": "#/definitions/services.AddCookbookReceiptRequest"
                         }
                     }
                 ],
@@ -688,7 +661,7 @@ var doc = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/handler.ReceiptDirectionAPIResponse"
+                            "$ref": "#/definitions/handler.CookbookAPIResponse"
                         }
                     },
                     "400": {
@@ -705,6 +678,13 @@ var doc = `{
                             "$ref": "#/definitions/handler.APIResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -715,7 +695,7 @@ var doc = `{
                 }
             }
         },
-        "/v1/receipts/{id}/directions/{direction_id}": {
+        "/v1/cookbooks/{id}/receipts/order": {
             "put": {
                 "security": [
                     {
@@ -726,32 +706,25 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "receipts"
+                    "cookbooks"
                 ],
-                "summary": "Update receipt direction",
+                "summary": "Reorder cookbook receipts",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Receipt id",
+                        "description": "Cookbook id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "integer",
-                        "description": "Receipt direction id",
-                        "name": "direction_id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "params",
-                        "name": "direction",
+                        "name": "order",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/services.UpdateReceiptDirectionRequest"
+                            "$ref": "#/definitions/services.ReorderCookbookReceiptsRequest"
                         }
                     }
                 ],
@@ -760,7 +733,7 @@ var doc = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/handler.ReceiptDirectionAPIResponse"
+                            "$ref": "#/definitions/handler.CookbookAPIResponse"
                         }
                     },
                     "400": {
@@ -792,7 +765,9 @@ var doc = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/cookbooks/{id}/receipts/{receipt_id}": {
             "delete": {
                 "security": [
                     {
@@ -803,21 +778,21 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "receipts"
+                    "cookbooks"
                 ],
-                "summary": "Delete receipt direction",
+                "summary": "Remove receipt from cookbook",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Receipt id",
+                        "description": "Cookbook id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Receipt direction id",
-                        "name": "direction_id",
+                        "description": "Receipt id",
+                        "name": "receipt_id",
                         "in": "path",
                         "required": true
                     }
@@ -855,28 +830,73 @@ var doc = `{
                 }
             }
         },
-        "/v1/receipts/{id}/ingredients": {
+        "/v1/favorites": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "find receipt ingredients by params",
+                "description": "find receipts favorited by the current user, recently added first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Get favorite receipts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListAPIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/ingredients/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find ingredients by params",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "receipts"
                 ],
-                "summary": "Get receipt ingredients",
+                "summary": "Get ingredients",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Receipt id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "language of translations, en, uk or original for untranslated items, Accept-Language is used when omitted",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "preferred languages of translations",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -884,7 +904,7 @@ var doc = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/handler.ListReceiptIngredientAPIResponse"
+                            "$ref": "#/definitions/handler.ListIngredientsAPIResponse"
                         }
                     },
                     "400": {
@@ -922,15 +942,8 @@ var doc = `{
                 "tags": [
                     "receipts"
                 ],
-                "summary": "Create receipt ingredient",
+                "summary": "Create ingredient",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Receipt id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "params",
                         "name": "ingredient",
@@ -938,7 +951,7 @@ var doc = `{
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/services.CreateReceiptIngredientRequest"
+                            "$ref": "#/definitions/services.CreateIngredientRequest"
                         }
                     }
                 ],
@@ -947,7 +960,7 @@ var doc = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/handler.ReceiptIngredientAPIResponse"
+                            "$ref": "#/definitions/handler.IngredientAPIResponse"
                         }
                     },
                     "400": {
@@ -974,7 +987,7 @@ var doc = `{
                 }
             }
         },
-        "/v1/receipts/{id}/ingredients/{ingredient_id}": {
+        "/v1/ingredients/{id}": {
             "put": {
                 "security": [
                     {
@@ -987,22 +1000,15 @@ var doc = `{
                 "tags": [
                     "receipts"
                 ],
-                "summary": "Update receipt",
+                "summary": "Update ingredient",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Receipt id",
+                        "description": "Ingredient id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "integer",
-                        "description": "Receipt ingredient id",
-                        "name": "ingredient_id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "params",
                         "name": "ingredient",
@@ -1010,7 +1016,7 @@ var doc = `{
                         "required": true,
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/services.UpdateReceiptIngredientRequest"
+                            "$ref": "#/definitions/services.UpdateIngredientRequest"
                         }
                     }
                 ],
@@ -1019,7 +1025,7 @@ var doc = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/handler.ReceiptIngredientAPIResponse"
+                            "$ref": "#/definitions/handler.IngredientAPIResponse"
                         }
                     },
                     "400": {
@@ -1036,8 +1042,56 @@ var doc = `{
                             "$ref": "#/definitions/handler.APIResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/ingredients/{id}/translations": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Get ingredient translations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Ingredient id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListIngredientTranslationAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "$ref": "#/definitions/handler.APIResponse"
@@ -1051,54 +1105,68 @@ var doc = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/ingredients/{id}/translations/{lang}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "receipts"
                 ],
-                "summary": "Delete receipt ingredient",
+                "summary": "Add or update ingredient translation",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Receipt id",
+                        "description": "Ingredient id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Receipt ingredient id",
-                        "name": "ingredient_id",
+                        "type": "string",
+                        "description": "Language, en or uk",
+                        "name": "lang",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.SaveIngredientTranslationRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {},
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
-                            "$ref": "#/definitions/handler.APIResponse"
+                            "$ref": "#/definitions/handler.IngredientTranslationAPIResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "$ref": "#/definitions/handler.APIResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "$ref": "#/definitions/handler.APIResponse"
@@ -1114,47 +1182,100 @@ var doc = `{
                 }
             }
         },
-        "/v1/receipts/{id}/media": {
-            "post": {
+        "/v1/meal-plan": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "upload a receipt media",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "find meal plan entries between given dates inclusively",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "receipts"
+                    "meal plan"
                 ],
-                "summary": "Update a receipt media",
+                "summary": "Get meal plan",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Receipt id",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "start date, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Media file",
-                        "name": "media_file",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "end date, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListMealPlanEntryAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "$ref": "#/definitions/handler.APIResponse"
                         }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "meal plan"
+                ],
+                "summary": "Add receipt to meal plan",
+                "parameters": [
+                    {
+                        "description": "params",
+                        "name": "entry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.CreateMealPlanEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.MealPlanEntryAPIResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1186,269 +1307,7846 @@ var doc = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handler.APIResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "description": "need fill only if error occurred",
-                    "type": "string"
-                }
-            }
         },
-        "handler.AuthAPIResponse": {
-            "type": "object",
+        "/v1/meal-plan/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "change date, slot or servings of the entry",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "meal plan"
+                ],
+                "summary": "Move meal plan entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Meal plan entry id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "entry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.UpdateMealPlanEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.MealPlanEntryAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "meal plan"
+                ],
+                "summary": "Delete meal plan entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Meal plan entry id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/meal-plan/{id}/copy": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "copy the entry to another date or slot",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "meal plan"
+                ],
+                "summary": "Copy meal plan entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Meal plan entry id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "entry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.CopyMealPlanEntryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.MealPlanEntryAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/media/{folder}/{filename}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get media",
+                "produces": [
+                    "image/png",
+                    "image/jpeg",
+                    "image/gif",
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Get media from DB",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "1ac4f9a135d204e71ed41fa8accfbe42",
+                        "name": "folder",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "avatar.png",
+                        "name": "filename",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/pantry": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find pantry items of the current user, the ones expiring first go first. Only items expiring within the given number of days are returned when 'expiring_within' is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pantry"
+                ],
+                "summary": "Get pantry items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Days",
+                        "name": "expiring_within",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListPantryItemAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pantry"
+                ],
+                "summary": "Add ingredient to pantry",
+                "parameters": [
+                    {
+                        "description": "params",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.CreatePantryItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.PantryItemAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/pantry/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "replace quantity and expiry date of the item",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pantry"
+                ],
+                "summary": "Update pantry item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Pantry item id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.UpdatePantryItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.PantryItemAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pantry"
+                ],
+                "summary": "Delete pantry item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Pantry item id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find public receipts and private ones owned by or shared with the current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Get receipts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "minimal average rating",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "easy, medium or hard",
+                        "name": "difficulty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cuisine",
+                        "name": "cuisine",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "course, like breakfast, main or dessert",
+                        "name": "course",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximal prep time in minutes",
+                        "name": "max_prep_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximal cook time in minutes",
+                        "name": "max_cook_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximal rest time in minutes",
+                        "name": "max_rest_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximal total time in minutes",
+                        "name": "max_total_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated diet labels all receipts should have: vegan, vegetarian, gluten-free or nut-free",
+                        "name": "diet",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated allergens no ingredient should have: gluten, dairy, eggs, nuts, peanuts, soy, fish, shellfish or sesame",
+                        "name": "exclude_allergens",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort by rating, ratings_count, created_at or total_time, prefix with '-' for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the cached response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of translations, en, uk or original for untranslated items, Accept-Language is used when omitted",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "preferred languages of translations",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListAPIResponse"
+                        }
+                    },
+                    "304": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "receipts with a similar name in the account are listed in 'duplicates', the receipt is created anyway",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Create receipt",
+                "parameters": [
+                    {
+                        "description": "params",
+                        "name": "ingredient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.CreateReceiptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/bulk-export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "zip archive contains receipts.json which can be imported back, receipts.csv and images",
+                "produces": [
+                    "application/zip",
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Export all own receipts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "'zip' (default), 'json' or 'csv'",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/services.BulkReceiptRecord"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/bulk-import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "accepts JSON array of receipts or zip archive with receipts.json and images it references. Every record is validated and reported separately, valid records are saved in batches, every batch in one transaction.",
+                "consumes": [
+                    "application/json",
+                    "application/zip"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Import many receipts",
+                "parameters": [
+                    {
+                        "description": "array of receipts",
+                        "name": "receipts",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.BulkReceiptRecord"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only validate the records",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "receipts saved in one transaction, 50 by default",
+                        "name": "batch_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.BulkImportAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find groups of receipts of the current user which are likely duplicates,\ncompared by name, ingredients and direction texts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Get duplicate receipts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListDuplicateClusterAPIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/full": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "whole receipt is created in one transaction, ingredients given by name are created when missing.\nLikely duplicates in the account are listed in 'duplicates', the receipt is created anyway",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Create receipt with ingredients and directions",
+                "parameters": [
+                    {
+                        "description": "params",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.FullReceiptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.FullReceiptAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "extract schema.org Recipe (JSON-LD or microdata) from the page at url or from the raw html, or read Cooklang-style text. Returns the preview unless save is set, then the receipt is created with its image and returned as handler.FullReceiptAPIResponse with likely duplicates in the account. Text can also be posted as 'text/plain' body with 'save' query param.",
+                "consumes": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Import receipt from web page",
+                "parameters": [
+                    {
+                        "description": "params",
+                        "name": "import",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.ImportReceiptRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "save plain-text receipt",
+                        "name": "save",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptImportPreviewAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Get receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the cached response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of translations, en, uk or original for untranslated items, Accept-Language is used when omitted",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "preferred languages of translations",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptAPIResponse"
+                        }
+                    },
+                    "304": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Update receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "ingredient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.UpdateReceiptRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the item, it is not changed when it has another one",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "move the receipt to trash, or delete it with its images permanently",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Delete receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "delete permanently instead of moving to trash",
+                        "name": "permanent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the item, it is not changed when it has another one",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "change only the fields given in the merge patch ('application/merge-patch+json')\nor by JSON Patch operations ('application/json-patch+json')",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Patch receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "fields to change",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.UpdateReceiptRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the receipt, it is not changed when it has another one",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/access": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "list users the receipt is shared with, only the owner can see them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Get receipt access grants",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListReceiptAccessAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/access/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "grant viewer or editor access to the receipt, only owner is permitted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Share receipt with user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "access",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.GrantReceiptAccessRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptAccessAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "revoke user access to the receipt, only owner is permitted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Stop sharing receipt with user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find receipt comment threads with nested replies, oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Get receipt comments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "threads per page, 100 max",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListReceiptCommentAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "create new thread or reply to the comment given by parent_id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Comment receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.CreateReceiptCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptCommentAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/comments/{comment_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Update receipt comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment id",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.UpdateReceiptCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptCommentAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "permitted to the comment author and the receipt owner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Delete receipt comment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Comment id",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/cooked": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "subtract receipt ingredients from the pantry, returns the updated pantry",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pantry"
+                ],
+                "summary": "Mark receipt as cooked",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListPantryItemAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/directions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find receipt directions by params",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Get receipt directions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the cached response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of translations, en, uk or original for untranslated items, Accept-Language is used when omitted",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "preferred languages of translations",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListReceiptDirectionAPIResponse"
+                        }
+                    },
+                    "304": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Create receipt direction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "ingredient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.CreateReceiptDirectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptDirectionAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/directions/order": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Reorder receipt directions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.ReorderReceiptDirectionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListReceiptDirectionAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/directions/{direction_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Update receipt direction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Receipt direction id",
+                        "name": "direction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "direction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.UpdateReceiptDirectionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the item, it is not changed when it has another one",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptDirectionAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Delete receipt direction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Receipt direction id",
+                        "name": "direction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the item, it is not changed when it has another one",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "change the fields given in the merge patch or by JSON Patch operations,\nnull clears 'duration' and 'temperature' in the merge patch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Patch receipt direction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Receipt direction id",
+                        "name": "direction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "fields to change",
+                        "name": "direction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.UpdateReceiptDirectionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the item, it is not changed when it has another one",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptDirectionAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/directions/{direction_id}/media": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Upload receipt step image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Direction id",
+                        "name": "direction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Media file",
+                        "name": "media_file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptDirectionAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/directions/{direction_id}/translations/{lang}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "description of the step in another language than the original one of the receipt",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Add or update receipt direction translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Receipt direction id",
+                        "name": "direction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language, en or uk",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.SaveDirectionTranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.DirectionTranslationAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "receipt is exported as schema.org Recipe in JSON-LD or as Cooklang-style plain text",
+                "produces": [
+                    "application/ld+json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Export receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "export format, 'jsonld' (default) or 'cooklang'",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/schemaorg.Document"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/export.pdf": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "printable receipt with photo, ingredients and numbered directions",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Export receipt as PDF",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/favorite": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Add receipt to favorites",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Remove receipt from favorites",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/full": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "schema.org Recipe is returned when 'application/ld+json' is accepted",
+                "produces": [
+                    "application/json",
+                    "application/ld+json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Get receipt with ingredients and directions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the cached response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of translations, en, uk or original for untranslated items, Accept-Language is used when omitted",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "preferred languages of translations",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.FullReceiptAPIResponse"
+                        }
+                    },
+                    "304": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "receipt fields, all ingredients and directions are replaced in one transaction, ingredients given by name are created when missing",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Replace receipt with ingredients and directions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.FullReceiptRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the item, it is not changed when it has another one",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.FullReceiptAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "edit the receipt with its ingredients and directions, like adding, removing or moving\nthe list items with JSON Patch ('application/json-patch+json'). The patched receipt is\nsaved like PUT /v1/receipts/{id}/full, ingredients refer to the existing ones by 'ingredient_id'",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Patch full receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "patch",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.FullReceiptRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the full receipt, it is not changed when it has another one",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.FullReceiptAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/images": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Get receipt gallery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListReceiptImageAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "the first image of the gallery always becomes the cover",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Add image to receipt gallery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Media file",
+                        "name": "media_file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caption",
+                        "name": "caption",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Zero based position, image is appended to the end when omitted",
+                        "name": "position",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Make the image the cover",
+                        "name": "cover",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptImageAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/images/order": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Reorder receipt images",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.ReorderReceiptImagesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListReceiptImageAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/images/{image_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "change the caption or make the image the cover",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Update receipt image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image id",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "image",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.UpdateReceiptImageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptImageAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "the first remaining image becomes the cover when the cover is deleted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Delete receipt image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image id",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/ingredients": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find receipt ingredients by params",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Get receipt ingredients",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the cached response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "language of translations, en, uk or original for untranslated items, Accept-Language is used when omitted",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "preferred languages of translations",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListReceiptIngredientAPIResponse"
+                        }
+                    },
+                    "304": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Create receipt ingredient",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "ingredient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.CreateReceiptIngredientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptIngredientAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/ingredients/order": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Reorder receipt ingredients",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.ReorderReceiptIngredientsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListReceiptIngredientAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/ingredients/{ingredient_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Update receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Receipt ingredient id",
+                        "name": "ingredient_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "ingredient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.UpdateReceiptIngredientRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the item, it is not changed when it has another one",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptIngredientAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Delete receipt ingredient",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Receipt ingredient id",
+                        "name": "ingredient_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the item, it is not changed when it has another one",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "change the fields given in the merge patch or by JSON Patch operations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Patch receipt ingredient",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Receipt ingredient id",
+                        "name": "ingredient_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "fields to change",
+                        "name": "ingredient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.UpdateReceiptIngredientRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity tag of the item, it is not changed when it has another one",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptIngredientAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/media": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "upload a receipt image, it is added to the gallery and becomes the cover",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Update a receipt media",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Media file",
+                        "name": "media_file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "move favorites, cookbook and meal plan entries, reviews, comments and images of the duplicates\nto the receipt, then move the duplicates to trash. All receipts should be owned by the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Merge duplicate receipts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.MergeReceiptsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "restore the deleted receipt with its ingredients, directions and images",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore receipt from trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find receipt reviews, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Get receipt reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListReceiptReviewAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "one review per user, own receipts cannot be rated",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Rate and review receipt",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.CreateReceiptReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptReviewAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/reviews/{review_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Update receipt review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Review id",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.UpdateReceiptReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptReviewAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Delete receipt review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Review id",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/similar": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find other receipts visible to the current user which are similar to the receipt\nby ingredients (rare ones weigh more), category, tags (cuisine, course and diet labels) and total time, the most similar first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Get similar receipts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of receipts, 10 by default, 50 max",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListSimilarReceiptAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/translations": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "translations of the receipt name, description and directions to all languages",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Get receipt translations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptTranslationsAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/receipts/{id}/translations/{lang}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "name and description of the receipt in another language than the original one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "receipts"
+                ],
+                "summary": "Add or update receipt translation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language, en or uk",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.SaveReceiptTranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ReceiptTranslationAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/shopping-lists": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find shopping lists of the current user, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping lists"
+                ],
+                "summary": "Get shopping lists",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListShoppingListAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "aggregate ingredients of given receipts and meal plan entries in the date range, converting compatible units",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping lists"
+                ],
+                "summary": "Generate shopping list",
+                "parameters": [
+                    {
+                        "description": "params",
+                        "name": "list",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.CreateShoppingListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ShoppingListAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/shopping-lists/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "get shopping list with items grouped by category",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping lists"
+                ],
+                "summary": "Get shopping list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shopping list id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ShoppingListAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping lists"
+                ],
+                "summary": "Delete shopping list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shopping list id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/shopping-lists/{id}/items": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping lists"
+                ],
+                "summary": "Add extra item to shopping list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shopping list id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.CreateShoppingListItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ShoppingListItemAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/shopping-lists/{id}/items/{item_id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "check or uncheck the item and optionally change its quantity",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping lists"
+                ],
+                "summary": "Tick shopping list item off",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shopping list id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Shopping list item id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "params",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/services.UpdateShoppingListItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ShoppingListItemAPIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping lists"
+                ],
+                "summary": "Delete shopping list item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shopping list id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Shopping list item id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/trash": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "find deleted receipts of the current user, they are deleted permanently at 'purge_at'",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Get trash",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.ListTrashAPIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/trash/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "delete the receipt in trash with everything related to it, it cannot be restored",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Delete receipt permanently",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Receipt id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {},
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "$ref": "#/definitions/handler.APIResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "cookbook.Cookbook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "receipts": {
+                    "description": "ordered by position",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cookbook.CookbookReceipt"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "cookbook.CookbookReceipt": {
+            "type": "object",
+            "properties": {
+                "cookbook_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "position": {
+                    "description": "zero based position of the receipt in the cookbook",
+                    "type": "integer"
+                },
+                "receipt": {
+                    "type": "object",
+                    "$ref": "#/definitions/receipt.Receipt"
+                },
+                "receipt_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.APIResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AuthAPIResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BulkImportAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/services.BulkImportResult"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CookbookAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/cookbook.Cookbook"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.DirectionTranslationAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/receipt.DirectionTranslation"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.FullReceiptAPIResponse": {
+            "type": "object",
+            "properties": {
+                "duplicates": {
+                    "description": "likely duplicates in the account, returned when the receipt is created or imported",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.Duplicate"
+                    }
+                },
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/receipt.FullReceipt"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.IngredientAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/ingredient.Ingredient"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.IngredientTranslationAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/ingredient.Translation"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.Receipt"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListCookbookAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cookbook.Cookbook"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListDuplicateClusterAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.DuplicateCluster"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListIngredientTranslationAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ingredient.Translation"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListIngredientsAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ingredient.Ingredient"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListMealPlanEntryAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/mealplan.Entry"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListPantryItemAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pantry.Item"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListReceiptAccessAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.ReceiptAccess"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListReceiptCommentAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.ReceiptComment"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                },
+                "pagination": {
+                    "type": "object",
+                    "$ref": "#/definitions/tools.Pagination"
+                }
+            }
+        },
+        "handler.ListReceiptDirectionAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.ReceiptDirection"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListReceiptImageAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.ReceiptImage"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListReceiptIngredientAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.ReceiptIngredient"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListReceiptReviewAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.ReceiptReview"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListShoppingListAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/shopping.List"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListSimilarReceiptAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.SimilarReceipt"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ListTrashAPIResponse": {
+            "type": "object",
+            "properties": {
+                "list": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.TrashItem"
+                    }
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.MealPlanCalendarAPIResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "secret iCalendar feed URL, anyone who knows it can read the meal plan",
+                    "type": "string",
+                    "example": "https://api.food.test/calendar/4f1c.../meal-plan.ics"
+                }
+            }
+        },
+        "handler.MealPlanEntryAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/mealplan.Entry"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.PantryItemAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/pantry.Item"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReceiptAPIResponse": {
+            "type": "object",
+            "properties": {
+                "duplicates": {
+                    "description": "likely duplicates in the account, returned when the receipt is created",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.Duplicate"
+                    }
+                },
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/receipt.Receipt"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReceiptAccessAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/receipt.ReceiptAccess"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReceiptCommentAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/receipt.ReceiptComment"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReceiptDirectionAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/receipt.ReceiptDirection"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReceiptImageAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/receipt.ReceiptImage"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReceiptImportPreviewAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/services.ReceiptImportPreview"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReceiptIngredientAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/receipt.ReceiptIngredient"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReceiptReviewAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/receipt.ReceiptReview"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReceiptTranslationAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/receipt.ReceiptTranslation"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReceiptTranslationsAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/receipt.Translations"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ShoppingListAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/shopping.List"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ShoppingListItemAPIResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "type": "object",
+                    "$ref": "#/definitions/shopping.Item"
+                },
+                "message": {
+                    "description": "need fill only if error occurred",
+                    "type": "string"
+                }
+            }
+        },
+        "ingredient.Ingredient": {
+            "type": "object",
+            "properties": {
+                "animal_product": {
+                    "description": "other products of animal origin, like honey",
+                    "type": "boolean"
+                },
+                "category": {
+                    "description": "used to group shopping list items, like 'dairy' or 'vegetables'",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dairy": {
+                    "type": "boolean"
+                },
+                "eggs": {
+                    "type": "boolean"
+                },
+                "fish": {
+                    "type": "boolean"
+                },
+                "gluten": {
+                    "description": "allergens",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "meat": {
+                    "description": "meat or poultry",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nuts": {
+                    "description": "tree nuts",
+                    "type": "boolean"
+                },
+                "peanuts": {
+                    "type": "boolean"
+                },
+                "sesame": {
+                    "type": "boolean"
+                },
+                "shellfish": {
+                    "type": "boolean"
+                },
+                "soy": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "ingredient.Translation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ingredient_id": {
+                    "type": "integer"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "mealplan.Entry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2019-06-28T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "receipt": {
+                    "type": "object",
+                    "$ref": "#/definitions/receipt.Receipt"
+                },
+                "receipt_id": {
+                    "type": "integer"
+                },
+                "servings": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "slot": {
+                    "description": "breakfast, lunch or dinner",
+                    "type": "string",
+                    "example": "dinner"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "media.Media": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "format (read only) (required)",
+                    "type": "string",
+                    "example": "image/png"
+                },
+                "link": {
+                    "description": "media link (read only) (required)",
+                    "type": "string",
+                    "example": "ac146571eb55d9cbde1a886dbc1f85d2/event.png"
+                }
+            }
+        },
+        "pantry.Item": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "empty when the item does not expire",
+                    "type": "string",
+                    "example": "2019-07-10T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ingredient": {
+                    "type": "object",
+                    "$ref": "#/definitions/ingredient.Ingredient"
+                },
+                "ingredient_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "string",
+                    "example": "500 g"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "receipt.DirectionTranslation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "direction_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "receipt_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "receipt.Duplicate": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "receipt_id": {
+                    "type": "integer"
+                },
+                "score": {
+                    "description": "similarity from 0 to 1",
+                    "type": "number",
+                    "example": 0.92
+                }
+            }
+        },
+        "receipt.DuplicateCluster": {
+            "type": "object",
+            "properties": {
+                "receipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.Receipt"
+                    }
+                },
+                "score": {
+                    "description": "lowest similarity of the receipts linked in the cluster",
+                    "type": "number",
+                    "example": 0.85
+                }
+            }
+        },
+        "receipt.FullReceipt": {
+            "type": "object",
+            "properties": {
+                "active_time": {
+                    "description": "sum of active step durations in minutes (read only)",
+                    "type": "integer"
+                },
+                "average_rating": {
+                    "description": "average of all review ratings (read only)",
+                    "type": "number",
+                    "example": 4.5
+                },
+                "category": {
+                    "type": "string"
+                },
+                "cook_time": {
+                    "description": "cooking time in minutes",
+                    "type": "integer",
+                    "example": 40
+                },
+                "cooking_time": {
+                    "description": "deprecated, the total time kept for older clients (read only)",
+                    "type": "integer",
+                    "example": 60
+                },
+                "course": {
+                    "description": "like breakfast, main or dessert",
+                    "type": "string",
+                    "example": "main"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "cuisine": {
+                    "type": "string",
+                    "example": "italian"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "description": "easy, medium or hard",
+                    "type": "string",
+                    "example": "easy"
+                },
+                "directions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.ReceiptDirection"
+                    }
+                },
+                "favorited": {
+                    "description": "whether the receipt is in favorites of the current user (read only)",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.ReceiptImage"
+                    }
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.ReceiptIngredient"
+                    }
+                },
+                "labels": {
+                    "description": "diet labels computed from the ingredients, like vegan or gluten-free (read only)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vegan",
+                        "nut-free"
+                    ]
+                },
+                "language": {
+                    "description": "language of the name, description and directions, translated ones when a translation is requested",
+                    "type": "string",
+                    "example": "uk"
+                },
+                "media": {
+                    "type": "object",
+                    "$ref": "#/definitions/media.Media"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "passive_time": {
+                    "description": "sum of passive step durations in minutes (read only)",
+                    "type": "integer"
+                },
+                "prep_time": {
+                    "description": "preparation time in minutes",
+                    "type": "integer",
+                    "example": 15
+                },
+                "private": {
+                    "description": "private receipts are visible to the owner and users it is shared with only",
+                    "type": "boolean"
+                },
+                "ratings_count": {
+                    "description": "number of review ratings (read only)",
+                    "type": "integer"
+                },
+                "rest_time": {
+                    "description": "resting time in minutes, like cooling or marinating",
+                    "type": "integer",
+                    "example": 5
+                },
+                "servings": {
+                    "description": "number of servings the ingredient quantities are given for, 0 when unknown",
+                    "type": "integer",
+                    "example": 4
+                },
+                "total_time": {
+                    "description": "sum of prep, cook and rest time in minutes (read only)",
+                    "type": "integer",
+                    "example": 60
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "receipt.Receipt": {
+            "type": "object",
+            "properties": {
+                "active_time": {
+                    "description": "sum of active step durations in minutes (read only)",
+                    "type": "integer"
+                },
+                "average_rating": {
+                    "description": "average of all review ratings (read only)",
+                    "type": "number",
+                    "example": 4.5
+                },
+                "category": {
+                    "type": "string"
+                },
+                "cook_time": {
+                    "description": "cooking time in minutes",
+                    "type": "integer",
+                    "example": 40
+                },
+                "cooking_time": {
+                    "description": "deprecated, the total time kept for older clients (read only)",
+                    "type": "integer",
+                    "example": 60
+                },
+                "course": {
+                    "description": "like breakfast, main or dessert",
+                    "type": "string",
+                    "example": "main"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "cuisine": {
+                    "type": "string",
+                    "example": "italian"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "description": "easy, medium or hard",
+                    "type": "string",
+                    "example": "easy"
+                },
+                "favorited": {
+                    "description": "whether the receipt is in favorites of the current user (read only)",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "description": "diet labels computed from the ingredients, like vegan or gluten-free (read only)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vegan",
+                        "nut-free"
+                    ]
+                },
+                "language": {
+                    "description": "language of the name, description and directions, translated ones when a translation is requested",
+                    "type": "string",
+                    "example": "uk"
+                },
+                "media": {
+                    "type": "object",
+                    "$ref": "#/definitions/media.Media"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "passive_time": {
+                    "description": "sum of passive step durations in minutes (read only)",
+                    "type": "integer"
+                },
+                "prep_time": {
+                    "description": "preparation time in minutes",
+                    "type": "integer",
+                    "example": 15
+                },
+                "private": {
+                    "description": "private receipts are visible to the owner and users it is shared with only",
+                    "type": "boolean"
+                },
+                "ratings_count": {
+                    "description": "number of review ratings (read only)",
+                    "type": "integer"
+                },
+                "rest_time": {
+                    "description": "resting time in minutes, like cooling or marinating",
+                    "type": "integer",
+                    "example": 5
+                },
+                "servings": {
+                    "description": "number of servings the ingredient quantities are given for, 0 when unknown",
+                    "type": "integer",
+                    "example": 4
+                },
+                "total_time": {
+                    "description": "sum of prep, cook and rest time in minutes (read only)",
+                    "type": "integer",
+                    "example": 60
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "receipt.ReceiptAccess": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "receipt_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "viewer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "receipt.ReceiptComment": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted": {
+                    "description": "deleted comments are kept as placeholders without body, so replies stay in place",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "receipt_id": {
+                    "type": "integer"
+                },
+                "replies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.ReceiptComment"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "receipt.ReceiptDirection": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "step duration in minutes",
+                    "type": "integer",
+                    "example": 15
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "media": {
+                    "type": "object",
+                    "$ref": "#/definitions/media.Media"
+                },
+                "passive": {
+                    "description": "waiting step which needs no attention, like baking or resting",
+                    "type": "boolean"
+                },
+                "position": {
+                    "description": "zero based step number",
+                    "type": "integer"
+                },
+                "receipt_id": {
+                    "type": "integer"
+                },
+                "temperature": {
+                    "description": "oven temperature in Celsius degrees",
+                    "type": "integer",
+                    "example": 180
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "receipt.ReceiptImage": {
+            "type": "object",
+            "properties": {
+                "caption": {
+                    "type": "string"
+                },
+                "cover": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "media": {
+                    "type": "object",
+                    "$ref": "#/definitions/media.Media"
+                },
+                "position": {
+                    "description": "zero based position in the gallery",
+                    "type": "integer"
+                },
+                "receipt_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "receipt.ReceiptIngredient": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ingredient": {
+                    "type": "object",
+                    "$ref": "#/definitions/ingredient.Ingredient"
+                },
+                "ingredient_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "description": "zero based position in the receipt",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "string"
+                },
+                "receipt_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "receipt.ReceiptReview": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "receipt_id": {
+                    "type": "integer"
+                },
+                "review": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "receipt.ReceiptTranslation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "receipt_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "receipt.SimilarReceipt": {
+            "type": "object",
+            "properties": {
+                "active_time": {
+                    "description": "sum of active step durations in minutes (read only)",
+                    "type": "integer"
+                },
+                "average_rating": {
+                    "description": "average of all review ratings (read only)",
+                    "type": "number",
+                    "example": 4.5
+                },
+                "category": {
+                    "type": "string"
+                },
+                "cook_time": {
+                    "description": "cooking time in minutes",
+                    "type": "integer",
+                    "example": 40
+                },
+                "cooking_time": {
+                    "description": "deprecated, the total time kept for older clients (read only)",
+                    "type": "integer",
+                    "example": 60
+                },
+                "course": {
+                    "description": "like breakfast, main or dessert",
+                    "type": "string",
+                    "example": "main"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "cuisine": {
+                    "type": "string",
+                    "example": "italian"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "description": "easy, medium or hard",
+                    "type": "string",
+                    "example": "easy"
+                },
+                "favorited": {
+                    "description": "whether the receipt is in favorites of the current user (read only)",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "description": "diet labels computed from the ingredients, like vegan or gluten-free (read only)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vegan",
+                        "nut-free"
+                    ]
+                },
+                "language": {
+                    "description": "language of the name, description and directions, translated ones when a translation is requested",
+                    "type": "string",
+                    "example": "uk"
+                },
+                "media": {
+                    "type": "object",
+                    "$ref": "#/definitions/media.Media"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "passive_time": {
+                    "description": "sum of passive step durations in minutes (read only)",
+                    "type": "integer"
+                },
+                "prep_time": {
+                    "description": "preparation time in minutes",
+                    "type": "integer",
+                    "example": 15
+                },
+                "private": {
+                    "description": "private receipts are visible to the owner and users it is shared with only",
+                    "type": "boolean"
+                },
+                "ratings_count": {
+                    "description": "number of review ratings (read only)",
+                    "type": "integer"
+                },
+                "rest_time": {
+                    "description": "resting time in minutes, like cooling or marinating",
+                    "type": "integer",
+                    "example": 5
+                },
+                "servings": {
+                    "description": "number of servings the ingredient quantities are given for, 0 when unknown",
+                    "type": "integer",
+                    "example": 4
+                },
+                "similarity": {
+                    "description": "similarity from 0 to 1",
+                    "type": "number",
+                    "example": 0.64
+                },
+                "total_time": {
+                    "description": "sum of prep, cook and rest time in minutes (read only)",
+                    "type": "integer",
+                    "example": 60
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "receipt.Translations": {
+            "type": "object",
+            "properties": {
+                "directions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.DirectionTranslation"
+                    }
+                },
+                "receipt": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/receipt.ReceiptTranslation"
+                    }
+                }
+            }
+        },
+        "receipt.TrashItem": {
+            "type": "object",
+            "properties": {
+                "active_time": {
+                    "description": "sum of active step durations in minutes (read only)",
+                    "type": "integer"
+                },
+                "average_rating": {
+                    "description": "average of all review ratings (read only)",
+                    "type": "number",
+                    "example": 4.5
+                },
+                "category": {
+                    "type": "string"
+                },
+                "cook_time": {
+                    "description": "cooking time in minutes",
+                    "type": "integer",
+                    "example": 40
+                },
+                "cooking_time": {
+                    "description": "deprecated, the total time kept for older clients (read only)",
+                    "type": "integer",
+                    "example": 60
+                },
+                "course": {
+                    "description": "like breakfast, main or dessert",
+                    "type": "string",
+                    "example": "main"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "cuisine": {
+                    "type": "string",
+                    "example": "italian"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "description": "easy, medium or hard",
+                    "type": "string",
+                    "example": "easy"
+                },
+                "favorited": {
+                    "description": "whether the receipt is in favorites of the current user (read only)",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "description": "diet labels computed from the ingredients, like vegan or gluten-free (read only)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vegan",
+                        "nut-free"
+                    ]
+                },
+                "language": {
+                    "description": "language of the name, description and directions, translated ones when a translation is requested",
+                    "type": "string",
+                    "example": "uk"
+                },
+                "media": {
+                    "type": "object",
+                    "$ref": "#/definitions/media.Media"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "passive_time": {
+                    "description": "sum of passive step durations in minutes (read only)",
+                    "type": "integer"
+                },
+                "prep_time": {
+                    "description": "preparation time in minutes",
+                    "type": "integer",
+                    "example": 15
+                },
+                "private": {
+                    "description": "private receipts are visible to the owner and users it is shared with only",
+                    "type": "boolean"
+                },
+                "purge_at": {
+                    "description": "time when the receipt is deleted permanently",
+                    "type": "string"
+                },
+                "ratings_count": {
+                    "description": "number of review ratings (read only)",
+                    "type": "integer"
+                },
+                "rest_time": {
+                    "description": "resting time in minutes, like cooling or marinating",
+                    "type": "integer",
+                    "example": 5
+                },
+                "servings": {
+                    "description": "number of servings the ingredient quantities are given for, 0 when unknown",
+                    "type": "integer",
+                    "example": 4
+                },
+                "total_time": {
+                    "description": "sum of prep, cook and rest time in minutes (read only)",
+                    "type": "integer",
+                    "example": 60
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schemaorg.AggregateRating": {
+            "type": "object",
+            "properties": {
+                "@type": {
+                    "type": "string"
+                },
+                "ratingCount": {
+                    "type": "integer"
+                },
+                "ratingValue": {
+                    "type": "number"
+                }
+            }
+        },
+        "schemaorg.Document": {
+            "type": "object",
+            "properties": {
+                "@context": {
+                    "type": "string"
+                },
+                "@type": {
+                    "type": "string"
+                },
+                "aggregateRating": {
+                    "type": "object",
+                    "$ref": "#/definitions/schemaorg.AggregateRating"
+                },
+                "cookTime": {
+                    "type": "string"
+                },
+                "dateCreated": {
+                    "type": "string"
+                },
+                "dateModified": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "identifier": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prepTime": {
+                    "type": "string"
+                },
+                "recipeCategory": {
+                    "type": "string"
+                },
+                "recipeCuisine": {
+                    "type": "string"
+                },
+                "recipeIngredient": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "recipeInstructions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemaorg.HowToStep"
+                    }
+                },
+                "suitableForDiet": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "totalTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemaorg.HowToStep": {
+            "type": "object",
+            "properties": {
+                "@type": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.AddCookbookReceiptRequest": {
+            "type": "object",
+            "required": [
+                "receipt_id"
+            ],
+            "properties": {
+                "position": {
+                    "description": "zero based position, receipt is appended to the end when omitted",
+                    "type": "integer"
+                },
+                "receipt_id": {
+                    "description": "(required)",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "services.BulkImportRecordResult": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "index": {
+                    "description": "zero based index of the record in the document",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "receipt_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "valid, imported, invalid or failed",
+                    "type": "string",
+                    "example": "imported"
+                },
+                "warnings": {
+                    "description": "likely duplicates of receipts in the account or of previous records, they are imported anyway",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "services.BulkImportResult": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "imported": {
+                    "description": "number of valid records for dry runs",
+                    "type": "integer"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.BulkImportRecordResult"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.BulkReceiptImage": {
+            "type": "object",
+            "required": [
+                "file"
+            ],
+            "properties": {
+                "caption": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "file": {
+                    "description": "path of the image file in the zip archive (required)",
+                    "type": "string",
+                    "example": "images/12/1.jpg"
+                }
+            }
+        },
+        "services.BulkReceiptRecord": {
+            "type": "object",
+            "required": [
+                "category",
+                "description",
+                "name"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "cook_time": {
+                    "description": "cooking time in minutes",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "cooking_time": {
+                    "description": "deprecated, total cooking time in minutes, the cook time is changed to make it up when it differs from the current total",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "course": {
+                    "description": "like breakfast, main or dessert",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "cuisine": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "difficulty": {
+                    "type": "string",
+                    "enum": [
+                        "easy",
+                        "medium",
+                        "hard"
+                    ]
+                },
+                "directions": {
+                    "description": "steps in their order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.FullReceiptDirectionRequest"
+                    }
+                },
+                "images": {
+                    "description": "gallery of zip archives in its order, the first image is the cover",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.BulkReceiptImage"
+                    }
+                },
+                "ingredients": {
+                    "description": "ingredients in their order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.FullReceiptIngredientRequest"
+                    }
+                },
+                "language": {
+                    "description": "language of the name, description and directions",
+                    "type": "string",
+                    "enum": [
+                        "en",
+                        "uk"
+                    ]
+                },
+                "name": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "prep_time": {
+                    "description": "preparation time in minutes",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "private": {
+                    "description": "visible to the owner and users it is shared with only, kept when omitted",
+                    "type": "boolean"
+                },
+                "rest_time": {
+                    "description": "resting time in minutes, like cooling or marinating",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "servings": {
+                    "description": "number of servings the ingredient quantities are given for",
+                    "type": "integer",
+                    "maximum": 1000
+                }
+            }
+        },
+        "services.CopyMealPlanEntryRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "slot"
+            ],
+            "properties": {
+                "date": {
+                    "description": "(required)",
+                    "type": "string",
+                    "example": "2019-06-28"
+                },
+                "slot": {
+                    "description": "breakfast, lunch or dinner (required)",
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner"
+                    ]
+                }
+            }
+        },
+        "services.CreateCookbookRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                }
+            }
+        },
+        "services.CreateIngredientRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "animal_product": {
+                    "description": "other products of animal origin, like honey",
+                    "type": "boolean"
+                },
+                "category": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "dairy": {
+                    "type": "boolean"
+                },
+                "eggs": {
+                    "type": "boolean"
+                },
+                "fish": {
+                    "type": "boolean"
+                },
+                "gluten": {
+                    "description": "allergens",
+                    "type": "boolean"
+                },
+                "meat": {
+                    "description": "meat or poultry",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "nuts": {
+                    "description": "tree nuts",
+                    "type": "boolean"
+                },
+                "peanuts": {
+                    "type": "boolean"
+                },
+                "sesame": {
+                    "type": "boolean"
+                },
+                "shellfish": {
+                    "type": "boolean"
+                },
+                "soy": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "services.CreateMealPlanEntryRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "receipt_id",
+                "servings",
+                "slot"
+            ],
+            "properties": {
+                "date": {
+                    "description": "(required)",
+                    "type": "string",
+                    "example": "2019-06-28"
+                },
+                "receipt_id": {
+                    "description": "(required)",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "servings": {
+                    "description": "(required)",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "slot": {
+                    "description": "breakfast, lunch or dinner (required)",
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner"
+                    ]
+                }
+            }
+        },
+        "services.CreatePantryItemRequest": {
+            "type": "object",
+            "required": [
+                "ingredient_id"
+            ],
+            "properties": {
+                "expires_at": {
+                    "description": "YYYY-MM-DD, empty when the item does not expire",
+                    "type": "string",
+                    "example": "2019-07-10"
+                },
+                "ingredient_id": {
+                    "description": "(required)",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "quantity": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "500 g"
+                }
+            }
+        },
+        "services.CreateReceiptCommentRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 4096,
+                    "minLength": 1
+                },
+                "parent_id": {
+                    "description": "id of the comment to reply to",
+                    "type": "integer"
+                }
+            }
+        },
+        "services.CreateReceiptDirectionRequest": {
+            "type": "object",
+            "required": [
+                "description"
+            ],
+            "properties": {
+                "description": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "duration": {
+                    "description": "step duration in minutes",
+                    "type": "integer",
+                    "maximum": 10080,
+                    "minimum": 1
+                },
+                "passive": {
+                    "description": "waiting step which needs no attention, like baking or resting",
+                    "type": "boolean"
+                },
+                "position": {
+                    "description": "zero based position, step is appended to the end when omitted",
+                    "type": "integer"
+                },
+                "temperature": {
+                    "description": "oven temperature in Celsius degrees",
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 1
+                }
+            }
+        },
+        "services.CreateReceiptIngredientRequest": {
+            "type": "object",
+            "required": [
+                "ingredient_id",
+                "quantity"
+            ],
+            "properties": {
+                "ingredient_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "position": {
+                    "description": "zero based position, ingredient is appended to the end when omitted",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                }
+            }
+        },
+        "services.CreateReceiptRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "description",
+                "name"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "cook_time": {
+                    "description": "cooking time in minutes",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "cooking_time": {
+                    "description": "deprecated, total cooking time in minutes, the cook time is changed to make it up when it differs from the current total",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "course": {
+                    "description": "like breakfast, main or dessert",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "cuisine": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "difficulty": {
+                    "type": "string",
+                    "enum": [
+                        "easy",
+                        "medium",
+                        "hard"
+                    ]
+                },
+                "language": {
+                    "description": "language of the name, description and directions",
+                    "type": "string",
+                    "enum": [
+                        "en",
+                        "uk"
+                    ]
+                },
+                "name": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "prep_time": {
+                    "description": "preparation time in minutes",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "private": {
+                    "description": "visible to the owner and users it is shared with only, kept when omitted",
+                    "type": "boolean"
+                },
+                "rest_time": {
+                    "description": "resting time in minutes, like cooling or marinating",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "servings": {
+                    "description": "number of servings the ingredient quantities are given for",
+                    "type": "integer",
+                    "maximum": 1000
+                }
+            }
+        },
+        "services.CreateReceiptReviewRequest": {
+            "type": "object",
+            "required": [
+                "rating"
+            ],
             "properties": {
-                "access_token": {
-                    "type": "string"
+                "rating": {
+                    "description": "from 1 to 5 (required)",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "review": {
+                    "type": "string",
+                    "maxLength": 4096
+                }
+            }
+        },
+        "services.CreateShoppingListItemRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "quantity": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "services.CreateShoppingListRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "from": {
+                    "description": "start of the meal plan range, YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2019-07-01"
+                },
+                "ignore_pantry": {
+                    "description": "list ingredients available in the pantry too",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "receipt_ids": {
+                    "description": "receipts to buy ingredients for, repeated ids are counted several times",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "to": {
+                    "description": "end of the meal plan range, YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2019-07-07"
+                }
+            }
+        },
+        "services.FullReceiptDirectionRequest": {
+            "type": "object",
+            "required": [
+                "description"
+            ],
+            "properties": {
+                "description": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "duration": {
+                    "description": "step duration in minutes",
+                    "type": "integer",
+                    "maximum": 10080,
+                    "minimum": 1
                 },
                 "id": {
+                    "description": "existing step to update, when no step has id they replace the ones at their positions",
                     "type": "integer"
                 },
-                "message": {
-                    "description": "need fill only if error occurred",
-                    "type": "string"
+                "passive": {
+                    "description": "waiting step which needs no attention, like baking or resting",
+                    "type": "boolean"
+                },
+                "temperature": {
+                    "description": "oven temperature in Celsius degrees",
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 1
                 }
             }
         },
-        "handler.IngredientAPIResponse": {
+        "services.FullReceiptIngredientRequest": {
             "type": "object",
+            "required": [
+                "quantity"
+            ],
             "properties": {
-                "item": {
-                    "type": "object",
-                    "$ref": "#/definitions/ingredient.Ingredient"
+                "category": {
+                    "description": "category of the created ingredient",
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "message": {
-                    "description": "need fill only if error occurred",
-                    "type": "string"
+                "id": {
+                    "description": "existing receipt ingredient to update, when no ingredient has id they replace the ones at their positions",
+                    "type": "integer"
+                },
+                "ingredient_id": {
+                    "description": "existing ingredient, either it or the name is required",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "ingredient is created when there is no ingredient with such name yet",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "quantity": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 }
             }
         },
-        "handler.ListAPIResponse": {
+        "services.FullReceiptRequest": {
             "type": "object",
+            "required": [
+                "category",
+                "description",
+                "name"
+            ],
             "properties": {
-                "list": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "cook_time": {
+                    "description": "cooking time in minutes",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "cooking_time": {
+                    "description": "deprecated, total cooking time in minutes, the cook time is changed to make it up when it differs from the current total",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "course": {
+                    "description": "like breakfast, main or dessert",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "cuisine": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "difficulty": {
+                    "type": "string",
+                    "enum": [
+                        "easy",
+                        "medium",
+                        "hard"
+                    ]
+                },
+                "directions": {
+                    "description": "steps in their order",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/receipt.Receipt"
+                        "$ref": "#/definitions/services.FullReceiptDirectionRequest"
                     }
                 },
-                "message": {
-                    "description": "need fill only if error occurred",
+                "ingredients": {
+                    "description": "ingredients in their order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.FullReceiptIngredientRequest"
+                    }
+                },
+                "language": {
+                    "description": "language of the name, description and directions",
+                    "type": "string",
+                    "enum": [
+                        "en",
+                        "uk"
+                    ]
+                },
+                "name": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "prep_time": {
+                    "description": "preparation time in minutes",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "private": {
+                    "description": "visible to the owner and users it is shared with only, kept when omitted",
+                    "type": "boolean"
+                },
+                "rest_time": {
+                    "description": "resting time in minutes, like cooling or marinating",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "servings": {
+                    "description": "number of servings the ingredient quantities are given for",
+                    "type": "integer",
+                    "maximum": 1000
+                }
+            }
+        },
+        "services.GrantReceiptAccessRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "description": "viewer or editor (required)",
+                    "type": "string",
+                    "enum": [
+                        "viewer",
+                        "editor"
+                    ]
+                }
+            }
+        },
+        "services.ImportReceiptRequest": {
+            "type": "object",
+            "properties": {
+                "html": {
+                    "description": "raw page, relative links are resolved against url when both are given",
+                    "type": "string"
+                },
+                "save": {
+                    "description": "save the receipt with its image instead of returning the preview",
+                    "type": "boolean"
+                },
+                "text": {
+                    "description": "receipt in Cooklang-style plain-text markup, it cannot be combined with url or html",
                     "type": "string"
+                },
+                "url": {
+                    "description": "page with schema.org Recipe, either it or html is required",
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/recipes/borsch"
                 }
             }
         },
-        "handler.ListIngredientsAPIResponse": {
+        "services.MergeReceiptsRequest": {
             "type": "object",
+            "required": [
+                "duplicate_ids"
+            ],
             "properties": {
-                "list": {
+                "duplicate_ids": {
+                    "description": "receipts merged into the receipt and moved to trash",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/ingredient.Ingredient"
+                        "type": "integer"
                     }
+                }
+            }
+        },
+        "services.ReceiptImportPreview": {
+            "type": "object",
+            "properties": {
+                "image_url": {
+                    "description": "main image of the recipe, it is downloaded when the receipt is saved",
+                    "type": "string"
                 },
-                "message": {
-                    "description": "need fill only if error occurred",
+                "receipt": {
+                    "description": "receipt document, it can be edited and created with POST /v1/receipts/full",
+                    "type": "object",
+                    "$ref": "#/definitions/services.FullReceiptRequest"
+                },
+                "source_url": {
                     "type": "string"
                 }
             }
         },
-        "handler.ListReceiptDirectionAPIResponse": {
+        "services.ReorderCookbookReceiptsRequest": {
             "type": "object",
+            "required": [
+                "receipt_ids"
+            ],
             "properties": {
-                "list": {
+                "receipt_ids": {
+                    "description": "all receipt ids of the cookbook in the new order (required)",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/receipt.ReceiptDirection"
+                        "type": "integer"
                     }
-                },
-                "message": {
-                    "description": "need fill only if error occurred",
-                    "type": "string"
                 }
             }
         },
-        "handler.ListReceiptIngredientAPIResponse": {
+        "services.ReorderReceiptDirectionsRequest": {
             "type": "object",
+            "required": [
+                "ids"
+            ],
             "properties": {
-                "list": {
+                "ids": {
+                    "description": "all direction ids of the receipt in the new order (required)",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/receipt.ReceiptIngredient"
+                        "type": "integer"
                     }
-                },
-                "message": {
-                    "description": "need fill only if error occurred",
-                    "type": "string"
                 }
             }
         },
-        "handler.ReceiptAPIResponse": {
+        "services.ReorderReceiptImagesRequest": {
             "type": "object",
+            "required": [
+                "ids"
+            ],
             "properties": {
-                "item": {
-                    "type": "object",
-                    "$ref": "#/definitions/receipt.Receipt"
-                },
-                "message": {
-                    "description": "need fill only if error occurred",
-                    "type": "string"
+                "ids": {
+                    "description": "all image ids of the receipt in the new order (required)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "handler.ReceiptDirectionAPIResponse": {
+        "services.ReorderReceiptIngredientsRequest": {
             "type": "object",
+            "required": [
+                "ids"
+            ],
             "properties": {
-                "item": {
-                    "type": "object",
-                    "$ref": "#/definitions/receipt.ReceiptDirection"
-                },
-                "message": {
-                    "description": "need fill only if error occurred",
-                    "type": "string"
+                "ids": {
+                    "description": "all receipt ingredient ids of the receipt in the new order (required)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "handler.ReceiptIngredientAPIResponse": {
+        "services.SaveDirectionTranslationRequest": {
             "type": "object",
+            "required": [
+                "description"
+            ],
             "properties": {
-                "item": {
-                    "type": "object",
-                    "$ref": "#/definitions/receipt.ReceiptIngredient"
-                },
-                "message": {
-                    "description": "need fill only if error occurred",
-                    "type": "string"
+                "description": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                }
+            }
+        },
+        "services.SaveIngredientTranslationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
                 }
             }
         },
-        "ingredient.Ingredient": {
+        "services.SaveReceiptTranslationRequest": {
             "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
                 },
                 "name": {
-                    "type": "string"
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
                 }
             }
         },
-        "media.Media": {
+        "services.UpdateCookbookRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "format": {
-                    "description": "format (read only) (required)",
+                "description": {
                     "type": "string",
-                    "example": "image/png"
+                    "maxLength": 255
                 },
-                "link": {
-                    "description": "media link (read only) (required)",
+                "name": {
+                    "description": "(required)",
                     "type": "string",
-                    "example": "ac146571eb55d9cbde1a886dbc1f85d2/event.png"
+                    "maxLength": 255,
+                    "minLength": 3
                 }
             }
         },
-        "receipt.Receipt": {
+        "services.UpdateIngredientRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
+                "animal_product": {
+                    "description": "other products of animal origin, like honey",
+                    "type": "boolean"
+                },
                 "category": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "cooking_time": {
-                    "type": "integer"
+                "dairy": {
+                    "type": "boolean"
                 },
-                "created_at": {
-                    "type": "string"
+                "eggs": {
+                    "type": "boolean"
                 },
-                "description": {
-                    "type": "string"
+                "fish": {
+                    "type": "boolean"
                 },
-                "id": {
-                    "type": "integer"
+                "gluten": {
+                    "description": "allergens",
+                    "type": "boolean"
                 },
-                "media": {
-                    "type": "object",
-                    "$ref": "#/definitions/media.Media"
+                "meat": {
+                    "description": "meat or poultry",
+                    "type": "boolean"
                 },
                 "name": {
-                    "type": "string"
+                    "description": "(required)",
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
                 },
-                "updated_at": {
-                    "type": "string"
+                "nuts": {
+                    "description": "tree nuts",
+                    "type": "boolean"
                 },
-                "user_id": {
-                    "type": "integer"
+                "peanuts": {
+                    "type": "boolean"
+                },
+                "sesame": {
+                    "type": "boolean"
+                },
+                "shellfish": {
+                    "type": "boolean"
+                },
+                "soy": {
+                    "type": "boolean"
                 }
             }
         },
-        "receipt.ReceiptDirection": {
+        "services.UpdateMealPlanEntryRequest": {
             "type": "object",
+            "required": [
+                "date",
+                "servings",
+                "slot"
+            ],
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
+                "date": {
+                    "description": "(required)",
+                    "type": "string",
+                    "example": "2019-06-28"
                 },
-                "receipt_id": {
-                    "type": "integer"
+                "servings": {
+                    "description": "(required)",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
                 },
-                "updated_at": {
-                    "type": "string"
+                "slot": {
+                    "description": "breakfast, lunch or dinner (required)",
+                    "type": "string",
+                    "enum": [
+                        "breakfast",
+                        "lunch",
+                        "dinner"
+                    ]
                 }
             }
         },
-        "receipt.ReceiptIngredient": {
+        "services.UpdatePantryItemRequest": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "ingredient": {
-                    "type": "object",
-                    "$ref": "#/definitions/ingredient.Ingredient"
-                },
-                "ingredient_id": {
-                    "type": "integer"
+                "expires_at": {
+                    "description": "YYYY-MM-DD, empty when the item does not expire",
+                    "type": "string",
+                    "example": "2019-07-10"
                 },
                 "quantity": {
-                    "type": "string"
-                },
-                "receipt_id": {
-                    "type": "integer"
-                },
-                "updated_at": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "500 g"
                 }
             }
         },
-        "services.CreateIngredientRequest": {
+        "services.UpdateReceiptCommentRequest": {
             "type": "object",
             "required": [
-                "name"
+                "body"
             ],
             "properties": {
-                "name": {
+                "body": {
                     "description": "(required)",
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
+                    "maxLength": 4096,
+                    "minLength": 1
                 }
             }
         },
-        "services.CreateReceiptDirectionRequest": {
+        "services.UpdateReceiptDirectionRequest": {
             "type": "object",
             "required": [
                 "description"
@@ -1459,20 +9157,44 @@ var doc = `{
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 3
+                },
+                "duration": {
+                    "description": "step duration in minutes",
+                    "type": "integer",
+                    "maximum": 10080,
+                    "minimum": 1
+                },
+                "passive": {
+                    "description": "waiting step which needs no attention, like baking or resting",
+                    "type": "boolean"
+                },
+                "temperature": {
+                    "description": "oven temperature in Celsius degrees",
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 1
                 }
             }
         },
-        "services.CreateReceiptIngredientRequest": {
+        "services.UpdateReceiptImageRequest": {
+            "type": "object",
+            "properties": {
+                "caption": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "cover": {
+                    "description": "make the image the cover, the cover can be changed only by choosing another one",
+                    "type": "boolean"
+                }
+            }
+        },
+        "services.UpdateReceiptIngredientRequest": {
             "type": "object",
             "required": [
-                "ingredient_id",
                 "quantity"
             ],
             "properties": {
-                "ingredient_id": {
-                    "type": "integer",
-                    "minimum": 1
-                },
                 "quantity": {
                     "description": "(required)",
                     "type": "string",
@@ -1481,11 +9203,10 @@ var doc = `{
                 }
             }
         },
-        "services.CreateReceiptRequest": {
+        "services.UpdateReceiptRequest": {
             "type": "object",
             "required": [
                 "category",
-                "cooking_time",
                 "description",
                 "name"
             ],
@@ -1495,93 +9216,197 @@ var doc = `{
                     "maxLength": 255,
                     "minLength": 3
                 },
+                "cook_time": {
+                    "description": "cooking time in minutes",
+                    "type": "integer",
+                    "maximum": 10080
+                },
                 "cooking_time": {
+                    "description": "deprecated, total cooking time in minutes, the cook time is changed to make it up when it differs from the current total",
                     "type": "integer",
-                    "minimum": 1
+                    "maximum": 10080
+                },
+                "course": {
+                    "description": "like breakfast, main or dessert",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "cuisine": {
+                    "type": "string",
+                    "maxLength": 50
                 },
                 "description": {
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 3
                 },
+                "difficulty": {
+                    "type": "string",
+                    "enum": [
+                        "easy",
+                        "medium",
+                        "hard"
+                    ]
+                },
+                "language": {
+                    "description": "language of the name, description and directions",
+                    "type": "string",
+                    "enum": [
+                        "en",
+                        "uk"
+                    ]
+                },
                 "name": {
                     "description": "(required)",
                     "type": "string",
                     "maxLength": 255,
                     "minLength": 3
+                },
+                "prep_time": {
+                    "description": "preparation time in minutes",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "private": {
+                    "description": "visible to the owner and users it is shared with only, kept when omitted",
+                    "type": "boolean"
+                },
+                "rest_time": {
+                    "description": "resting time in minutes, like cooling or marinating",
+                    "type": "integer",
+                    "maximum": 10080
+                },
+                "servings": {
+                    "description": "number of servings the ingredient quantities are given for",
+                    "type": "integer",
+                    "maximum": 1000
                 }
             }
         },
-        "services.UpdateIngredientRequest": {
+        "services.UpdateReceiptReviewRequest": {
             "type": "object",
             "required": [
-                "name"
+                "rating"
             ],
             "properties": {
-                "name": {
-                    "description": "(required)",
+                "rating": {
+                    "description": "from 1 to 5 (required)",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "review": {
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
+                    "maxLength": 4096
                 }
             }
         },
-        "services.UpdateReceiptDirectionRequest": {
+        "services.UpdateShoppingListItemRequest": {
             "type": "object",
             "required": [
-                "description"
+                "checked"
             ],
             "properties": {
-                "description": {
+                "checked": {
                     "description": "(required)",
+                    "type": "boolean"
+                },
+                "quantity": {
+                    "description": "keeps current quantity when omitted",
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
+                    "maxLength": 255
                 }
             }
         },
-        "services.UpdateReceiptIngredientRequest": {
+        "shopping.Item": {
             "type": "object",
-            "required": [
-                "quantity"
-            ],
             "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "checked": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ingredient_id": {
+                    "type": "integer"
+                },
+                "manual": {
+                    "description": "added by the user, not generated from receipts",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
                 "quantity": {
-                    "description": "(required)",
                     "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
+                    "example": "1.2 kg"
+                },
+                "shopping_list_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "services.UpdateReceiptRequest": {
+        "shopping.ItemGroup": {
             "type": "object",
-            "required": [
-                "category",
-                "cooking_time",
-                "description",
-                "name"
-            ],
             "properties": {
                 "category": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
+                    "type": "string"
                 },
-                "cooking_time": {
-                    "type": "integer",
-                    "minimum": 1
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/shopping.Item"
+                    }
+                }
+            }
+        },
+        "shopping.List": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "description": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
+                "groups": {
+                    "description": "items grouped by ingredient category (read only)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/shopping.ItemGroup"
+                    }
+                },
+                "id": {
+                    "type": "integer"
                 },
                 "name": {
-                    "description": "(required)",
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "tools.Pagination": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "per_page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
@@ -0,0 +1,522 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/cookbook"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type ListCookbookAPIResponse struct {
+	APIResponse
+	List []cookbook.Cookbook `json:"list"`
+}
+
+type CookbookAPIResponse struct {
+	APIResponse
+	Item cookbook.Cookbook `json:"item"`
+}
+
+// GetCookbooks godoc
+// @Summary Get cookbooks
+// @Description find cookbooks of the current user
+// @Tags cookbooks
+// @Produce  json
+// @Success 200 {object} handler.ListCookbookAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/cookbooks [get]
+func (*Controller) GetCookbooks(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get cookbooks"})
+		return
+	}
+
+	svc := services.GetCookbookService(db)
+	cookbooks, err := svc.GetCookbooks(userClaims.Id)
+	if err != nil {
+		log.Printf("get cookbooks error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get cookbooks"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListCookbookAPIResponse{APIResponse: APIResponse{}, List: cookbooks})
+}
+
+// GetCookbook godoc
+// @Summary Get cookbook
+// @Description get cookbook with its receipts in order
+// @Tags cookbooks
+// @Produce  json
+// @Param   id     path    int     true        "Cookbook id"
+// @Success 200 {object} handler.CookbookAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/cookbooks/{id} [get]
+func (*Controller) GetCookbook(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get cookbook is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get cookbook"})
+		return
+	}
+
+	svc := services.GetCookbookService(db)
+	i, err := svc.GetCookbook(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get cookbook"})
+		return
+	}
+
+	c.JSON(http.StatusOK, CookbookAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// CreateCookbook godoc
+// @Summary Create cookbook
+// @Tags cookbooks
+// @Produce  json
+// @Param cookbook body services.CreateCookbookRequest true "params"
+// @Success 200 {object} handler.CookbookAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/cookbooks [post]
+func (*Controller) CreateCookbook(c *gin.Context) {
+	var request services.CreateCookbookRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to create cookbook is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to create cookbook"})
+		return
+	}
+
+	svc := services.GetCookbookService(db)
+	i, err := svc.CreateCookbook(userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when create cookbook"})
+		return
+	}
+
+	c.JSON(http.StatusOK, CookbookAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// UpdateCookbook godoc
+// @Summary Update cookbook
+// @Tags cookbooks
+// @Produce  json
+// @Param   id     path    int     true        "Cookbook id"
+// @Param cookbook body services.UpdateCookbookRequest true "params"
+// @Success 200 {object} handler.CookbookAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/cookbooks/{id} [put]
+func (*Controller) UpdateCookbook(c *gin.Context) {
+	var request services.UpdateCookbookRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update cookbook is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update cookbook is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to update cookbook"})
+		return
+	}
+
+	svc := services.GetCookbookService(db)
+	i, err := svc.UpdateCookbook(uint(id), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when update cookbook"})
+		return
+	}
+
+	c.JSON(http.StatusOK, CookbookAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// DeleteCookbook godoc
+// @Summary Delete cookbook
+// @Tags cookbooks
+// @Produce  json
+// @Param   id     path    int     true        "Cookbook id"
+// @Success 204
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/cookbooks/{id} [delete]
+func (*Controller) DeleteCookbook(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to delete cookbook is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to delete cookbook"})
+		return
+	}
+
+	svc := services.GetCookbookService(db)
+	err = svc.DeleteCookbook(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when delete cookbook"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddCookbookReceipt godoc
+// @Summary Add receipt to cookbook
+// @Description insert receipt at given position or append it to the end
+// @Tags cookbooks
+// @Produce  json
+// @Param   id     path    int     true        "Cookbook id"
+// @Param receipt body services.AddCookbookReceiptRequest true "params"
+// @Success 200 {object} handler.CookbookAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/cookbooks/{id}/receipts [post]
+func (*Controller) AddCookbookReceipt(c *gin.Context) {
+	var request services.AddCookbookReceiptRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to add cookbook receipt is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to add cookbook receipt is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to add cookbook receipt"})
+		return
+	}
+
+	svc := services.GetCookbookService(db)
+	i, err := svc.AddCookbookReceipt(uint(id), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when add cookbook receipt"})
+		return
+	}
+
+	c.JSON(http.StatusOK, CookbookAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// RemoveCookbookReceipt godoc
+// @Summary Remove receipt from cookbook
+// @Tags cookbooks
+// @Produce  json
+// @Param   id     path    int     true        "Cookbook id"
+// @Param   receipt_id     path    int     true        "Receipt id"
+// @Success 204
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/cookbooks/{id}/receipts/{receipt_id} [delete]
+func (*Controller) RemoveCookbookReceipt(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to remove cookbook receipt is invalid"})
+		return
+	}
+
+	receiptIdParam := c.Param("receipt_id")
+	receiptId, err := strconv.Atoi(receiptIdParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to remove cookbook receipt is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to remove cookbook receipt"})
+		return
+	}
+
+	svc := services.GetCookbookService(db)
+	err = svc.RemoveCookbookReceipt(uint(id), uint(receiptId), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when remove cookbook receipt"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReorderCookbookReceipts godoc
+// @Summary Reorder cookbook receipts
+// @Tags cookbooks
+// @Produce  json
+// @Param   id     path    int     true        "Cookbook id"
+// @Param order body services.ReorderCookbookReceiptsRequest true "params"
+// @Success 200 {object} handler.CookbookAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/cookbooks/{id}/receipts/order [put]
+func (*Controller) ReorderCookbookReceipts(c *gin.Context) {
+	var request services.ReorderCookbookReceiptsRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to reorder cookbook receipts is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to reorder cookbook receipts is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to reorder cookbook receipts"})
+		return
+	}
+
+	svc := services.GetCookbookService(db)
+	i, err := svc.ReorderCookbookReceipts(uint(id), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when reorder cookbook receipts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, CookbookAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// ExportCookbookPDF godoc
+// @Summary Export cookbook as PDF
+// @Description printable cookbook receipts with a table of contents
+// @Tags cookbooks
+// @Produce  application/pdf
+// @Param   id     path    int     true        "Cookbook id"
+// @Success 200 {array} integer
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/cookbooks/{id}/export.pdf [get]
+func (*Controller) ExportCookbookPDF(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to export cookbook is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to export cookbook"})
+		return
+	}
+
+	svc := services.GetCookbookService(db)
+	document := &bytes.Buffer{}
+	err = svc.WriteCookbookPDF(document, uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when export cookbook"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=cookbook-%d.pdf", id))
+	c.Data(http.StatusOK, "application/pdf", document.Bytes())
+}
@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"fmt"
 	"food/src/api/config"
 	"food/src/api/jwt_auth"
+	"food/src/api/models/tools"
 	"food/src/api/models/user"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -15,6 +18,25 @@ import (
 	_ "food/src/api/docs"
 )
 
+// Static path segments which gin cannot register next to wildcard ones,
+// like `/receipts/full` next to `/receipts/:id/...`. They are matched by handlers instead.
+const (
+	fullReceiptSegment   = "full"
+	importReceiptSegment = "import"
+	bulkImportSegment    = "bulk-import"
+	bulkExportSegment    = "bulk-export"
+	duplicatesSegment    = "duplicates"
+	orderSegment         = "order"
+)
+
+const (
+	ifMatchHeader     = "If-Match"
+	ifNoneMatchHeader = "If-None-Match"
+)
+
+// originalLanguage is the `lang` value which requests items untranslated.
+const originalLanguage = "original"
+
 type APIResponse struct {
 	Message string `json:"message,omitempty"` // need fill only if error occurred
 }
@@ -58,6 +80,9 @@ func SetupHandler() http.Handler {
 		userCtrl.POST("/signIn", c.SignIn)
 	}
 
+	// Calendar feeds are authenticated by the secret token in URL
+	r.GET("/calendar/:token/meal-plan.ics", c.GetMealPlanCalendarFeed)
+
 	// Secure API
 	v1Api := r.Group("/v1")
 	{
@@ -67,28 +92,146 @@ func SetupHandler() http.Handler {
 
 		ctrlSecureRegular.GET("/receipts", c.GetReceipts)
 		ctrlSecureRegular.POST("/receipts", c.CreateReceipt)
+		// serves POST /receipts/full, POST /receipts/import and POST /receipts/bulk-import
+		ctrlSecureRegular.POST("/receipts/:id", c.postReceiptSegment)
+		// serves GET /receipts/:id, GET /receipts/bulk-export and GET /receipts/duplicates
+		ctrlSecureRegular.GET("/receipts/:id", c.getReceiptSegment)
+		ctrlSecureRegular.GET("/receipts/:id/full", c.GetFullReceipt)
+		ctrlSecureRegular.GET("/receipts/:id/similar", c.GetSimilarReceipts)
+		ctrlSecureRegular.GET("/receipts/:id/export", c.ExportReceipt)
+		ctrlSecureRegular.GET("/receipts/:id/export.pdf", c.ExportReceiptPDF)
+		ctrlSecureRegular.PUT("/receipts/:id/full", c.ReplaceFullReceipt)
 		ctrlSecureRegular.PUT("/receipts/:id", c.UpdateReceipt)
+		ctrlSecureRegular.PATCH("/receipts/:id", c.PatchReceipt)
+		ctrlSecureRegular.PATCH("/receipts/:id/full", c.PatchFullReceipt)
 		ctrlSecureRegular.DELETE("/receipts/:id", c.DeleteReceipt)
+		ctrlSecureRegular.POST("/receipts/:id/restore", c.RestoreReceipt)
+		ctrlSecureRegular.POST("/receipts/:id/merge", c.MergeReceipts)
 		ctrlSecureRegular.POST("/receipts/:id/media", c.UploadReceiptMedia)
+		ctrlSecureRegular.GET("/receipts/:id/images", c.GetReceiptImages)
+		ctrlSecureRegular.POST("/receipts/:id/images", c.AddReceiptImage)
+		// also serves PUT /receipts/:id/images/order
+		ctrlSecureRegular.PUT("/receipts/:id/images/:image_id", c.UpdateReceiptImage)
+		ctrlSecureRegular.DELETE("/receipts/:id/images/:image_id", c.DeleteReceiptImage)
 
 		ctrlSecureRegular.GET("/receipts/:id/ingredients", c.GetReceiptIngredients)
 		ctrlSecureRegular.POST("/receipts/:id/ingredients/", c.CreateReceiptIngredient)
+		// also serves PUT /receipts/:id/ingredients/order
 		ctrlSecureRegular.PUT("/receipts/:id/ingredients/:ingredient_id", c.UpdateReceiptIngredient)
+		ctrlSecureRegular.PATCH("/receipts/:id/ingredients/:ingredient_id", c.PatchReceiptIngredient)
 		ctrlSecureRegular.DELETE("/receipts/:id/ingredients/:ingredient_id", c.DeleteReceiptIngredient)
 
 		ctrlSecureRegular.GET("/receipts/:id/directions", c.GetReceiptDirections)
 		ctrlSecureRegular.POST("/receipts/:id/directions/", c.CreateReceiptDirection)
+		// also serves PUT /receipts/:id/directions/order
 		ctrlSecureRegular.PUT("/receipts/:id/directions/:direction_id", c.UpdateReceiptDirection)
+		ctrlSecureRegular.PATCH("/receipts/:id/directions/:direction_id", c.PatchReceiptDirection)
 		ctrlSecureRegular.DELETE("/receipts/:id/directions/:direction_id", c.DeleteReceiptDirection)
+		ctrlSecureRegular.POST("/receipts/:id/directions/:direction_id/media", c.UploadReceiptDirectionMedia)
+		ctrlSecureRegular.PUT("/receipts/:id/directions/:direction_id/translations/:lang", c.SaveReceiptDirectionTranslation)
+
+		ctrlSecureRegular.GET("/receipts/:id/translations", c.GetReceiptTranslations)
+		ctrlSecureRegular.PUT("/receipts/:id/translations/:lang", c.SaveReceiptTranslation)
+
+		ctrlSecureRegular.GET("/receipts/:id/access", c.GetReceiptAccesses)
+		ctrlSecureRegular.PUT("/receipts/:id/access/:user_id", c.GrantReceiptAccess)
+		ctrlSecureRegular.DELETE("/receipts/:id/access/:user_id", c.RevokeReceiptAccess)
+
+		ctrlSecureRegular.GET("/receipts/:id/reviews", c.GetReceiptReviews)
+		ctrlSecureRegular.POST("/receipts/:id/reviews", c.CreateReceiptReview)
+		ctrlSecureRegular.PUT("/receipts/:id/reviews/:review_id", c.UpdateReceiptReview)
+		ctrlSecureRegular.DELETE("/receipts/:id/reviews/:review_id", c.DeleteReceiptReview)
+
+		ctrlSecureRegular.GET("/receipts/:id/comments", c.GetReceiptComments)
+		ctrlSecureRegular.POST("/receipts/:id/comments", c.CreateReceiptComment)
+		ctrlSecureRegular.PUT("/receipts/:id/comments/:comment_id", c.UpdateReceiptComment)
+		ctrlSecureRegular.DELETE("/receipts/:id/comments/:comment_id", c.DeleteReceiptComment)
+
+		ctrlSecureRegular.GET("/favorites", c.GetFavoriteReceipts)
+		ctrlSecureRegular.PUT("/receipts/:id/favorite", c.AddFavoriteReceipt)
+		ctrlSecureRegular.DELETE("/receipts/:id/favorite", c.RemoveFavoriteReceipt)
+		ctrlSecureRegular.POST("/receipts/:id/cooked", c.CookReceipt)
+
+		ctrlSecureRegular.GET("/trash", c.GetTrash)
+		ctrlSecureRegular.DELETE("/trash/:id", c.PurgeReceipt)
+
+		ctrlSecureRegular.GET("/cookbooks", c.GetCookbooks)
+		ctrlSecureRegular.POST("/cookbooks", c.CreateCookbook)
+		ctrlSecureRegular.GET("/cookbooks/:id", c.GetCookbook)
+		ctrlSecureRegular.PUT("/cookbooks/:id", c.UpdateCookbook)
+		ctrlSecureRegular.DELETE("/cookbooks/:id", c.DeleteCookbook)
+		ctrlSecureRegular.GET("/cookbooks/:id/export.pdf", c.ExportCookbookPDF)
+		ctrlSecureRegular.POST("/cookbooks/:id/receipts", c.AddCookbookReceipt)
+		ctrlSecureRegular.PUT("/cookbooks/:id/receipts/order", c.ReorderCookbookReceipts)
+		ctrlSecureRegular.DELETE("/cookbooks/:id/receipts/:receipt_id", c.RemoveCookbookReceipt)
+
+		ctrlSecureRegular.GET("/meal-plan", c.GetMealPlan)
+		ctrlSecureRegular.POST("/meal-plan", c.CreateMealPlanEntry)
+		ctrlSecureRegular.PUT("/meal-plan/:id", c.UpdateMealPlanEntry)
+		ctrlSecureRegular.DELETE("/meal-plan/:id", c.DeleteMealPlanEntry)
+		ctrlSecureRegular.POST("/meal-plan/:id/copy", c.CopyMealPlanEntry)
+		ctrlSecureRegular.GET("/calendar/meal-plan", c.GetMealPlanCalendar)
+		ctrlSecureRegular.POST("/calendar/meal-plan/reset", c.ResetMealPlanCalendar)
+
+		ctrlSecureRegular.GET("/pantry", c.GetPantryItems)
+		ctrlSecureRegular.POST("/pantry", c.CreatePantryItem)
+		ctrlSecureRegular.PUT("/pantry/:id", c.UpdatePantryItem)
+		ctrlSecureRegular.DELETE("/pantry/:id", c.DeletePantryItem)
+
+		ctrlSecureRegular.GET("/shopping-lists", c.GetShoppingLists)
+		ctrlSecureRegular.POST("/shopping-lists", c.CreateShoppingList)
+		ctrlSecureRegular.GET("/shopping-lists/:id", c.GetShoppingList)
+		ctrlSecureRegular.DELETE("/shopping-lists/:id", c.DeleteShoppingList)
+		ctrlSecureRegular.POST("/shopping-lists/:id/items", c.CreateShoppingListItem)
+		ctrlSecureRegular.PUT("/shopping-lists/:id/items/:item_id", c.UpdateShoppingListItem)
+		ctrlSecureRegular.DELETE("/shopping-lists/:id/items/:item_id", c.DeleteShoppingListItem)
 
 		ctrlSecureRegular.GET("/ingredients", c.GetIngredients)
 		ctrlSecureRegular.POST("/ingredients", c.CreateIngredient)
 		ctrlSecureRegular.PUT("/ingredients/:id", c.UpdateIngredient)
+		ctrlSecureRegular.GET("/ingredients/:id/translations", c.GetIngredientTranslations)
+		ctrlSecureRegular.PUT("/ingredients/:id/translations/:lang", c.SaveIngredientTranslation)
 	}
 
 	return r
 }
 
+func (ctrl *Controller) postReceiptSegment(c *gin.Context) {
+	switch c.Param("id") {
+	case fullReceiptSegment:
+		ctrl.CreateFullReceipt(c)
+	case importReceiptSegment:
+		ctrl.ImportReceipt(c)
+	case bulkImportSegment:
+		ctrl.BulkImportReceipts(c)
+	default:
+		c.JSON(http.StatusNotFound, APIResponse{Message: "Not found"})
+	}
+}
+
+func (ctrl *Controller) getReceiptSegment(c *gin.Context) {
+	switch c.Param("id") {
+	case bulkExportSegment:
+		ctrl.BulkExportReceipts(c)
+	case duplicatesSegment:
+		ctrl.GetDuplicateReceipts(c)
+	default:
+		ctrl.GetReceipt(c)
+	}
+}
+
+// writeTaggedJSON sends the item with its entity tag. Reads of the version the client
+// already has are answered with 304 and no body.
+func writeTaggedJSON(c *gin.Context, etag string, body interface{}) {
+	c.Header("ETag", etag)
+	method := c.Request.Method
+	if (method == http.MethodGet || method == http.MethodHead) && tools.MatchesETag(c.GetHeader(ifNoneMatchHeader), etag, true) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, body)
+}
+
 func auth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -138,3 +281,61 @@ func regularAccess() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// getPagination reads `page` and `per_page` query params.
+func getPagination(c *gin.Context) (pagination tools.Pagination, err error) {
+	page, perPage := 1, tools.DefaultPerPage
+	if pageParam := c.Query("page"); pageParam != "" {
+		page, err = strconv.Atoi(pageParam)
+		if err != nil {
+			return
+		}
+	}
+	if perPageParam := c.Query("per_page"); perPageParam != "" {
+		perPage, err = strconv.Atoi(perPageParam)
+		if err != nil {
+			return
+		}
+	}
+	pagination = tools.NewPagination(page, perPage)
+	return
+}
+
+// getLanguage returns the language of translations requested by the `lang` query param or the Accept-Language header,
+// empty one means the original language, `lang=original` requests it whatever the header is.
+// Responses vary by the header, so it is listed in Vary.
+func getLanguage(c *gin.Context) (language string, err error) {
+	c.Header("Vary", "Accept-Language")
+	if langParam := c.Query("lang"); langParam != "" {
+		language = strings.ToLower(langParam)
+		if language == originalLanguage {
+			return "", nil
+		}
+		if !tools.IsValidLanguage(language) {
+			err = fmt.Errorf("language `%s` is not supported", langParam)
+		}
+		return
+	}
+	language = tools.NegotiateLanguage(c.GetHeader("Accept-Language"))
+	return
+}
+
+// splitQueryList splits the comma separated query param, empty items are skipped.
+func splitQueryList(param string) (items []string) {
+	for _, item := range strings.Split(param, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	return
+}
+
+// baseUrl returns scheme and host of the API as seen by the client.
+func baseUrl(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
@@ -28,6 +28,8 @@ type IngredientAPIResponse struct {
 // @Description find ingredients by params
 // @Tags receipts
 // @Produce  json
+// @Param lang query string false "language of translations, en, uk or original for untranslated items, Accept-Language is used when omitted"
+// @Param Accept-Language header string false "preferred languages of translations"
 // @Success 200 {object} handler.ListIngredientsAPIResponse
 // @Failure 401 {object} handler.APIResponse
 // @Failure 400 {object} handler.APIResponse
@@ -35,6 +37,12 @@ type IngredientAPIResponse struct {
 // @Security ApiKeyAuth
 // @Router /v1/ingredients/ [get]
 func (*Controller) GetIngredients(c *gin.Context) {
+	language, err := getLanguage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+		return
+	}
+
 	db, err := database.GetDB()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get ingredients"})
@@ -44,6 +52,9 @@ func (*Controller) GetIngredients(c *gin.Context) {
 
 	svc := services.GetReceiptService(db)
 	items, err := svc.GetAllIngredients()
+	if err == nil {
+		err = svc.TranslateIngredients(language, items)
+	}
 	if err != nil {
 		log.Printf("get ingredients error: `%s`", err)
 		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get ingredients"})
@@ -0,0 +1,423 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/mealplan"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type ListMealPlanEntryAPIResponse struct {
+	APIResponse
+	List []mealplan.Entry `json:"list"`
+}
+
+type MealPlanEntryAPIResponse struct {
+	APIResponse
+	Item mealplan.Entry `json:"item"`
+}
+
+type MealPlanCalendarAPIResponse struct {
+	APIResponse
+	// secret iCalendar feed URL, anyone who knows it can read the meal plan
+	Url string `json:"url" example:"https://api.food.test/calendar/4f1c.../meal-plan.ics"`
+}
+
+// GetMealPlan godoc
+// @Summary Get meal plan
+// @Description find meal plan entries between given dates inclusively
+// @Tags meal plan
+// @Produce  json
+// @Param from query string true "start date, YYYY-MM-DD"
+// @Param to query string true "end date, YYYY-MM-DD"
+// @Success 200 {object} handler.ListMealPlanEntryAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/meal-plan [get]
+func (*Controller) GetMealPlan(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	from, to, err := services.ParseMealPlanRange(c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request to get meal plan is invalid. Orig err: `%s`", err)})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get meal plan"})
+		return
+	}
+
+	svc := services.GetMealPlanService(db)
+	entries, err := svc.GetEntries(userClaims.Id, from, to)
+	if err != nil {
+		log.Printf("get meal plan error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get meal plan"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListMealPlanEntryAPIResponse{APIResponse: APIResponse{}, List: entries})
+}
+
+// CreateMealPlanEntry godoc
+// @Summary Add receipt to meal plan
+// @Tags meal plan
+// @Produce  json
+// @Param entry body services.CreateMealPlanEntryRequest true "params"
+// @Success 200 {object} handler.MealPlanEntryAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/meal-plan [post]
+func (*Controller) CreateMealPlanEntry(c *gin.Context) {
+	var request services.CreateMealPlanEntryRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to create meal plan entry is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to create meal plan entry"})
+		return
+	}
+
+	svc := services.GetMealPlanService(db)
+	i, err := svc.CreateEntry(userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when create meal plan entry"})
+		return
+	}
+
+	c.JSON(http.StatusOK, MealPlanEntryAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// UpdateMealPlanEntry godoc
+// @Summary Move meal plan entry
+// @Description change date, slot or servings of the entry
+// @Tags meal plan
+// @Produce  json
+// @Param   id     path    int     true        "Meal plan entry id"
+// @Param entry body services.UpdateMealPlanEntryRequest true "params"
+// @Success 200 {object} handler.MealPlanEntryAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/meal-plan/{id} [put]
+func (*Controller) UpdateMealPlanEntry(c *gin.Context) {
+	var request services.UpdateMealPlanEntryRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update meal plan entry is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update meal plan entry is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to update meal plan entry"})
+		return
+	}
+
+	svc := services.GetMealPlanService(db)
+	i, err := svc.UpdateEntry(uint(id), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when update meal plan entry"})
+		return
+	}
+
+	c.JSON(http.StatusOK, MealPlanEntryAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// CopyMealPlanEntry godoc
+// @Summary Copy meal plan entry
+// @Description copy the entry to another date or slot
+// @Tags meal plan
+// @Produce  json
+// @Param   id     path    int     true        "Meal plan entry id"
+// @Param entry body services.CopyMealPlanEntryRequest true "params"
+// @Success 200 {object} handler.MealPlanEntryAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/meal-plan/{id}/copy [post]
+func (*Controller) CopyMealPlanEntry(c *gin.Context) {
+	var request services.CopyMealPlanEntryRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to copy meal plan entry is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to copy meal plan entry is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to copy meal plan entry"})
+		return
+	}
+
+	svc := services.GetMealPlanService(db)
+	i, err := svc.CopyEntry(uint(id), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when copy meal plan entry"})
+		return
+	}
+
+	c.JSON(http.StatusOK, MealPlanEntryAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// DeleteMealPlanEntry godoc
+// @Summary Delete meal plan entry
+// @Tags meal plan
+// @Produce  json
+// @Param   id     path    int     true        "Meal plan entry id"
+// @Success 204
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/meal-plan/{id} [delete]
+func (*Controller) DeleteMealPlanEntry(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to delete meal plan entry is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to delete meal plan entry"})
+		return
+	}
+
+	svc := services.GetMealPlanService(db)
+	err = svc.DeleteEntry(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when delete meal plan entry"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetMealPlanCalendar godoc
+// @Summary Get meal plan calendar URL
+// @Description get secret URL of the iCalendar feed to subscribe to
+// @Tags meal plan
+// @Produce  json
+// @Success 200 {object} handler.MealPlanCalendarAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/calendar/meal-plan [get]
+func (*Controller) GetMealPlanCalendar(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get meal plan calendar"})
+		return
+	}
+
+	svc := services.GetMealPlanService(db)
+	token, err := svc.GetCalendarToken(userClaims.Id)
+	if err != nil {
+		log.Printf("get calendar token error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get meal plan calendar"})
+		return
+	}
+
+	c.JSON(http.StatusOK, MealPlanCalendarAPIResponse{APIResponse: APIResponse{}, Url: calendarFeedUrl(c, token)})
+}
+
+// ResetMealPlanCalendar godoc
+// @Summary Reset meal plan calendar URL
+// @Description generate new secret URL, the previous one stops working
+// @Tags meal plan
+// @Produce  json
+// @Success 200 {object} handler.MealPlanCalendarAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/calendar/meal-plan/reset [post]
+func (*Controller) ResetMealPlanCalendar(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to reset meal plan calendar"})
+		return
+	}
+
+	svc := services.GetMealPlanService(db)
+	token, err := svc.ResetCalendarToken(userClaims.Id)
+	if err != nil {
+		log.Printf("reset calendar token error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when reset meal plan calendar"})
+		return
+	}
+
+	c.JSON(http.StatusOK, MealPlanCalendarAPIResponse{APIResponse: APIResponse{}, Url: calendarFeedUrl(c, token)})
+}
+
+// GetMealPlanCalendarFeed godoc
+// @Summary Get meal plan iCalendar feed
+// @Description authenticated by the secret token from the feed URL
+// @Tags meal plan
+// @Produce  text/calendar
+// @Param   token     path    string     true        "Calendar token"
+// @Success 200 {string} string
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Router /calendar/{token}/meal-plan.ics [get]
+func (*Controller) GetMealPlanCalendarFeed(c *gin.Context) {
+	token := c.Param("token")
+	if len(token) == 0 {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Request is invalid. Token cannot be empty"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get meal plan calendar"})
+		return
+	}
+
+	svc := services.GetMealPlanService(db)
+	feed := &bytes.Buffer{}
+	err = svc.WriteCalendar(feed, token)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get meal plan calendar"})
+		return
+	}
+
+	c.Header("Content-Disposition", "inline; filename=meal-plan.ics")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", feed.Bytes())
+}
+
+func calendarFeedUrl(c *gin.Context, token string) string {
+	return fmt.Sprintf("%s/calendar/%s/meal-plan.ics", baseUrl(c), token)
+}
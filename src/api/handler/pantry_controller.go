@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/pantry"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type ListPantryItemAPIResponse struct {
+	APIResponse
+	List []pantry.Item `json:"list"`
+}
+
+type PantryItemAPIResponse struct {
+	APIResponse
+	Item pantry.Item `json:"item"`
+}
+
+// GetPantryItems godoc
+// @Summary Get pantry items
+// @Description find pantry items of the current user, the ones expiring first go first. Only items expiring within the given number of days are returned when 'expiring_within' is set
+// @Tags pantry
+// @Produce  json
+// @Param   expiring_within     query    int     false        "Days"
+// @Success 200 {object} handler.ListPantryItemAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/pantry [get]
+func (*Controller) GetPantryItems(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	expiringWithin := -1
+	if param, ok := c.GetQuery("expiring_within"); ok {
+		days, err := strconv.Atoi(param)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get pantry items is invalid"})
+			return
+		}
+		expiringWithin = days
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get pantry items"})
+		return
+	}
+
+	svc := services.GetPantryService(db)
+	var items []pantry.Item
+	if expiringWithin >= 0 {
+		items, err = svc.GetExpiringItems(userClaims.Id, expiringWithin)
+	} else {
+		items, err = svc.GetItems(userClaims.Id)
+	}
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get pantry items"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListPantryItemAPIResponse{APIResponse: APIResponse{}, List: items})
+}
+
+// CreatePantryItem godoc
+// @Summary Add ingredient to pantry
+// @Tags pantry
+// @Produce  json
+// @Param item body services.CreatePantryItemRequest true "params"
+// @Success 200 {object} handler.PantryItemAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/pantry [post]
+func (*Controller) CreatePantryItem(c *gin.Context) {
+	var request services.CreatePantryItemRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to create pantry item is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to create pantry item"})
+		return
+	}
+
+	svc := services.GetPantryService(db)
+	i, err := svc.CreateItem(userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when create pantry item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, PantryItemAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// UpdatePantryItem godoc
+// @Summary Update pantry item
+// @Description replace quantity and expiry date of the item
+// @Tags pantry
+// @Produce  json
+// @Param   id     path    int     true        "Pantry item id"
+// @Param item body services.UpdatePantryItemRequest true "params"
+// @Success 200 {object} handler.PantryItemAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/pantry/{id} [put]
+func (*Controller) UpdatePantryItem(c *gin.Context) {
+	var request services.UpdatePantryItemRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update pantry item is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update pantry item is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to update pantry item"})
+		return
+	}
+
+	svc := services.GetPantryService(db)
+	i, err := svc.UpdateItem(uint(id), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when update pantry item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, PantryItemAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// DeletePantryItem godoc
+// @Summary Delete pantry item
+// @Tags pantry
+// @Produce  json
+// @Param   id     path    int     true        "Pantry item id"
+// @Success 204
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/pantry/{id} [delete]
+func (*Controller) DeletePantryItem(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to delete pantry item is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to delete pantry item"})
+		return
+	}
+
+	svc := services.GetPantryService(db)
+	err = svc.DeleteItem(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when delete pantry item"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CookReceipt godoc
+// @Summary Mark receipt as cooked
+// @Description subtract receipt ingredients from the pantry, returns the updated pantry
+// @Tags pantry
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Success 200 {object} handler.ListPantryItemAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/cooked [post]
+func (*Controller) CookReceipt(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to mark receipt as cooked is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to mark receipt as cooked"})
+		return
+	}
+
+	svc := services.GetPantryService(db)
+	items, err := svc.CookReceipt(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when mark receipt as cooked"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListPantryItemAPIResponse{APIResponse: APIResponse{}, List: items})
+}
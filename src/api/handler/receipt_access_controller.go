@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type ListReceiptAccessAPIResponse struct {
+	APIResponse
+	List []receipt.ReceiptAccess `json:"list"`
+}
+
+type ReceiptAccessAPIResponse struct {
+	APIResponse
+	Item receipt.ReceiptAccess `json:"item"`
+}
+
+// GetReceiptAccesses godoc
+// @Summary Get receipt access grants
+// @Description list users the receipt is shared with, only the owner can see them
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Success 200 {object} handler.ListReceiptAccessAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/access [get]
+func (*Controller) GetReceiptAccesses(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get receipt access is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get receipt access"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	accesses, err := svc.GetReceiptAccesses(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get receipt access"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListReceiptAccessAPIResponse{APIResponse: APIResponse{}, List: accesses})
+}
+
+// GrantReceiptAccess godoc
+// @Summary Share receipt with user
+// @Description grant viewer or editor access to the receipt, only owner is permitted
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param   user_id     path    int     true        "User id"
+// @Param access body services.GrantReceiptAccessRequest true "params"
+// @Success 200 {object} handler.ReceiptAccessAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/access/{user_id} [put]
+func (*Controller) GrantReceiptAccess(c *gin.Context) {
+	var request services.GrantReceiptAccessRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to share receipt is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to share receipt is invalid"})
+		return
+	}
+
+	granteeIdParam := c.Param("user_id")
+	granteeId, err := strconv.Atoi(granteeIdParam)
+	if err != nil || granteeId == 0 {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to share receipt is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to share receipt"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.GrantReceiptAccess(uint(id), uint(granteeId), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when share receipt"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ReceiptAccessAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// RevokeReceiptAccess godoc
+// @Summary Stop sharing receipt with user
+// @Description revoke user access to the receipt, only owner is permitted
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param   user_id     path    int     true        "User id"
+// @Success 204
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/access/{user_id} [delete]
+func (*Controller) RevokeReceiptAccess(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to revoke receipt access is invalid"})
+		return
+	}
+
+	granteeIdParam := c.Param("user_id")
+	granteeId, err := strconv.Atoi(granteeIdParam)
+	if err != nil || granteeId == 0 {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to revoke receipt access is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to revoke receipt access"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	err = svc.RevokeReceiptAccess(uint(id), uint(granteeId), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when revoke receipt access"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+const (
+	maxBulkImportDocumentSize = 20 << 20
+	maxBulkImportArchiveSize  = 200 << 20
+)
+
+var bulkExportContentTypes = map[string]string{
+	services.BulkExportZip:  "application/zip",
+	services.BulkExportJSON: "application/json; charset=utf-8",
+	services.BulkExportCSV:  "text/csv; charset=utf-8",
+}
+
+type BulkImportAPIResponse struct {
+	APIResponse
+	Item services.BulkImportResult `json:"item"`
+}
+
+// BulkImportReceipts godoc
+// @Summary Import many receipts
+// @Description accepts JSON array of receipts or zip archive with receipts.json and images it references. Every record is validated and reported separately, valid records are saved in batches, every batch in one transaction.
+// @Tags receipts
+// @Accept  json
+// @Accept  application/zip
+// @Produce  json
+// @Param receipts body services.BulkReceiptRecord true "array of receipts"
+// @Param dry_run query bool false "only validate the records"
+// @Param batch_size query int false "receipts saved in one transaction, 50 by default"
+// @Success 200 {object} handler.BulkImportAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/bulk-import [post]
+func (*Controller) BulkImportReceipts(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	options := services.BulkImportOptions{DryRun: c.Query("dry_run") == "true"}
+	if batchSizeParam := c.Query("batch_size"); batchSizeParam != "" {
+		batchSize, err := strconv.Atoi(batchSizeParam)
+		if err != nil || batchSize < 1 {
+			c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to import receipts is invalid. Batch size should be positive"})
+			return
+		}
+		options.BatchSize = batchSize
+	}
+
+	isArchive := c.ContentType() == "application/zip" || c.ContentType() == "application/x-zip-compressed"
+	limit := int64(maxBulkImportDocumentSize)
+	if isArchive {
+		limit = maxBulkImportArchiveSize
+	}
+	data, err := ioutil.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, limit))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request to import receipts is invalid. Body should not be larger than %d bytes", limit)})
+		return
+	}
+
+	var records []services.BulkReceiptRecord
+	if !isArchive {
+		err = json.Unmarshal(data, &records)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to import receipts is invalid. JSON array of receipts or zip archive is expected"})
+			return
+		}
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to import receipts"})
+		return
+	}
+
+	svc := services.GetReceiptBulkService(db)
+	var result services.BulkImportResult
+	if isArchive {
+		result, err = svc.ImportArchive(userClaims.Id, data, options)
+	} else {
+		result, err = svc.Import(userClaims.Id, records, nil, options)
+	}
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when import receipts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, BulkImportAPIResponse{APIResponse: APIResponse{}, Item: result})
+}
+
+// BulkExportReceipts godoc
+// @Summary Export all own receipts
+// @Description zip archive contains receipts.json which can be imported back, receipts.csv and images
+// @Tags receipts
+// @Produce  application/zip
+// @Produce  json
+// @Produce  text/csv
+// @Param format query string false "'zip' (default), 'json' or 'csv'"
+// @Success 200 {array} services.BulkReceiptRecord
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/bulk-export [get]
+func (*Controller) BulkExportReceipts(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	format := c.DefaultQuery("format", services.BulkExportZip)
+	contentType, ok := bulkExportContentTypes[format]
+	if !ok {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Export format `%s` is not supported", format)})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to export receipts"})
+		return
+	}
+
+	svc := services.GetReceiptBulkService(db)
+	document := &bytes.Buffer{}
+	err = svc.Export(document, userClaims.Id, format)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when export receipts"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=receipts.%s", format))
+	c.Data(http.StatusOK, contentType, document.Bytes())
+}
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type ListReceiptCommentAPIResponse struct {
+	APIResponse
+	List       []*receipt.ReceiptComment `json:"list"`
+	Pagination tools.Pagination          `json:"pagination"`
+}
+
+type ReceiptCommentAPIResponse struct {
+	APIResponse
+	Item receipt.ReceiptComment `json:"item"`
+}
+
+// GetReceiptComments godoc
+// @Summary Get receipt comments
+// @Description find receipt comment threads with nested replies, oldest first
+// @Tags comments
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param page query int false "page number, starting from 1"
+// @Param per_page query int false "threads per page, 100 max"
+// @Success 200 {object} handler.ListReceiptCommentAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/comments [get]
+func (*Controller) GetReceiptComments(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get receipt comments is invalid"})
+		return
+	}
+
+	pagination, err := getPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get receipt comments is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get receipt comments"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	comments, pagination, err := svc.GetReceiptCommentThreads(uint(id), userClaims.Id, pagination)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get receipt comments"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListReceiptCommentAPIResponse{APIResponse: APIResponse{}, List: comments, Pagination: pagination})
+}
+
+// CreateReceiptComment godoc
+// @Summary Comment receipt
+// @Description create new thread or reply to the comment given by parent_id
+// @Tags comments
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param comment body services.CreateReceiptCommentRequest true "params"
+// @Success 200 {object} handler.ReceiptCommentAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/comments [post]
+func (*Controller) CreateReceiptComment(c *gin.Context) {
+	var request services.CreateReceiptCommentRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to create comment is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to process request"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.CreateReceiptComment(uint(id), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when create comment"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ReceiptCommentAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// UpdateReceiptComment godoc
+// @Summary Update receipt comment
+// @Tags comments
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param   comment_id     path    int     true        "Comment id"
+// @Param comment body services.UpdateReceiptCommentRequest true "params"
+// @Success 200 {object} handler.ReceiptCommentAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/comments/{comment_id} [put]
+func (*Controller) UpdateReceiptComment(c *gin.Context) {
+	var request services.UpdateReceiptCommentRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update comment is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update comment is invalid"})
+		return
+	}
+
+	commentIdParam := c.Param("comment_id")
+	commentId, err := strconv.Atoi(commentIdParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update comment is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to update comment"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.UpdateReceiptComment(uint(id), uint(commentId), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusForbidden, APIResponse{Message: fmt.Sprintf("Not permitted")})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when update comment"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ReceiptCommentAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// DeleteReceiptComment godoc
+// @Summary Delete receipt comment
+// @Description permitted to the comment author and the receipt owner
+// @Tags comments
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param   comment_id     path    int     true        "Comment id"
+// @Success 204
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/comments/{comment_id} [delete]
+func (*Controller) DeleteReceiptComment(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to delete comment is invalid"})
+		return
+	}
+
+	commentIdParam := c.Param("comment_id")
+	commentId, err := strconv.Atoi(commentIdParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to delete comment is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to delete comment"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	err = svc.DeleteReceiptComment(uint(id), uint(commentId), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusForbidden, APIResponse{Message: fmt.Sprintf("Not permitted")})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when delete comment"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -22,22 +22,86 @@ type ListAPIResponse struct {
 type ReceiptAPIResponse struct {
 	APIResponse
 	Item receipt.Receipt `json:"item"`
+	// likely duplicates in the account, returned when the receipt is created
+	Duplicates []receipt.Duplicate `json:"duplicates,omitempty"`
 }
 
 // GetReceipts godoc
 // @Summary Get receipts
-// @Description find receipts by params
+// @Description find public receipts and private ones owned by or shared with the current user
 // @Tags receipts
 // @Produce  json
 // @Param category query string false "category"
+// @Param min_rating query number false "minimal average rating"
+// @Param difficulty query string false "easy, medium or hard"
+// @Param cuisine query string false "cuisine"
+// @Param course query string false "course, like breakfast, main or dessert"
+// @Param max_prep_time query int false "maximal prep time in minutes"
+// @Param max_cook_time query int false "maximal cook time in minutes"
+// @Param max_rest_time query int false "maximal rest time in minutes"
+// @Param max_total_time query int false "maximal total time in minutes"
+// @Param diet query string false "comma separated diet labels all receipts should have: vegan, vegetarian, gluten-free or nut-free"
+// @Param exclude_allergens query string false "comma separated allergens no ingredient should have: gluten, dairy, eggs, nuts, peanuts, soy, fish, shellfish or sesame"
+// @Param sort query string false "sort by rating, ratings_count, created_at or total_time, prefix with '-' for descending order"
+// @Param If-None-Match header string false "entity tag of the cached response"
+// @Param lang query string false "language of translations, en, uk or original for untranslated items, Accept-Language is used when omitted"
+// @Param Accept-Language header string false "preferred languages of translations"
 // @Success 200 {object} handler.ListAPIResponse
+// @Success 304
 // @Failure 401 {object} handler.APIResponse
 // @Failure 400 {object} handler.APIResponse
 // @Failure 500 {object} handler.APIResponse
 // @Security ApiKeyAuth
 // @Router /v1/receipts/ [get]
 func (*Controller) GetReceipts(c *gin.Context) {
-	category := c.Request.URL.Query().Get("category")
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	query := c.Request.URL.Query()
+	filter := receipt.ListFilter{
+		Category:         query.Get("category"),
+		Difficulty:       query.Get("difficulty"),
+		Cuisine:          query.Get("cuisine"),
+		Course:           query.Get("course"),
+		Sort:             query.Get("sort"),
+		Diets:            splitQueryList(query.Get("diet")),
+		ExcludeAllergens: splitQueryList(query.Get("exclude_allergens")),
+	}
+	if minRating := query.Get("min_rating"); minRating != "" {
+		var err error
+		filter.MinRating, err = strconv.ParseFloat(minRating, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get receipts is invalid"})
+			return
+		}
+	}
+	times := map[string]*uint{
+		"max_prep_time":  &filter.MaxPrepTime,
+		"max_cook_time":  &filter.MaxCookTime,
+		"max_rest_time":  &filter.MaxRestTime,
+		"max_total_time": &filter.MaxTotalTime,
+	}
+	for param, value := range times {
+		if minutes := query.Get(param); minutes != "" {
+			parsed, err := strconv.ParseUint(minutes, 10, 32)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get receipts is invalid"})
+				return
+			}
+			*value = uint(parsed)
+		}
+	}
+
+	language, err := getLanguage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+		return
+	}
+
 	db, err := database.GetDB()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get receipts"})
@@ -46,19 +110,97 @@ func (*Controller) GetReceipts(c *gin.Context) {
 
 
 	receiptService := services.GetReceiptService(db)
-	categories, err := receiptService.GetAllReceiptsByCategory(category)
+	categories, err := receiptService.GetAllReceipts(userClaims.Id, filter)
+	if err == nil {
+		err = receiptService.TranslateReceipts(language, categories)
+	}
 	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
 		log.Printf("get receipts error: `%s`", err)
 		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get receipts"})
 		return
 	}
 
 
-	c.JSON(http.StatusOK, ListAPIResponse{APIResponse: APIResponse{}, List: categories})
+	writeTaggedJSON(c, tools.ETag(categories), ListAPIResponse{APIResponse: APIResponse{}, List: categories})
+}
+
+// GetReceipt godoc
+// @Summary Get receipt
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param If-None-Match header string false "entity tag of the cached response"
+// @Param lang query string false "language of translations, en, uk or original for untranslated items, Accept-Language is used when omitted"
+// @Param Accept-Language header string false "preferred languages of translations"
+// @Success 200 {object} handler.ReceiptAPIResponse
+// @Success 304
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id} [get]
+func (*Controller) GetReceipt(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get receipt is invalid"})
+		return
+	}
+
+	language, err := getLanguage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get receipt"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.GetReceipt(uint(id), userClaims.Id)
+	// the tag of the stored receipt is kept, so the translated one can be updated with it
+	etag := i.ETag()
+	if err == nil {
+		err = svc.TranslateReceipt(language, &i)
+	}
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get receipt"})
+		return
+	}
+
+	writeTaggedJSON(c, tools.TranslatedETag(etag, language, i), ReceiptAPIResponse{APIResponse: APIResponse{}, Item: i})
 }
 
 // CreateReceipt godoc
 // @Summary Create receipt
+// @Description receipts with a similar name in the account are listed in 'duplicates', the receipt is created anyway
 // @Tags receipts
 // @Produce  json
 // @Param ingredient body services.CreateReceiptRequest true "params"
@@ -107,8 +249,11 @@ func (*Controller) CreateReceipt(c *gin.Context) {
 		return
 	}
 
-
-	c.JSON(http.StatusOK, ReceiptAPIResponse{APIResponse: APIResponse{}, Item: i})
+	writeTaggedJSON(c, i.ETag(), ReceiptAPIResponse{
+		APIResponse: APIResponse{},
+		Item:        i,
+		Duplicates:  likelyDuplicates(svc, userClaims.Id, receipt.FullReceipt{Receipt: i}),
+	})
 }
 
 // UpdateReceipt godoc
@@ -117,10 +262,12 @@ func (*Controller) CreateReceipt(c *gin.Context) {
 // @Produce  json
 // @Param   id     path    int     true        "Receipt id"
 // @Param ingredient body services.UpdateReceiptRequest true "params"
+// @Param If-Match header string false "entity tag of the item, it is not changed when it has another one"
 // @Success 200 {object} handler.ReceiptAPIResponse
 // @Failure 401 {object} handler.APIResponse
 // @Failure 400 {object} handler.APIResponse
 // @Failure 403 {object} handler.APIResponse
+// @Failure 412 {object} handler.APIResponse
 // @Failure 500 {object} handler.APIResponse
 // @Security ApiKeyAuth
 // @Router /v1/receipts/{id} [put]
@@ -153,13 +300,16 @@ func (*Controller) UpdateReceipt(c *gin.Context) {
 	}
 
 	svc := services.GetReceiptService(db)
-	i, err := svc.UpdateReceipt(uint(id), userClaims.Id, request)
+	i, err := svc.UpdateReceipt(uint(id), userClaims.Id, request, c.GetHeader(ifMatchHeader))
 	if err != nil {
 		switch errors.Cause(err).(type) {
 		case *tools.NotPermittedErr:
 			log.Printf("validate error %s", err)
 			c.JSON(http.StatusForbidden, APIResponse{Message: fmt.Sprintf("Not permitted")})
 			return
+		case *tools.PreconditionFailedErr:
+			c.JSON(http.StatusPreconditionFailed, APIResponse{Message: "Item was changed, get the current version"})
+			return
 		case *tools.ValidationErr:
 			log.Printf("validate error %s", err)
 			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
@@ -171,17 +321,22 @@ func (*Controller) UpdateReceipt(c *gin.Context) {
 	}
 
 
-	c.JSON(http.StatusOK, ReceiptAPIResponse{APIResponse: APIResponse{}, Item: i})
+	writeTaggedJSON(c, i.ETag(), ReceiptAPIResponse{APIResponse: APIResponse{}, Item: i})
 }
 
 // DeleteReceipt godoc
 // @Summary Delete receipt
+// @Description move the receipt to trash, or delete it with its images permanently
 // @Tags receipts
 // @Produce  json
 // @Param   id     path    int     true        "Receipt id"
+// @Param permanent query bool false "delete permanently instead of moving to trash"
+// @Param If-Match header string false "entity tag of the item, it is not changed when it has another one"
 // @Success 204
 // @Failure 401 {object} handler.APIResponse
 // @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 412 {object} handler.APIResponse
 // @Failure 500 {object} handler.APIResponse
 // @Security ApiKeyAuth
 // @Router /v1/receipts/{id} [delete]
@@ -210,9 +365,15 @@ func (*Controller) DeleteReceipt(c *gin.Context) {
 	}
 
 	svc := services.GetReceiptService(db)
-	err = svc.DeleteReceipt(uint(id), userClaims.Id)
+	err = svc.DeleteReceipt(uint(id), userClaims.Id, c.Query("permanent") == "true", c.GetHeader(ifMatchHeader))
 	if err != nil {
 		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.PreconditionFailedErr:
+			c.JSON(http.StatusPreconditionFailed, APIResponse{Message: "Item was changed, get the current version"})
+			return
 		case *tools.ValidationErr:
 			log.Printf("validate error %s", err)
 			c.JSON(http.StatusUnauthorized, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
@@ -229,7 +390,7 @@ func (*Controller) DeleteReceipt(c *gin.Context) {
 
 // UploadReceiptMedia godoc
 // @Summary Update a receipt media
-// @Description upload a receipt media
+// @Description upload a receipt image, it is added to the gallery and becomes the cover
 // @Tags receipts
 // @Accept  multipart/form-data
 // @Produce json
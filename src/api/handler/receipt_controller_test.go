@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"food/src/api/database"
+	"food/src/api/database/dbtest"
+	"food/src/api/jwt_auth"
+	"food/src/api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newTestRouter serves receipts to the user like the authorized API does.
+func newTestRouter(t *testing.T, userId uint) *gin.Engine {
+	_, err := database.InitDB(os.Getenv(dbtest.DSNEnv))
+	if err != nil {
+		t.Fatalf("cannot connect to database: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("claims", &jwt_auth.UserClaims{Id: userId})
+	})
+	c := NewController()
+	r.GET("/v1/receipts/:id", c.GetReceipt)
+	r.PUT("/v1/receipts/:id", c.UpdateReceipt)
+	r.GET("/v1/receipts/:id/full", c.GetFullReceipt)
+	r.PUT("/v1/receipts/:id/full", c.ReplaceFullReceipt)
+	return r
+}
+
+func serve(r *gin.Engine, method, path string, header http.Header, body interface{}) *httptest.ResponseRecorder {
+	var content bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&content).Encode(body)
+	}
+	request := httptest.NewRequest(method, path, &content)
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	request.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, request)
+	return w
+}
+
+func TestTranslatedReceiptIfMatch(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	s := services.GetReceiptService(db)
+
+	userId := dbtest.CreateUser(t, db, "cook")
+	i, err := s.CreateReceipt(userId, services.CreateReceiptRequest{
+		Name:        "Borscht",
+		Description: "Beetroot soup",
+		Category:    "soups",
+		CookTime:    60,
+	})
+	if err != nil {
+		t.Fatalf("cannot create receipt: %v", err)
+	}
+	_, err = s.SaveReceiptTranslation(i.Id, userId, "uk", services.SaveReceiptTranslationRequest{Name: "Борщ", Description: "Буряковий суп"})
+	if err != nil {
+		t.Fatalf("cannot translate receipt: %v", err)
+	}
+	r := newTestRouter(t, userId)
+	path := fmt.Sprintf("/v1/receipts/%d", i.Id)
+
+	original := serve(r, http.MethodGet, path, nil, nil)
+	translated := serve(r, http.MethodGet, path+"?lang=uk", nil, nil)
+	if translated.Code != http.StatusOK || !bytes.Contains(translated.Body.Bytes(), []byte("Борщ")) {
+		t.Fatalf("translated receipt is not returned: %d %s", translated.Code, translated.Body)
+	}
+	etag := translated.Header().Get("ETag")
+	if etag == original.Header().Get("ETag") {
+		t.Errorf("translated receipt has the tag %s of the original one", etag)
+	}
+
+	// the original is returned whatever languages are accepted
+	w := serve(r, http.MethodGet, path+"?lang=original", http.Header{"Accept-Language": {"uk"}}, nil)
+	if w.Header().Get("ETag") != original.Header().Get("ETag") || bytes.Contains(w.Body.Bytes(), []byte("Борщ")) {
+		t.Errorf("original receipt is not returned: %s", w.Body)
+	}
+	w = serve(r, http.MethodGet, path+"?lang=uk", http.Header{"If-None-Match": {etag}}, nil)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("cached translation got %d, expected 304", w.Code)
+	}
+
+	update := services.UpdateReceiptRequest{Name: "Green borscht", Description: "Sorrel soup", Category: "soups", CookTime: 60}
+	w = serve(r, http.MethodPut, path, http.Header{"If-Match": {etag}}, update)
+	if w.Code != http.StatusOK {
+		t.Fatalf("update with the tag of the translation got %d: %s", w.Code, w.Body)
+	}
+	w = serve(r, http.MethodPut, path, http.Header{"If-Match": {etag}}, update)
+	if w.Code != http.StatusPreconditionFailed {
+		t.Errorf("update with the stale tag of the translation got %d, expected 412", w.Code)
+	}
+
+	full := serve(r, http.MethodGet, path+"/full?lang=uk", nil, nil)
+	if full.Code != http.StatusOK {
+		t.Fatalf("cannot get full receipt: %d %s", full.Code, full.Body)
+	}
+	replace := services.FullReceiptRequest{CreateReceiptRequest: services.CreateReceiptRequest(update)}
+	replace.Name = "Borscht"
+	w = serve(r, http.MethodPut, path+"/full", http.Header{"If-Match": {full.Header().Get("ETag")}}, replace)
+	if w.Code != http.StatusOK {
+		t.Errorf("replace with the tag of the translation got %d: %s", w.Code, w.Body)
+	}
+}
@@ -30,13 +30,24 @@ type ReceiptDirectionAPIResponse struct {
 // @Tags receipts
 // @Produce  json
 // @Param   id     path    int     true        "Receipt id"
+// @Param If-None-Match header string false "entity tag of the cached response"
+// @Param lang query string false "language of translations, en, uk or original for untranslated items, Accept-Language is used when omitted"
+// @Param Accept-Language header string false "preferred languages of translations"
 // @Success 200 {object} handler.ListReceiptDirectionAPIResponse
+// @Success 304
 // @Failure 401 {object} handler.APIResponse
 // @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
 // @Failure 500 {object} handler.APIResponse
 // @Security ApiKeyAuth
 // @Router /v1/receipts/{id}/directions [get]
 func (*Controller) GetReceiptDirections(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
 
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
@@ -45,6 +56,12 @@ func (*Controller) GetReceiptDirections(c *gin.Context) {
 		return
 	}
 
+	language, err := getLanguage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+		return
+	}
+
 	db, err := database.GetDB()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get receipts"})
@@ -53,15 +70,27 @@ func (*Controller) GetReceiptDirections(c *gin.Context) {
 
 
 	receiptService := services.GetReceiptService(db)
-	directions, err := receiptService.GetAllReceiptDirectionsById(uint(id))
+	directions, err := receiptService.GetAllReceiptDirectionsById(uint(id), userClaims.Id)
+	if err == nil {
+		err = receiptService.TranslateReceiptDirections(language, directions)
+	}
 	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
 		log.Printf("internal error: `%s`", err)
 		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get receipt directions"})
 		return
 	}
 
 
-	c.JSON(http.StatusOK, ListReceiptDirectionAPIResponse{APIResponse: APIResponse{}, List: directions})
+	writeTaggedJSON(c, tools.ETag(directions), ListReceiptDirectionAPIResponse{APIResponse: APIResponse{}, List: directions})
 }
 
 // CreateReceiptDirection godoc
@@ -123,7 +152,7 @@ func (*Controller) CreateReceiptDirection(c *gin.Context) {
 	}
 
 
-	c.JSON(http.StatusOK, ReceiptDirectionAPIResponse{APIResponse: APIResponse{}, Item: i})
+	writeTaggedJSON(c, i.ETag(), ReceiptDirectionAPIResponse{APIResponse: APIResponse{}, Item: i})
 }
 
 // UpdateReceiptDirection godoc
@@ -133,14 +162,21 @@ func (*Controller) CreateReceiptDirection(c *gin.Context) {
 // @Param   id     path    int     true        "Receipt id"
 // @Param   direction_id     path    int     true        "Receipt direction id"
 // @Param direction body services.UpdateReceiptDirectionRequest true "params"
+// @Param If-Match header string false "entity tag of the item, it is not changed when it has another one"
 // @Success 200 {object} handler.ReceiptDirectionAPIResponse
 // @Failure 401 {object} handler.APIResponse
 // @Failure 400 {object} handler.APIResponse
 // @Failure 403 {object} handler.APIResponse
+// @Failure 412 {object} handler.APIResponse
 // @Failure 500 {object} handler.APIResponse
 // @Security ApiKeyAuth
 // @Router /v1/receipts/{id}/directions/{direction_id} [put]
-func (*Controller) UpdateReceiptDirection(c *gin.Context) {
+func (ctrl *Controller) UpdateReceiptDirection(c *gin.Context) {
+	if c.Param("direction_id") == orderSegment {
+		ctrl.ReorderReceiptDirections(c)
+		return
+	}
+
 	var request services.UpdateReceiptDirectionRequest
 	err := c.ShouldBindJSON(&request)
 	if err != nil {
@@ -176,13 +212,16 @@ func (*Controller) UpdateReceiptDirection(c *gin.Context) {
 	}
 
 	svc := services.GetReceiptService(db)
-	i, err := svc.UpdateReceiptDirection(uint(id), uint(directionId), userClaims.Id, request)
+	i, err := svc.UpdateReceiptDirection(uint(id), uint(directionId), userClaims.Id, request, c.GetHeader(ifMatchHeader))
 	if err != nil {
 		switch errors.Cause(err).(type) {
 		case *tools.NotPermittedErr:
 			log.Printf("validate error %s", err)
 			c.JSON(http.StatusForbidden, APIResponse{Message: fmt.Sprintf("Not permitted")})
 			return
+		case *tools.PreconditionFailedErr:
+			c.JSON(http.StatusPreconditionFailed, APIResponse{Message: "Item was changed, get the current version"})
+			return
 		case *tools.ValidationErr:
 			log.Printf("validate error %s", err)
 			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
@@ -194,7 +233,7 @@ func (*Controller) UpdateReceiptDirection(c *gin.Context) {
 	}
 
 
-	c.JSON(http.StatusOK, ReceiptDirectionAPIResponse{APIResponse: APIResponse{}, Item: i})
+	writeTaggedJSON(c, i.ETag(), ReceiptDirectionAPIResponse{APIResponse: APIResponse{}, Item: i})
 }
 
 // DeleteReceiptDirection godoc
@@ -203,10 +242,12 @@ func (*Controller) UpdateReceiptDirection(c *gin.Context) {
 // @Produce  json
 // @Param   id     path    int     true        "Receipt id"
 // @Param   direction_id     path    int     true        "Receipt direction id"
+// @Param If-Match header string false "entity tag of the item, it is not changed when it has another one"
 // @Success 204
 // @Failure 401 {object} handler.APIResponse
 // @Failure 400 {object} handler.APIResponse
 // @Failure 403 {object} handler.APIResponse
+// @Failure 412 {object} handler.APIResponse
 // @Failure 500 {object} handler.APIResponse
 // @Security ApiKeyAuth
 // @Router /v1/receipts/{id}/directions/{direction_id} [delete]
@@ -239,13 +280,16 @@ func (*Controller) DeleteReceiptDirection(c *gin.Context) {
 	}
 
 	svc := services.GetReceiptService(db)
-	err = svc.DeleteReceiptDirection(uint(id), uint(directionId), userClaims.Id)
+	err = svc.DeleteReceiptDirection(uint(id), uint(directionId), userClaims.Id, c.GetHeader(ifMatchHeader))
 	if err != nil {
 		switch errors.Cause(err).(type) {
 		case *tools.NotPermittedErr:
 			log.Printf("validate error %s", err)
 			c.JSON(http.StatusForbidden, APIResponse{Message: fmt.Sprintf("Not permitted")})
 			return
+		case *tools.PreconditionFailedErr:
+			c.JSON(http.StatusPreconditionFailed, APIResponse{Message: "Item was changed, get the current version"})
+			return
 		case *tools.ValidationErr:
 			log.Printf("validate error %s", err)
 			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
@@ -259,3 +303,150 @@ func (*Controller) DeleteReceiptDirection(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// ReorderReceiptDirections godoc
+// @Summary Reorder receipt directions
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param order body services.ReorderReceiptDirectionsRequest true "params"
+// @Success 200 {object} handler.ListReceiptDirectionAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/directions/order [put]
+func (*Controller) ReorderReceiptDirections(c *gin.Context) {
+	var request services.ReorderReceiptDirectionsRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to reorder receipt directions is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to reorder receipt directions is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to reorder receipt directions"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	list, err := svc.ReorderReceiptDirections(uint(id), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when reorder receipt directions"})
+		return
+	}
+
+	writeTaggedJSON(c, tools.ETag(list), ListReceiptDirectionAPIResponse{APIResponse: APIResponse{}, List: list})
+}
+
+// UploadReceiptDirectionMedia godoc
+// @Summary Upload receipt step image
+// @Tags receipts
+// @Accept  multipart/form-data
+// @Produce json
+// @Param   id     path    int     true        "Receipt id"
+// @Param   direction_id     path    int     true        "Direction id"
+// @Param media_file formData file true "Media file"
+// @Success 200 {object} handler.ReceiptDirectionAPIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/directions/{direction_id}/media [post]
+func (*Controller) UploadReceiptDirectionMedia(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update direction is invalid"})
+		return
+	}
+
+	directionIdParam := c.Param("direction_id")
+	directionId, err := strconv.Atoi(directionIdParam)
+	if err != nil || directionId == 0 {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update direction is invalid"})
+		return
+	}
+
+	if c.ContentType() == "" {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Message: http.ErrNotMultipart.Error(),
+		})
+		return
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Message: fmt.Sprintf("Given request isn't valid multipart/form-data. Orig err: `%s`", err.Error()),
+		})
+		return
+	}
+
+	if len(form.File) != 1 || len(form.File["media_file"]) != 1 {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Message: "Given multipart form should contain exactly 1 media file.",
+		})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to update direction media"})
+		return
+	}
+
+	fileHeader := form.File["media_file"][0]
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.UpdateReceiptDirectionMedia(uint(id), uint(directionId), userClaims.Id, fileHeader, services.Options{Filename: "step"})
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			c.JSON(http.StatusBadRequest, APIResponse{Message: err.Error()})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to update direction media"})
+		return
+	}
+
+	writeTaggedJSON(c, i.ETag(), ReceiptDirectionAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
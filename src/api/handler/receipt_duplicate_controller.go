@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type ListDuplicateClusterAPIResponse struct {
+	APIResponse
+	List []receipt.DuplicateCluster `json:"list"`
+}
+
+// likelyDuplicates finds duplicates of the created receipt, they are only a warning
+// so errors are logged and nothing is returned.
+func likelyDuplicates(svc *services.Receipt, userId uint, i receipt.FullReceipt) []receipt.Duplicate {
+	duplicates, err := svc.FindDuplicates(userId, i)
+	if err != nil {
+		log.Printf("receipt `%d` duplicates error: `%s`", i.Id, err)
+		return nil
+	}
+	return duplicates
+}
+
+// GetDuplicateReceipts godoc
+// @Summary Get duplicate receipts
+// @Description find groups of receipts of the current user which are likely duplicates,
+// @Description compared by name, ingredients and direction texts
+// @Tags receipts
+// @Produce  json
+// @Success 200 {object} handler.ListDuplicateClusterAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/duplicates [get]
+func (*Controller) GetDuplicateReceipts(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get duplicates"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	clusters, err := svc.GetDuplicateClusters(userClaims.Id)
+	if err != nil {
+		log.Printf("get duplicates error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get duplicates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListDuplicateClusterAPIResponse{APIResponse: APIResponse{}, List: clusters})
+}
+
+// MergeReceipts godoc
+// @Summary Merge duplicate receipts
+// @Description move favorites, cookbook and meal plan entries, reviews, comments and images of the duplicates
+// @Description to the receipt, then move the duplicates to trash. All receipts should be owned by the current user
+// @Tags receipts
+// @Accept  json
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param merge body services.MergeReceiptsRequest true "params"
+// @Success 200 {object} handler.ReceiptAPIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/merge [post]
+func (*Controller) MergeReceipts(c *gin.Context) {
+	var request services.MergeReceiptsRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to merge receipts is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to merge receipts is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to merge receipts"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	item, err := svc.MergeReceipts(uint(id), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when merge receipts"})
+		return
+	}
+
+	writeTaggedJSON(c, item.ETag(), ReceiptAPIResponse{APIResponse: APIResponse{}, Item: item})
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/cooklang"
+	"food/src/api/models/receipt"
+	"food/src/api/models/schemaorg"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+const (
+	jsonLDExportFormat   = "jsonld"
+	cooklangExportFormat = "cooklang"
+)
+
+// ExportReceipt godoc
+// @Summary Export receipt
+// @Description receipt is exported as schema.org Recipe in JSON-LD or as Cooklang-style plain text
+// @Tags receipts
+// @Produce  application/ld+json
+// @Produce  plain
+// @Param   id     path    int     true        "Receipt id"
+// @Param   format query   string  false       "export format, 'jsonld' (default) or 'cooklang'"
+// @Success 200 {object} schemaorg.Document
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/export [get]
+func (*Controller) ExportReceipt(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to export receipt is invalid"})
+		return
+	}
+
+	format := c.DefaultQuery("format", jsonLDExportFormat)
+	if format != jsonLDExportFormat && format != cooklangExportFormat {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Export format `%s` is not supported", format)})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to export receipt"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	if format == cooklangExportFormat {
+		document := &bytes.Buffer{}
+		err = svc.WriteReceiptCooklang(document, uint(id), userClaims.Id)
+		if err != nil {
+			switch errors.Cause(err).(type) {
+			case *tools.NotPermittedErr:
+				c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+				return
+			case *tools.ValidationErr:
+				log.Printf("validate error %s", err)
+				c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+				return
+			}
+			log.Printf("internal error: `%s`", err)
+			c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when export receipt"})
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("inline; filename=receipt-%d%s", id, cooklang.Extension))
+		c.Data(http.StatusOK, cooklang.MediaType, document.Bytes())
+		return
+	}
+
+	i, err := svc.GetFullReceipt(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when export receipt"})
+		return
+	}
+
+	writeReceiptJSONLD(c, i)
+}
+
+func writeReceiptJSONLD(c *gin.Context, i receipt.FullReceipt) {
+	mediaUrl := func(link string) string {
+		return fmt.Sprintf("%s/v1/media/%s", baseUrl(c), link)
+	}
+	data, err := json.Marshal(schemaorg.NewDocument(i, mediaUrl))
+	if err != nil {
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when export receipt"})
+		return
+	}
+	c.Data(http.StatusOK, schemaorg.MediaType+"; charset=utf-8", data)
+}
+
+// ExportReceiptPDF godoc
+// @Summary Export receipt as PDF
+// @Description printable receipt with photo, ingredients and numbered directions
+// @Tags receipts
+// @Produce  application/pdf
+// @Param   id     path    int     true        "Receipt id"
+// @Success 200 {array} integer
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/export.pdf [get]
+func (*Controller) ExportReceiptPDF(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to export receipt is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to export receipt"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	document := &bytes.Buffer{}
+	err = svc.WriteReceiptPDF(document, uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when export receipt"})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=receipt-%d.pdf", id))
+	c.Data(http.StatusOK, "application/pdf", document.Bytes())
+}
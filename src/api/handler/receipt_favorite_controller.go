@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// GetFavoriteReceipts godoc
+// @Summary Get favorite receipts
+// @Description find receipts favorited by the current user, recently added first
+// @Tags favorites
+// @Produce  json
+// @Success 200 {object} handler.ListAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/favorites [get]
+func (*Controller) GetFavoriteReceipts(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get favorites"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	receipts, err := svc.GetFavoriteReceipts(userClaims.Id)
+	if err != nil {
+		log.Printf("get favorites error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get favorites"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListAPIResponse{APIResponse: APIResponse{}, List: receipts})
+}
+
+// AddFavoriteReceipt godoc
+// @Summary Add receipt to favorites
+// @Tags favorites
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Success 204
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/favorite [put]
+func (*Controller) AddFavoriteReceipt(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to add favorite is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to add favorite"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	err = svc.AddFavoriteReceipt(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when add favorite"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveFavoriteReceipt godoc
+// @Summary Remove receipt from favorites
+// @Tags favorites
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Success 204
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/favorite [delete]
+func (*Controller) RemoveFavoriteReceipt(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to remove favorite is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to remove favorite"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	err = svc.RemoveFavoriteReceipt(uint(id), userClaims.Id)
+	if err != nil {
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when remove favorite"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
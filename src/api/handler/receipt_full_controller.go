@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/receipt"
+	"food/src/api/models/schemaorg"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type FullReceiptAPIResponse struct {
+	APIResponse
+	Item receipt.FullReceipt `json:"item"`
+	// likely duplicates in the account, returned when the receipt is created or imported
+	Duplicates []receipt.Duplicate `json:"duplicates,omitempty"`
+}
+
+// GetFullReceipt godoc
+// @Summary Get receipt with ingredients and directions
+// @Description schema.org Recipe is returned when 'application/ld+json' is accepted
+// @Tags receipts
+// @Produce  json
+// @Produce  application/ld+json
+// @Param   id     path    int     true        "Receipt id"
+// @Param If-None-Match header string false "entity tag of the cached response"
+// @Param lang query string false "language of translations, en, uk or original for untranslated items, Accept-Language is used when omitted"
+// @Param Accept-Language header string false "preferred languages of translations"
+// @Success 200 {object} handler.FullReceiptAPIResponse
+// @Success 304
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/full [get]
+func (*Controller) GetFullReceipt(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get receipt is invalid"})
+		return
+	}
+
+	language, err := getLanguage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get receipt"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.GetFullReceipt(uint(id), userClaims.Id)
+	// the tag of the stored receipt is kept, so the translated one can be replaced with it
+	etag := i.ETag()
+	if err == nil {
+		err = svc.TranslateFullReceipt(language, &i)
+	}
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get receipt"})
+		return
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, schemaorg.MediaType) == schemaorg.MediaType {
+		writeReceiptJSONLD(c, i)
+		return
+	}
+	writeTaggedJSON(c, tools.TranslatedETag(etag, language, i), FullReceiptAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// CreateFullReceipt godoc
+// @Summary Create receipt with ingredients and directions
+// @Description whole receipt is created in one transaction, ingredients given by name are created when missing.
+// @Description Likely duplicates in the account are listed in 'duplicates', the receipt is created anyway
+// @Tags receipts
+// @Produce  json
+// @Param receipt body services.FullReceiptRequest true "params"
+// @Success 200 {object} handler.FullReceiptAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/full [post]
+func (*Controller) CreateFullReceipt(c *gin.Context) {
+	var request services.FullReceiptRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to create receipt is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to create receipt"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.CreateFullReceipt(userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when create receipt"})
+		return
+	}
+
+	writeTaggedJSON(c, i.ETag(), FullReceiptAPIResponse{
+		APIResponse: APIResponse{},
+		Item:        i,
+		Duplicates:  likelyDuplicates(svc, userClaims.Id, i),
+	})
+}
+
+// ReplaceFullReceipt godoc
+// @Summary Replace receipt with ingredients and directions
+// @Description receipt fields, all ingredients and directions are replaced in one transaction, ingredients given by name are created when missing
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param receipt body services.FullReceiptRequest true "params"
+// @Param If-Match header string false "entity tag of the item, it is not changed when it has another one"
+// @Success 200 {object} handler.FullReceiptAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 412 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/full [put]
+func (*Controller) ReplaceFullReceipt(c *gin.Context) {
+	var request services.FullReceiptRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to replace receipt is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to replace receipt is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to replace receipt"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.ReplaceFullReceipt(uint(id), userClaims.Id, request, c.GetHeader(ifMatchHeader))
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.PreconditionFailedErr:
+			c.JSON(http.StatusPreconditionFailed, APIResponse{Message: "Item was changed, get the current version"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when replace receipt"})
+		return
+	}
+
+	writeTaggedJSON(c, i.ETag(), FullReceiptAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
@@ -0,0 +1,376 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type ListReceiptImageAPIResponse struct {
+	APIResponse
+	List []receipt.ReceiptImage `json:"list"`
+}
+
+type ReceiptImageAPIResponse struct {
+	APIResponse
+	Item receipt.ReceiptImage `json:"item"`
+}
+
+// GetReceiptImages godoc
+// @Summary Get receipt gallery
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Success 200 {object} handler.ListReceiptImageAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/images [get]
+func (*Controller) GetReceiptImages(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get receipt images is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get receipt images"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	list, err := svc.GetReceiptImages(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get receipt images"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListReceiptImageAPIResponse{APIResponse: APIResponse{}, List: list})
+}
+
+// AddReceiptImage godoc
+// @Summary Add image to receipt gallery
+// @Description the first image of the gallery always becomes the cover
+// @Tags receipts
+// @Accept  multipart/form-data
+// @Produce json
+// @Param   id     path    int     true        "Receipt id"
+// @Param media_file formData file true "Media file"
+// @Param caption formData string false "Caption"
+// @Param position formData int false "Zero based position, image is appended to the end when omitted"
+// @Param cover formData bool false "Make the image the cover"
+// @Success 200 {object} handler.ReceiptImageAPIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/images [post]
+func (*Controller) AddReceiptImage(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to add receipt image is invalid"})
+		return
+	}
+
+	if c.ContentType() == "" {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Message: http.ErrNotMultipart.Error(),
+		})
+		return
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Message: fmt.Sprintf("Given request isn't valid multipart/form-data. Orig err: `%s`", err.Error()),
+		})
+		return
+	}
+
+	if len(form.File) != 1 || len(form.File["media_file"]) != 1 {
+		c.JSON(http.StatusBadRequest, APIResponse{
+			Message: "Given multipart form should contain exactly 1 media file.",
+		})
+		return
+	}
+
+	request := services.AddReceiptImageRequest{Caption: c.PostForm("caption")}
+	if positionParam, ok := c.GetPostForm("position"); ok {
+		position, err := strconv.ParseUint(positionParam, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to add receipt image is invalid"})
+			return
+		}
+		p := uint(position)
+		request.Position = &p
+	}
+	if coverParam, ok := c.GetPostForm("cover"); ok {
+		request.Cover, err = strconv.ParseBool(coverParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to add receipt image is invalid"})
+			return
+		}
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to add receipt image"})
+		return
+	}
+
+	fileHeader := form.File["media_file"][0]
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.AddReceiptImage(uint(id), userClaims.Id, fileHeader, services.Options{Filename: "dish"}, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			c.JSON(http.StatusBadRequest, APIResponse{Message: err.Error()})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to add receipt image"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ReceiptImageAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// UpdateReceiptImage godoc
+// @Summary Update receipt image
+// @Description change the caption or make the image the cover
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param   image_id     path    int     true        "Image id"
+// @Param image body services.UpdateReceiptImageRequest true "params"
+// @Success 200 {object} handler.ReceiptImageAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/images/{image_id} [put]
+func (ctrl *Controller) UpdateReceiptImage(c *gin.Context) {
+	if c.Param("image_id") == orderSegment {
+		ctrl.ReorderReceiptImages(c)
+		return
+	}
+
+	var request services.UpdateReceiptImageRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update receipt image is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update receipt image is invalid"})
+		return
+	}
+
+	imageIdParam := c.Param("image_id")
+	imageId, err := strconv.Atoi(imageIdParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update receipt image is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to update receipt image"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.UpdateReceiptImage(uint(id), uint(imageId), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when update receipt image"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ReceiptImageAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// DeleteReceiptImage godoc
+// @Summary Delete receipt image
+// @Description the first remaining image becomes the cover when the cover is deleted
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param   image_id     path    int     true        "Image id"
+// @Success 204
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/images/{image_id} [delete]
+func (*Controller) DeleteReceiptImage(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to delete receipt image is invalid"})
+		return
+	}
+
+	imageIdParam := c.Param("image_id")
+	imageId, err := strconv.Atoi(imageIdParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to delete receipt image is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to delete receipt image"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	err = svc.DeleteReceiptImage(uint(id), uint(imageId), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when delete receipt image"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReorderReceiptImages godoc
+// @Summary Reorder receipt images
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param order body services.ReorderReceiptImagesRequest true "params"
+// @Success 200 {object} handler.ListReceiptImageAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/images/order [put]
+func (*Controller) ReorderReceiptImages(c *gin.Context) {
+	var request services.ReorderReceiptImagesRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to reorder receipt images is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to reorder receipt images is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to reorder receipt images"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	list, err := svc.ReorderReceiptImages(uint(id), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when reorder receipt images"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListReceiptImageAPIResponse{APIResponse: APIResponse{}, List: list})
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+)
+
+const maxImportTextSize = 1 << 20
+
+type ReceiptImportPreviewAPIResponse struct {
+	APIResponse
+	Item services.ReceiptImportPreview `json:"item"`
+}
+
+// ImportReceipt godoc
+// @Summary Import receipt from web page
+// @Description extract schema.org Recipe (JSON-LD or microdata) from the page at url or from the raw html, or read Cooklang-style text. Returns the preview unless save is set, then the receipt is created with its image and returned as handler.FullReceiptAPIResponse with likely duplicates in the account. Text can also be posted as 'text/plain' body with 'save' query param.
+// @Tags receipts
+// @Accept  json
+// @Accept  plain
+// @Produce  json
+// @Param import body services.ImportReceiptRequest true "params"
+// @Param save query bool false "save plain-text receipt"
+// @Success 200 {object} handler.ReceiptImportPreviewAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/import [post]
+func (*Controller) ImportReceipt(c *gin.Context) {
+	var request services.ImportReceiptRequest
+	err := bindImportReceiptRequest(c, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to import receipt is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to import receipt"})
+		return
+	}
+
+	svc := services.GetReceiptImportService(db)
+	if !request.Save {
+		preview, err := svc.Preview(request)
+		if err != nil {
+			switch errors.Cause(err).(type) {
+			case *tools.NotPermittedErr:
+				c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+				return
+			case *tools.ValidationErr:
+				log.Printf("validate error %s", err)
+				c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+				return
+			}
+			log.Printf("internal error: `%s`", err)
+			c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when import receipt"})
+			return
+		}
+
+		c.JSON(http.StatusOK, ReceiptImportPreviewAPIResponse{APIResponse: APIResponse{}, Item: preview})
+		return
+	}
+
+	i, err := svc.Import(userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when import receipt"})
+		return
+	}
+
+	c.JSON(http.StatusOK, FullReceiptAPIResponse{
+		APIResponse: APIResponse{},
+		Item:        i,
+		Duplicates:  likelyDuplicates(services.GetReceiptService(db), userClaims.Id, i),
+	})
+}
+
+// bindImportReceiptRequest reads JSON request or plain-text receipt sent as is.
+func bindImportReceiptRequest(c *gin.Context, request *services.ImportReceiptRequest) (err error) {
+	if c.ContentType() != gin.MIMEPlain {
+		return c.ShouldBindJSON(request)
+	}
+
+	text, err := ioutil.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportTextSize))
+	if err != nil {
+		return
+	}
+	request.Text = string(text)
+	request.Save = c.Query("save") == "true"
+	return
+}
@@ -30,13 +30,25 @@ type ReceiptIngredientAPIResponse struct {
 // @Tags receipts
 // @Produce  json
 // @Param   id     path    int     true        "Receipt id"
+// @Param If-None-Match header string false "entity tag of the cached response"
+// @Param lang query string false "language of translations, en, uk or original for untranslated items, Accept-Language is used when omitted"
+// @Param Accept-Language header string false "preferred languages of translations"
 // @Success 200 {object} handler.ListReceiptIngredientAPIResponse
+// @Success 304
 // @Failure 401 {object} handler.APIResponse
 // @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
 // @Failure 500 {object} handler.APIResponse
 // @Security ApiKeyAuth
 // @Router /v1/receipts/{id}/ingredients [get]
 func (*Controller) GetReceiptIngredients(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
@@ -44,6 +56,12 @@ func (*Controller) GetReceiptIngredients(c *gin.Context) {
 		return
 	}
 
+	language, err := getLanguage(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+		return
+	}
+
 	db, err := database.GetDB()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get receipts"})
@@ -52,15 +70,27 @@ func (*Controller) GetReceiptIngredients(c *gin.Context) {
 
 
 	receiptService := services.GetReceiptService(db)
-	receiptIngredients, err := receiptService.GetAllReceiptIngredientsById(uint(id))
+	receiptIngredients, err := receiptService.GetAllReceiptIngredientsById(uint(id), userClaims.Id)
+	if err == nil {
+		err = receiptService.TranslateReceiptIngredients(language, receiptIngredients)
+	}
 	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
 		log.Printf("internal error: `%s`", err)
 		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get receipt ingredients"})
 		return
 	}
 
 
-	c.JSON(http.StatusOK, ListReceiptIngredientAPIResponse{APIResponse: APIResponse{}, List: receiptIngredients})
+	writeTaggedJSON(c, tools.ETag(receiptIngredients), ListReceiptIngredientAPIResponse{APIResponse: APIResponse{}, List: receiptIngredients})
 }
 
 // CreateReceiptIngredient godoc
@@ -122,7 +152,7 @@ func (*Controller) CreateReceiptIngredient(c *gin.Context) {
 	}
 
 
-	c.JSON(http.StatusOK, ReceiptIngredientAPIResponse{APIResponse: APIResponse{}, Item: i})
+	writeTaggedJSON(c, i.ETag(), ReceiptIngredientAPIResponse{APIResponse: APIResponse{}, Item: i})
 }
 
 // UpdateReceiptIngredient godoc
@@ -132,14 +162,21 @@ func (*Controller) CreateReceiptIngredient(c *gin.Context) {
 // @Param   id     path    int     true        "Receipt id"
 // @Param   ingredient_id     path    int     true        "Receipt ingredient id"
 // @Param ingredient body services.UpdateReceiptIngredientRequest true "params"
+// @Param If-Match header string false "entity tag of the item, it is not changed when it has another one"
 // @Success 200 {object} handler.ReceiptIngredientAPIResponse
 // @Failure 401 {object} handler.APIResponse
 // @Failure 400 {object} handler.APIResponse
 // @Failure 403 {object} handler.APIResponse
+// @Failure 412 {object} handler.APIResponse
 // @Failure 500 {object} handler.APIResponse
 // @Security ApiKeyAuth
 // @Router /v1/receipts/{id}/ingredients/{ingredient_id} [put]
-func (*Controller) UpdateReceiptIngredient(c *gin.Context) {
+func (ctrl *Controller) UpdateReceiptIngredient(c *gin.Context) {
+	if c.Param("ingredient_id") == orderSegment {
+		ctrl.ReorderReceiptIngredients(c)
+		return
+	}
+
 	var request services.UpdateReceiptIngredientRequest
 	err := c.ShouldBindJSON(&request)
 	if err != nil {
@@ -175,13 +212,16 @@ func (*Controller) UpdateReceiptIngredient(c *gin.Context) {
 	}
 
 	svc := services.GetReceiptService(db)
-	i, err := svc.UpdateReceiptIngredient(uint(id), uint(ingredientId), userClaims.Id, request)
+	i, err := svc.UpdateReceiptIngredient(uint(id), uint(ingredientId), userClaims.Id, request, c.GetHeader(ifMatchHeader))
 	if err != nil {
 		switch errors.Cause(err).(type) {
 		case *tools.NotPermittedErr:
 			log.Printf("validate error %s", err)
 			c.JSON(http.StatusForbidden, APIResponse{Message: fmt.Sprintf("Not permitted")})
 			return
+		case *tools.PreconditionFailedErr:
+			c.JSON(http.StatusPreconditionFailed, APIResponse{Message: "Item was changed, get the current version"})
+			return
 		case *tools.ValidationErr:
 			log.Printf("validate error %s", err)
 			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
@@ -193,7 +233,7 @@ func (*Controller) UpdateReceiptIngredient(c *gin.Context) {
 	}
 
 
-	c.JSON(http.StatusOK, ReceiptIngredientAPIResponse{APIResponse: APIResponse{}, Item: i})
+	writeTaggedJSON(c, i.ETag(), ReceiptIngredientAPIResponse{APIResponse: APIResponse{}, Item: i})
 }
 
 // DeleteReceiptIngredient godoc
@@ -202,10 +242,12 @@ func (*Controller) UpdateReceiptIngredient(c *gin.Context) {
 // @Produce  json
 // @Param   id     path    int     true        "Receipt id"
 // @Param   ingredient_id     path    int     true        "Receipt ingredient id"
+// @Param If-Match header string false "entity tag of the item, it is not changed when it has another one"
 // @Success 204
 // @Failure 401 {object} handler.APIResponse
 // @Failure 400 {object} handler.APIResponse
 // @Failure 403 {object} handler.APIResponse
+// @Failure 412 {object} handler.APIResponse
 // @Failure 500 {object} handler.APIResponse
 // @Security ApiKeyAuth
 // @Router /v1/receipts/{id}/ingredients/{ingredient_id} [delete]
@@ -238,13 +280,16 @@ func (*Controller) DeleteReceiptIngredient(c *gin.Context) {
 	}
 
 	svc := services.GetReceiptService(db)
-	err = svc.DeleteReceiptIngredient(uint(id), uint(ingredientId), userClaims.Id)
+	err = svc.DeleteReceiptIngredient(uint(id), uint(ingredientId), userClaims.Id, c.GetHeader(ifMatchHeader))
 	if err != nil {
 		switch errors.Cause(err).(type) {
 		case *tools.NotPermittedErr:
 			log.Printf("validate error %s", err)
 			c.JSON(http.StatusForbidden, APIResponse{Message: fmt.Sprintf("Not permitted")})
 			return
+		case *tools.PreconditionFailedErr:
+			c.JSON(http.StatusPreconditionFailed, APIResponse{Message: "Item was changed, get the current version"})
+			return
 		case *tools.ValidationErr:
 			log.Printf("validate error %s", err)
 			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
@@ -258,3 +303,64 @@ func (*Controller) DeleteReceiptIngredient(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// ReorderReceiptIngredients godoc
+// @Summary Reorder receipt ingredients
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param order body services.ReorderReceiptIngredientsRequest true "params"
+// @Success 200 {object} handler.ListReceiptIngredientAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/ingredients/order [put]
+func (*Controller) ReorderReceiptIngredients(c *gin.Context) {
+	var request services.ReorderReceiptIngredientsRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to reorder receipt ingredients is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to reorder receipt ingredients is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to reorder receipt ingredients"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	list, err := svc.ReorderReceiptIngredients(uint(id), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when reorder receipt ingredients"})
+		return
+	}
+
+	writeTaggedJSON(c, tools.ETag(list), ListReceiptIngredientAPIResponse{APIResponse: APIResponse{}, List: list})
+}
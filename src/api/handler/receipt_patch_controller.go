@@ -0,0 +1,291 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/patch"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+const maxPatchSize = 1 << 20
+
+// readPatchRequest reads the patch document, the response is written when it cannot be read.
+func readPatchRequest(c *gin.Context) (p services.PatchRequest, ok bool) {
+	p.MediaType = c.ContentType()
+	if !patch.IsMediaType(p.MediaType) {
+		c.JSON(http.StatusUnsupportedMediaType, APIResponse{
+			Message: fmt.Sprintf("Patch should be %s or %s", patch.MergePatchMediaType, patch.JSONPatchMediaType),
+		})
+		return
+	}
+
+	var err error
+	p.Body, err = ioutil.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxPatchSize))
+	if err != nil || len(p.Body) == 0 {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given patch is invalid"})
+		return
+	}
+	return p, true
+}
+
+// writePatchError writes the response for the error of the patch request.
+func writePatchError(c *gin.Context, err error) {
+	switch errors.Cause(err).(type) {
+	case *tools.NotPermittedErr:
+		c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+		return
+	case *tools.PreconditionFailedErr:
+		c.JSON(http.StatusPreconditionFailed, APIResponse{Message: "Item was changed, get the current version"})
+		return
+	case *tools.ValidationErr:
+		log.Printf("validate error %s", err)
+		c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+		return
+	}
+	log.Printf("internal error: `%s`", err)
+	c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when patch receipt"})
+}
+
+// PatchReceipt godoc
+// @Summary Patch receipt
+// @Description change only the fields given in the merge patch ('application/merge-patch+json')
+// @Description or by JSON Patch operations ('application/json-patch+json')
+// @Tags receipts
+// @Accept  json
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param receipt body services.UpdateReceiptRequest true "fields to change"
+// @Param If-Match header string false "entity tag of the receipt, it is not changed when it has another one"
+// @Success 200 {object} handler.ReceiptAPIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 412 {object} handler.APIResponse
+// @Failure 415 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id} [patch]
+func (*Controller) PatchReceipt(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to patch receipt is invalid"})
+		return
+	}
+
+	p, ok := readPatchRequest(c)
+	if !ok {
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to patch receipt"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	item, err := svc.PatchReceipt(uint(id), userClaims.Id, p, c.GetHeader(ifMatchHeader))
+	if err != nil {
+		writePatchError(c, err)
+		return
+	}
+
+	writeTaggedJSON(c, item.ETag(), ReceiptAPIResponse{APIResponse: APIResponse{}, Item: item})
+}
+
+// PatchFullReceipt godoc
+// @Summary Patch full receipt
+// @Description edit the receipt with its ingredients and directions, like adding, removing or moving
+// @Description the list items with JSON Patch ('application/json-patch+json'). The patched receipt is
+// @Description saved like PUT /v1/receipts/{id}/full, ingredients refer to the existing ones by 'ingredient_id'
+// @Tags receipts
+// @Accept  json
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param receipt body services.FullReceiptRequest true "patch"
+// @Param If-Match header string false "entity tag of the full receipt, it is not changed when it has another one"
+// @Success 200 {object} handler.FullReceiptAPIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 412 {object} handler.APIResponse
+// @Failure 415 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/full [patch]
+func (*Controller) PatchFullReceipt(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to patch receipt is invalid"})
+		return
+	}
+
+	p, ok := readPatchRequest(c)
+	if !ok {
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to patch receipt"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	item, err := svc.PatchFullReceipt(uint(id), userClaims.Id, p, c.GetHeader(ifMatchHeader))
+	if err != nil {
+		writePatchError(c, err)
+		return
+	}
+
+	writeTaggedJSON(c, item.ETag(), FullReceiptAPIResponse{APIResponse: APIResponse{}, Item: item})
+}
+
+// PatchReceiptIngredient godoc
+// @Summary Patch receipt ingredient
+// @Description change the fields given in the merge patch or by JSON Patch operations
+// @Tags receipts
+// @Accept  json
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param   ingredient_id     path    int     true        "Receipt ingredient id"
+// @Param ingredient body services.UpdateReceiptIngredientRequest true "fields to change"
+// @Param If-Match header string false "entity tag of the item, it is not changed when it has another one"
+// @Success 200 {object} handler.ReceiptIngredientAPIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 412 {object} handler.APIResponse
+// @Failure 415 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/ingredients/{ingredient_id} [patch]
+func (*Controller) PatchReceiptIngredient(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to patch receipt is invalid"})
+		return
+	}
+
+	ingredientIdParam := c.Param("ingredient_id")
+	ingredientId, err := strconv.Atoi(ingredientIdParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to patch receipt is invalid"})
+		return
+	}
+
+	p, ok := readPatchRequest(c)
+	if !ok {
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to patch receipt"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	item, err := svc.PatchReceiptIngredient(uint(id), uint(ingredientId), userClaims.Id, p, c.GetHeader(ifMatchHeader))
+	if err != nil {
+		writePatchError(c, err)
+		return
+	}
+
+	writeTaggedJSON(c, item.ETag(), ReceiptIngredientAPIResponse{APIResponse: APIResponse{}, Item: item})
+}
+
+// PatchReceiptDirection godoc
+// @Summary Patch receipt direction
+// @Description change the fields given in the merge patch or by JSON Patch operations,
+// @Description null clears 'duration' and 'temperature' in the merge patch
+// @Tags receipts
+// @Accept  json
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param   direction_id     path    int     true        "Receipt direction id"
+// @Param direction body services.UpdateReceiptDirectionRequest true "fields to change"
+// @Param If-Match header string false "entity tag of the item, it is not changed when it has another one"
+// @Success 200 {object} handler.ReceiptDirectionAPIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 412 {object} handler.APIResponse
+// @Failure 415 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/directions/{direction_id} [patch]
+func (*Controller) PatchReceiptDirection(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to patch receipt is invalid"})
+		return
+	}
+
+	directionIdParam := c.Param("direction_id")
+	directionId, err := strconv.Atoi(directionIdParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to patch receipt is invalid"})
+		return
+	}
+
+	p, ok := readPatchRequest(c)
+	if !ok {
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to patch receipt"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	item, err := svc.PatchReceiptDirection(uint(id), uint(directionId), userClaims.Id, p, c.GetHeader(ifMatchHeader))
+	if err != nil {
+		writePatchError(c, err)
+		return
+	}
+
+	writeTaggedJSON(c, item.ETag(), ReceiptDirectionAPIResponse{APIResponse: APIResponse{}, Item: item})
+}
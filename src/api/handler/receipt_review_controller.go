@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type ListReceiptReviewAPIResponse struct {
+	APIResponse
+	List []receipt.ReceiptReview `json:"list"`
+}
+
+type ReceiptReviewAPIResponse struct {
+	APIResponse
+	Item receipt.ReceiptReview `json:"item"`
+}
+
+// GetReceiptReviews godoc
+// @Summary Get receipt reviews
+// @Description find receipt reviews, newest first
+// @Tags reviews
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Success 200 {object} handler.ListReceiptReviewAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/reviews [get]
+func (*Controller) GetReceiptReviews(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get receipt reviews is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get receipt reviews"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	reviews, err := svc.GetAllReceiptReviewsById(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get receipt reviews"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListReceiptReviewAPIResponse{APIResponse: APIResponse{}, List: reviews})
+}
+
+// CreateReceiptReview godoc
+// @Summary Rate and review receipt
+// @Description one review per user, own receipts cannot be rated
+// @Tags reviews
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param review body services.CreateReceiptReviewRequest true "params"
+// @Success 200 {object} handler.ReceiptReviewAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/reviews [post]
+func (*Controller) CreateReceiptReview(c *gin.Context) {
+	var request services.CreateReceiptReviewRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to create review is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to process request"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.CreateReceiptReview(uint(id), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when create review"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ReceiptReviewAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// UpdateReceiptReview godoc
+// @Summary Update receipt review
+// @Tags reviews
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param   review_id     path    int     true        "Review id"
+// @Param review body services.UpdateReceiptReviewRequest true "params"
+// @Success 200 {object} handler.ReceiptReviewAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/reviews/{review_id} [put]
+func (*Controller) UpdateReceiptReview(c *gin.Context) {
+	var request services.UpdateReceiptReviewRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update review is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update review is invalid"})
+		return
+	}
+
+	reviewIdParam := c.Param("review_id")
+	reviewId, err := strconv.Atoi(reviewIdParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update review is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to update review"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.UpdateReceiptReview(uint(id), uint(reviewId), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusForbidden, APIResponse{Message: fmt.Sprintf("Not permitted")})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when update review"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ReceiptReviewAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// DeleteReceiptReview godoc
+// @Summary Delete receipt review
+// @Tags reviews
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param   review_id     path    int     true        "Review id"
+// @Success 204
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/reviews/{review_id} [delete]
+func (*Controller) DeleteReceiptReview(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to delete review is invalid"})
+		return
+	}
+
+	reviewIdParam := c.Param("review_id")
+	reviewId, err := strconv.Atoi(reviewIdParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to delete review is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to delete review"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	err = svc.DeleteReceiptReview(uint(id), uint(reviewId), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusForbidden, APIResponse{Message: fmt.Sprintf("Not permitted")})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when delete review"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
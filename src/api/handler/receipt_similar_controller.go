@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type ListSimilarReceiptAPIResponse struct {
+	APIResponse
+	List []receipt.SimilarReceipt `json:"list"`
+}
+
+// GetSimilarReceipts godoc
+// @Summary Get similar receipts
+// @Description find other receipts visible to the current user which are similar to the receipt
+// @Description by ingredients (rare ones weigh more), category, tags (cuisine, course and diet labels) and total time, the most similar first
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param limit query int false "number of receipts, 10 by default, 50 max"
+// @Success 200 {object} handler.ListSimilarReceiptAPIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/similar [get]
+func (*Controller) GetSimilarReceipts(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get similar receipts is invalid"})
+		return
+	}
+
+	limit := 0
+	if limitParam := c.Query("limit"); limitParam != "" {
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, APIResponse{Message: "Given limit is invalid"})
+			return
+		}
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get similar receipts"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	items, err := svc.GetSimilarReceipts(uint(id), userClaims.Id, limit)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get similar receipts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListSimilarReceiptAPIResponse{APIResponse: APIResponse{}, List: items})
+}
@@ -0,0 +1,325 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/ingredient"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type ReceiptTranslationsAPIResponse struct {
+	APIResponse
+	Item receipt.Translations `json:"item"`
+}
+
+type ReceiptTranslationAPIResponse struct {
+	APIResponse
+	Item receipt.ReceiptTranslation `json:"item"`
+}
+
+type DirectionTranslationAPIResponse struct {
+	APIResponse
+	Item receipt.DirectionTranslation `json:"item"`
+}
+
+type ListIngredientTranslationAPIResponse struct {
+	APIResponse
+	List []ingredient.Translation `json:"list"`
+}
+
+type IngredientTranslationAPIResponse struct {
+	APIResponse
+	Item ingredient.Translation `json:"item"`
+}
+
+// GetReceiptTranslations godoc
+// @Summary Get receipt translations
+// @Description translations of the receipt name, description and directions to all languages
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Success 200 {object} handler.ReceiptTranslationsAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/translations [get]
+func (*Controller) GetReceiptTranslations(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get receipt translations is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get receipt translations"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	translations, err := svc.GetReceiptTranslations(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get receipt translations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ReceiptTranslationsAPIResponse{APIResponse: APIResponse{}, Item: translations})
+}
+
+// SaveReceiptTranslation godoc
+// @Summary Add or update receipt translation
+// @Description name and description of the receipt in another language than the original one
+// @Tags receipts
+// @Accept  json
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param   lang     path    string     true        "Language, en or uk"
+// @Param translation body services.SaveReceiptTranslationRequest true "params"
+// @Success 200 {object} handler.ReceiptTranslationAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/translations/{lang} [put]
+func (*Controller) SaveReceiptTranslation(c *gin.Context) {
+	var request services.SaveReceiptTranslationRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to save receipt translation is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to save receipt translation is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to save receipt translation"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.SaveReceiptTranslation(uint(id), userClaims.Id, c.Param("lang"), request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when save receipt translation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ReceiptTranslationAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// SaveReceiptDirectionTranslation godoc
+// @Summary Add or update receipt direction translation
+// @Description description of the step in another language than the original one of the receipt
+// @Tags receipts
+// @Accept  json
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Param   direction_id     path    int     true        "Receipt direction id"
+// @Param   lang     path    string     true        "Language, en or uk"
+// @Param translation body services.SaveDirectionTranslationRequest true "params"
+// @Success 200 {object} handler.DirectionTranslationAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/directions/{direction_id}/translations/{lang} [put]
+func (*Controller) SaveReceiptDirectionTranslation(c *gin.Context) {
+	var request services.SaveDirectionTranslationRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to save direction translation is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to save direction translation is invalid"})
+		return
+	}
+
+	directionIdParam := c.Param("direction_id")
+	directionId, err := strconv.Atoi(directionIdParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to save direction translation is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to save direction translation"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.SaveDirectionTranslation(uint(id), uint(directionId), userClaims.Id, c.Param("lang"), request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when save direction translation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, DirectionTranslationAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// GetIngredientTranslations godoc
+// @Summary Get ingredient translations
+// @Tags receipts
+// @Produce  json
+// @Param   id     path    int     true        "Ingredient id"
+// @Success 200 {object} handler.ListIngredientTranslationAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/ingredients/{id}/translations [get]
+func (*Controller) GetIngredientTranslations(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get ingredient translations is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get ingredient translations"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	items, err := svc.GetIngredientTranslations(uint(id))
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get ingredient translations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListIngredientTranslationAPIResponse{APIResponse: APIResponse{}, List: items})
+}
+
+// SaveIngredientTranslation godoc
+// @Summary Add or update ingredient translation
+// @Tags receipts
+// @Accept  json
+// @Produce  json
+// @Param   id     path    int     true        "Ingredient id"
+// @Param   lang     path    string     true        "Language, en or uk"
+// @Param translation body services.SaveIngredientTranslationRequest true "params"
+// @Success 200 {object} handler.IngredientTranslationAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/ingredients/{id}/translations/{lang} [put]
+func (*Controller) SaveIngredientTranslation(c *gin.Context) {
+	var request services.SaveIngredientTranslationRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to save ingredient translation is invalid"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to save ingredient translation is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to save ingredient translation"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	i, err := svc.SaveIngredientTranslation(uint(id), c.Param("lang"), request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. %s", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when save ingredient translation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, IngredientTranslationAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type ListTrashAPIResponse struct {
+	APIResponse
+	List []receipt.TrashItem `json:"list"`
+}
+
+// GetTrash godoc
+// @Summary Get trash
+// @Description find deleted receipts of the current user, they are deleted permanently at 'purge_at'
+// @Tags trash
+// @Produce  json
+// @Success 200 {object} handler.ListTrashAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/trash [get]
+func (*Controller) GetTrash(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get trash"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	items, err := svc.GetTrash(userClaims.Id)
+	if err != nil {
+		log.Printf("get trash error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get trash"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListTrashAPIResponse{APIResponse: APIResponse{}, List: items})
+}
+
+// RestoreReceipt godoc
+// @Summary Restore receipt from trash
+// @Description restore the deleted receipt with its ingredients, directions and images
+// @Tags trash
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Success 200 {object} handler.ReceiptAPIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/receipts/{id}/restore [post]
+func (*Controller) RestoreReceipt(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to restore receipt is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to restore receipt"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	item, err := svc.RestoreReceipt(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when restore receipt"})
+		return
+	}
+
+	writeTaggedJSON(c, item.ETag(), ReceiptAPIResponse{APIResponse: APIResponse{}, Item: item})
+}
+
+// PurgeReceipt godoc
+// @Summary Delete receipt permanently
+// @Description delete the receipt in trash with everything related to it, it cannot be restored
+// @Tags trash
+// @Produce  json
+// @Param   id     path    int     true        "Receipt id"
+// @Success 204
+// @Failure 400 {object} handler.APIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/trash/{id} [delete]
+func (*Controller) PurgeReceipt(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to purge receipt is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to purge receipt"})
+		return
+	}
+
+	svc := services.GetReceiptService(db)
+	err = svc.PurgeReceipt(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when purge receipt"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
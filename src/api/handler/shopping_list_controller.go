@@ -0,0 +1,419 @@
+package handler
+
+import (
+	"fmt"
+	"food/src/api/database"
+	"food/src/api/jwt_auth"
+	"food/src/api/models/shopping"
+	"food/src/api/models/tools"
+	"food/src/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type ListShoppingListAPIResponse struct {
+	APIResponse
+	List []shopping.List `json:"list"`
+}
+
+type ShoppingListAPIResponse struct {
+	APIResponse
+	Item shopping.List `json:"item"`
+}
+
+type ShoppingListItemAPIResponse struct {
+	APIResponse
+	Item shopping.Item `json:"item"`
+}
+
+// GetShoppingLists godoc
+// @Summary Get shopping lists
+// @Description find shopping lists of the current user, newest first
+// @Tags shopping lists
+// @Produce  json
+// @Success 200 {object} handler.ListShoppingListAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/shopping-lists [get]
+func (*Controller) GetShoppingLists(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get shopping lists"})
+		return
+	}
+
+	svc := services.GetShoppingListService(db)
+	lists, err := svc.GetLists(userClaims.Id)
+	if err != nil {
+		log.Printf("get shopping lists error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get shopping lists"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ListShoppingListAPIResponse{APIResponse: APIResponse{}, List: lists})
+}
+
+// GetShoppingList godoc
+// @Summary Get shopping list
+// @Description get shopping list with items grouped by category
+// @Tags shopping lists
+// @Produce  json
+// @Param   id     path    int     true        "Shopping list id"
+// @Success 200 {object} handler.ShoppingListAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/shopping-lists/{id} [get]
+func (*Controller) GetShoppingList(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to get shopping list is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to get shopping list"})
+		return
+	}
+
+	svc := services.GetShoppingListService(db)
+	i, err := svc.GetList(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when get shopping list"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ShoppingListAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// CreateShoppingList godoc
+// @Summary Generate shopping list
+// @Description aggregate ingredients of given receipts and meal plan entries in the date range, converting compatible units
+// @Tags shopping lists
+// @Produce  json
+// @Param list body services.CreateShoppingListRequest true "params"
+// @Success 200 {object} handler.ShoppingListAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/shopping-lists [post]
+func (*Controller) CreateShoppingList(c *gin.Context) {
+	var request services.CreateShoppingListRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to create shopping list is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to create shopping list"})
+		return
+	}
+
+	svc := services.GetShoppingListService(db)
+	i, err := svc.CreateList(userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid. Orig err: `%s`", err)})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when create shopping list"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ShoppingListAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// DeleteShoppingList godoc
+// @Summary Delete shopping list
+// @Tags shopping lists
+// @Produce  json
+// @Param   id     path    int     true        "Shopping list id"
+// @Success 204
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/shopping-lists/{id} [delete]
+func (*Controller) DeleteShoppingList(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to delete shopping list is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to delete shopping list"})
+		return
+	}
+
+	svc := services.GetShoppingListService(db)
+	err = svc.DeleteList(uint(id), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when delete shopping list"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateShoppingListItem godoc
+// @Summary Add extra item to shopping list
+// @Tags shopping lists
+// @Produce  json
+// @Param   id     path    int     true        "Shopping list id"
+// @Param item body services.CreateShoppingListItemRequest true "params"
+// @Success 200 {object} handler.ShoppingListItemAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/shopping-lists/{id}/items [post]
+func (*Controller) CreateShoppingListItem(c *gin.Context) {
+	var request services.CreateShoppingListItemRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to create shopping list item is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to create shopping list item is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to create shopping list item"})
+		return
+	}
+
+	svc := services.GetShoppingListService(db)
+	i, err := svc.CreateItem(uint(id), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when create shopping list item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ShoppingListItemAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// UpdateShoppingListItem godoc
+// @Summary Tick shopping list item off
+// @Description check or uncheck the item and optionally change its quantity
+// @Tags shopping lists
+// @Produce  json
+// @Param   id     path    int     true        "Shopping list id"
+// @Param   item_id     path    int     true        "Shopping list item id"
+// @Param item body services.UpdateShoppingListItemRequest true "params"
+// @Success 200 {object} handler.ShoppingListItemAPIResponse
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/shopping-lists/{id}/items/{item_id} [put]
+func (*Controller) UpdateShoppingListItem(c *gin.Context) {
+	var request services.UpdateShoppingListItemRequest
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update shopping list item is invalid"})
+		return
+	}
+
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update shopping list item is invalid"})
+		return
+	}
+
+	itemIdParam := c.Param("item_id")
+	itemId, err := strconv.Atoi(itemIdParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to update shopping list item is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to update shopping list item"})
+		return
+	}
+
+	svc := services.GetShoppingListService(db)
+	i, err := svc.UpdateItem(uint(id), uint(itemId), userClaims.Id, request)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when update shopping list item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ShoppingListItemAPIResponse{APIResponse: APIResponse{}, Item: i})
+}
+
+// DeleteShoppingListItem godoc
+// @Summary Delete shopping list item
+// @Tags shopping lists
+// @Produce  json
+// @Param   id     path    int     true        "Shopping list id"
+// @Param   item_id     path    int     true        "Shopping list item id"
+// @Success 204
+// @Failure 401 {object} handler.APIResponse
+// @Failure 400 {object} handler.APIResponse
+// @Failure 403 {object} handler.APIResponse
+// @Failure 500 {object} handler.APIResponse
+// @Security ApiKeyAuth
+// @Router /v1/shopping-lists/{id}/items/{item_id} [delete]
+func (*Controller) DeleteShoppingListItem(c *gin.Context) {
+	claims, _ := c.Get("claims")
+	userClaims, ok := claims.(*jwt_auth.UserClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, APIResponse{Message: "Unauthorized access"})
+		return
+	}
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to delete shopping list item is invalid"})
+		return
+	}
+
+	itemIdParam := c.Param("item_id")
+	itemId, err := strconv.Atoi(itemIdParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, APIResponse{Message: "Given request to delete shopping list item is invalid"})
+		return
+	}
+
+	db, err := database.GetDB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when try to delete shopping list item"})
+		return
+	}
+
+	svc := services.GetShoppingListService(db)
+	err = svc.DeleteItem(uint(id), uint(itemId), userClaims.Id)
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *tools.NotPermittedErr:
+			c.JSON(http.StatusForbidden, APIResponse{Message: "Not permitted"})
+			return
+		case *tools.ValidationErr:
+			log.Printf("validate error %s", err)
+			c.JSON(http.StatusBadRequest, APIResponse{Message: fmt.Sprintf("Given request is invalid.")})
+			return
+		}
+		log.Printf("internal error: `%s`", err)
+		c.JSON(http.StatusInternalServerError, APIResponse{Message: "Error occurred when delete shopping list item"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -7,6 +7,7 @@ import (
 	"food/src/api/handler"
 	"food/src/api/jwt_auth"
 	"food/src/api/server"
+	"food/src/api/services"
 	"os"
 	"os/signal"
 )
@@ -14,10 +15,11 @@ import (
 func main() {
 
 	cfg := config.GetConfig()
-	_, err := database.InitDB(cfg.DSN)
+	db, err := database.InitDB(cfg.DSN)
 	if err != nil {
 		panic(err)
 	}
+	stopTrashPurge := services.StartTrashPurge(db, cfg.TrashRetention)
 	jwt_auth.Setup(cfg.JwtKey)
 
 	addr := fmt.Sprintf(":%s", cfg.Port)
@@ -27,9 +29,10 @@ func main() {
 
 	// Wait for interrupt signal to gracefully shutdown the server with
 	// a timeout of 5 seconds.
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 	<-quit
 
 	srv.Stop()
+	stopTrashPurge()
 }
@@ -0,0 +1,36 @@
+package cookbook
+
+import (
+	"food/src/api/models/receipt"
+	"time"
+)
+
+type Cookbook struct {
+	Id          uint       `json:"id" gorm:"primary_key"`
+	UserId      uint       `json:"user_id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"-"`
+	// ordered by position
+	Receipts []CookbookReceipt `json:"receipts,omitempty" gorm:"foreignkey:CookbookId"`
+}
+
+func (Cookbook) TableName() string {
+	return "cookbooks"
+}
+
+type CookbookReceipt struct {
+	Id         uint `json:"-" gorm:"primary_key"`
+	CookbookId uint `json:"cookbook_id"`
+	ReceiptId  uint `json:"receipt_id"`
+	// zero based position of the receipt in the cookbook
+	Position  uint             `json:"position"`
+	CreatedAt time.Time        `json:"created_at"`
+	Receipt   *receipt.Receipt `json:"receipt,omitempty" gorm:"foreignkey:ReceiptId"`
+}
+
+func (CookbookReceipt) TableName() string {
+	return "cookbook_receipts"
+}
@@ -0,0 +1,180 @@
+package cookbook
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+)
+
+type CookbookRepository struct {
+	db *gorm.DB
+}
+
+func GetCookbookRepository(db *gorm.DB) *CookbookRepository {
+	return &CookbookRepository{db: db}
+}
+
+func (r *CookbookRepository) GetAllByUserId(userId uint) (cookbooks []Cookbook, err error) {
+	if userId == 0 {
+		err = fmt.Errorf("user id cannot be empty")
+		return
+	}
+	err = r.db.Where(&Cookbook{UserId: userId}).Order("name ASC").Find(&cookbooks).Error
+	return
+}
+
+func (r *CookbookRepository) GetById(id uint) (cookbook Cookbook, err error) {
+	if id == 0 {
+		err = fmt.Errorf("cookbook id cannot be empty")
+		return
+	}
+	err = r.db.Where(&Cookbook{Id: id}).First(&cookbook).Error
+	return
+}
+
+// GetWithReceiptsById returns the cookbook with its receipts ordered by position.
+func (r *CookbookRepository) GetWithReceiptsById(id uint) (cookbook Cookbook, err error) {
+	if id == 0 {
+		err = fmt.Errorf("cookbook id cannot be empty")
+		return
+	}
+	err = r.db.Where(&Cookbook{Id: id}).
+		Preload("Receipts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC").Order("id ASC")
+		}).
+		Preload("Receipts.Receipt").
+		Preload("Receipts.Receipt.Media").
+		First(&cookbook).Error
+	return
+}
+
+func (r *CookbookRepository) Create(cookbook *Cookbook) (err error) {
+	if cookbook == nil {
+		err = fmt.Errorf("cookbook cannot be empty")
+		return
+	}
+	if cookbook.Id != 0 {
+		err = fmt.Errorf("cookbook id should be empty")
+		return
+	}
+	err = r.db.Create(cookbook).Error
+	return
+}
+
+func (r *CookbookRepository) Update(cookbook *Cookbook) (err error) {
+	if cookbook == nil {
+		err = fmt.Errorf("cookbook cannot be empty")
+		return
+	}
+	if cookbook.Id == 0 {
+		err = fmt.Errorf("cookbook id cannot be empty")
+		return
+	}
+
+	err = r.db.Model(&Cookbook{}).Where(&Cookbook{Id: cookbook.Id}).
+		Updates(map[string]interface{}{"name": cookbook.Name, "description": cookbook.Description}).Error
+	return
+}
+
+func (r *CookbookRepository) Delete(id uint) (err error) {
+	if id == 0 {
+		err = fmt.Errorf("cookbook id cannot be empty")
+		return
+	}
+
+	err = r.db.Model(Cookbook{}).Where(&Cookbook{Id: id}).Delete(Cookbook{}).Error
+	return
+}
+
+func (r *CookbookRepository) GetReceipts(cookbookId uint) (items []CookbookReceipt, err error) {
+	if cookbookId == 0 {
+		err = fmt.Errorf("cookbook id cannot be empty")
+		return
+	}
+	err = r.db.Where(&CookbookReceipt{CookbookId: cookbookId}).Order("position ASC").Order("id ASC").Find(&items).Error
+	return
+}
+
+// AddReceipt inserts the receipt at the given position, shifting following receipts down.
+func (r *CookbookRepository) AddReceipt(item *CookbookReceipt) (err error) {
+	if item == nil {
+		err = fmt.Errorf("cookbook receipt cannot be empty")
+		return
+	}
+	if item.CookbookId == 0 || item.ReceiptId == 0 {
+		err = fmt.Errorf("cookbook id and receipt id cannot be empty")
+		return
+	}
+
+	tx := r.db.Begin()
+	err = tx.Model(&CookbookReceipt{}).
+		Where("cookbook_id = ? AND position >= ?", item.CookbookId, item.Position).
+		UpdateColumn("position", gorm.Expr("position + 1")).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Create(item).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Commit().Error
+	return
+}
+
+// RemoveReceipt deletes the receipt from the cookbook and closes the gap in positions.
+func (r *CookbookRepository) RemoveReceipt(cookbookId, receiptId uint) (err error) {
+	if cookbookId == 0 || receiptId == 0 {
+		err = fmt.Errorf("cookbook id and receipt id cannot be empty")
+		return
+	}
+
+	var item CookbookReceipt
+	err = r.db.Where(&CookbookReceipt{CookbookId: cookbookId, ReceiptId: receiptId}).First(&item).Error
+	if err != nil {
+		return
+	}
+
+	tx := r.db.Begin()
+	err = tx.Where(&CookbookReceipt{Id: item.Id}).Delete(&CookbookReceipt{}).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Model(&CookbookReceipt{}).
+		Where("cookbook_id = ? AND position > ?", cookbookId, item.Position).
+		UpdateColumn("position", gorm.Expr("position - 1")).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Commit().Error
+	return
+}
+
+// ReorderReceipts sets positions of the cookbook receipts according to their order in the given list.
+func (r *CookbookRepository) ReorderReceipts(cookbookId uint, receiptIds []uint) (err error) {
+	if cookbookId == 0 {
+		err = fmt.Errorf("cookbook id cannot be empty")
+		return
+	}
+
+	tx := r.db.Begin()
+	for position, receiptId := range receiptIds {
+		err = tx.Model(&CookbookReceipt{}).
+			Where(&CookbookReceipt{CookbookId: cookbookId, ReceiptId: receiptId}).
+			UpdateColumn("position", position).Error
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	err = tx.Commit().Error
+	return
+}
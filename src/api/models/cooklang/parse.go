@@ -0,0 +1,405 @@
+package cooklang
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	temperatureComment = regexp.MustCompile(`(?i)^\s*temperature\s*:\s*(\d+)\s*(?:°?\s*c)?\s*$`)
+	timePattern        = regexp.MustCompile(`^\s*(?:\d+(?:[.,]\d+)?\s*[a-zA-Z]*\s*)+$`)
+	timePartPattern    = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*([a-zA-Z]*)`)
+)
+
+// unitMinutes maps time units of timers and metadata to minutes
+var unitMinutes = map[string]float64{
+	"":        1,
+	"s":       1.0 / 60,
+	"sec":     1.0 / 60,
+	"secs":    1.0 / 60,
+	"second":  1.0 / 60,
+	"seconds": 1.0 / 60,
+	"m":       1,
+	"min":     1,
+	"mins":    1,
+	"minute":  1,
+	"minutes": 1,
+	"h":       60,
+	"hr":      60,
+	"hrs":     60,
+	"hour":    60,
+	"hours":   60,
+	"d":       24 * 60,
+	"day":     24 * 60,
+	"days":    24 * 60,
+}
+
+// listSeparators may stand between ingredients of an ingredient list
+const listSeparators = " \t,;.-*•"
+
+// Parse reads the recipe from the markup.
+func Parse(text string) (recipe Recipe, err error) {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	text, err = removeBlockComments(text)
+	if err != nil {
+		return
+	}
+
+	paragraphs := 0
+	var lines []string
+	var temperature *int
+	flush := func() error {
+		if len(lines) == 0 {
+			return nil
+		}
+		paragraphs++
+		step, refs, isList, err := parseStep(strings.Join(lines, " "))
+		if err != nil {
+			return fmt.Errorf("paragraph %d: %s", paragraphs, err)
+		}
+		step.Temperature = temperature
+		lines, temperature = nil, nil
+
+		for _, ref := range refs {
+			// every entry of an ingredient list is an ingredient of its own
+			if !isList && recipe.mentions(ref) {
+				continue
+			}
+			recipe.Ingredients = append(recipe.Ingredients, ref)
+		}
+		if !isList {
+			recipe.Steps = append(recipe.Steps, step)
+		}
+		return nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if len(trimmed) == 0 {
+			err = flush()
+			if err != nil {
+				return
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, ">>") {
+			err = recipe.setMetadata(trimmed[2:])
+			if err != nil {
+				return
+			}
+			continue
+		}
+
+		// lines with comments only do not split paragraphs
+		content, comment := splitComment(line)
+		if matches := temperatureComment.FindStringSubmatch(comment); matches != nil {
+			value, _ := strconv.Atoi(matches[1])
+			temperature = &value
+		}
+		if len(strings.TrimSpace(content)) > 0 {
+			lines = append(lines, content)
+		}
+	}
+	err = flush()
+	return
+}
+
+func (recipe *Recipe) setMetadata(line string) (err error) {
+	parts := strings.SplitN(line, ":", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("metadata `%s` should be written as `>> key: value`", strings.TrimSpace(line))
+	}
+	key, value := strings.ToLower(strings.TrimSpace(parts[0])), strings.TrimSpace(unescape(parts[1]))
+
+	switch key {
+	case "title", "name":
+		recipe.Name = value
+	case "description":
+		recipe.Description = value
+	case "category":
+		recipe.Category = value
+	case "cuisine":
+		recipe.Cuisine = value
+	case "course":
+		recipe.Course = value
+	case "difficulty":
+		recipe.Difficulty = strings.ToLower(value)
+	case "prep time", "preparation time":
+		recipe.PrepTime, err = parseTime(key, value)
+	case "cook time", "cooking time":
+		recipe.CookTime, err = parseTime(key, value)
+	case "rest time", "resting time":
+		recipe.RestTime, err = parseTime(key, value)
+	case "total time", "time":
+		recipe.TotalTime, err = parseTime(key, value)
+	case "servings", "serves":
+		recipe.Servings, err = parseServings(key, value)
+	}
+	return
+}
+
+func parseTime(key, value string) (uint, error) {
+	minutes, ok := parseMinutes(value)
+	if !ok {
+		return 0, fmt.Errorf("%s `%s` is invalid", key, value)
+	}
+	return uint(math.Ceil(minutes)), nil
+}
+
+// parseServings reads the number at the beginning of values like `4` or `4 people`.
+func parseServings(key, value string) (uint, error) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("%s `%s` is invalid", key, value)
+	}
+	servings, err := strconv.ParseUint(fields[0], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%s `%s` is invalid", key, value)
+	}
+	return uint(servings), nil
+}
+
+// parseMinutes reads times like `45`, `45 minutes` or `1 h 30 min`.
+func parseMinutes(value string) (minutes float64, ok bool) {
+	if !timePattern.MatchString(value) {
+		return
+	}
+	for _, part := range timePartPattern.FindAllStringSubmatch(value, -1) {
+		amount, err := strconv.ParseFloat(strings.Replace(part[1], ",", ".", 1), 64)
+		if err != nil {
+			return
+		}
+		unit, known := unitMinutes[strings.ToLower(part[2])]
+		if !known {
+			return
+		}
+		minutes += amount * unit
+	}
+	return minutes, true
+}
+
+// parseStep reads the paragraph. It reports whether the paragraph is an ingredient list.
+func parseStep(text string) (step Step, refs []Ingredient, isList bool, err error) {
+	r := []rune(text)
+	out := &strings.Builder{}
+	// text outside of components tells lists from steps
+	literal := &strings.Builder{}
+	components := 0
+	minutes := 0.0
+
+	for i := 0; i < len(r); {
+		c := r[i]
+		if c == '\\' && i+1 < len(r) {
+			out.WriteRune(r[i+1])
+			literal.WriteRune(r[i+1])
+			i += 2
+			continue
+		}
+		if c != '@' && c != '#' && c != '~' {
+			out.WriteRune(c)
+			literal.WriteRune(c)
+			i++
+			continue
+		}
+
+		name, amount, unit, braces, end, ok, componentErr := readComponent(r, i)
+		if componentErr != nil {
+			err = componentErr
+			return
+		}
+		if !ok || (c == '~' && !braces) || (c != '~' && len(name) == 0) {
+			out.WriteRune(c)
+			literal.WriteRune(c)
+			i++
+			continue
+		}
+
+		switch c {
+		case '@':
+			refs = append(refs, Ingredient{Name: name, Quantity: singleLine(amount + " " + unit)})
+			out.WriteString(name)
+		case '#':
+			components++
+			out.WriteString(name)
+		case '~':
+			components++
+			value, timerErr := timerMinutes(amount, unit)
+			if timerErr != nil {
+				err = timerErr
+				return
+			}
+			minutes += value
+			if strings.ToLower(name) == passiveTimer {
+				step.Passive = true
+			}
+			// the timer closing the step only holds its duration
+			if len(strings.TrimSpace(string(r[end:]))) > 0 {
+				out.WriteString(singleLine(amount + " " + unit))
+			}
+		}
+		i = end
+	}
+
+	step.Text = singleLine(out.String())
+	if minutes > 0 {
+		duration := uint(math.Ceil(minutes))
+		step.Duration = &duration
+	}
+	isList = len(refs) > 0 && components == 0 && len(strings.Trim(literal.String(), listSeparators)) == 0
+	return
+}
+
+// readComponent reads the ingredient, cookware or timer starting with the marker at i.
+// Names of several words end with the opening brace, single word names do not need braces.
+func readComponent(r []rune, i int) (name, amount, unit string, braces bool, end int, ok bool, err error) {
+	start := i + 1
+	if start >= len(r) || unicode.IsSpace(r[start]) {
+		return
+	}
+
+	for k := start; k < len(r); k++ {
+		if r[k] == '\\' {
+			k++
+			continue
+		}
+		if r[k] == '{' {
+			name = singleLine(unescape(string(r[start:k])))
+			amount, unit, end, err = readBraces(r, k)
+			return name, amount, unit, true, end, err == nil, err
+		}
+		if strings.ContainsRune("}@#~", r[k]) {
+			break
+		}
+	}
+
+	end = start
+	for end < len(r) && isWordRune(r[end]) {
+		end++
+	}
+	name = string(r[start:end])
+	ok = end > start
+	return
+}
+
+// readBraces reads `{amount%unit}` starting at the opening brace.
+func readBraces(r []rune, open int) (amount, unit string, end int, err error) {
+	value := &strings.Builder{}
+	separator := -1
+	for k := open + 1; k < len(r); k++ {
+		switch {
+		case r[k] == '\\' && k+1 < len(r):
+			k++
+			value.WriteRune(r[k])
+		case r[k] == '%' && separator < 0:
+			separator = value.Len()
+		case r[k] == '}':
+			amount = value.String()
+			if separator >= 0 {
+				amount, unit = amount[:separator], amount[separator:]
+			}
+			return strings.TrimSpace(amount), strings.TrimSpace(unit), k + 1, nil
+		default:
+			value.WriteRune(r[k])
+		}
+	}
+	err = fmt.Errorf("brace is not closed")
+	return
+}
+
+func timerMinutes(amount, unit string) (minutes float64, err error) {
+	if len(amount) == 0 {
+		return
+	}
+	value, ok := parseAmount(amount)
+	scale, known := unitMinutes[strings.ToLower(unit)]
+	if !ok || !known {
+		err = fmt.Errorf("timer `%s` is invalid", singleLine(amount+" "+unit))
+		return
+	}
+	minutes = value * scale
+	return
+}
+
+// parseAmount reads numbers like `2`, `1.5` or `1/2`.
+func parseAmount(amount string) (value float64, ok bool) {
+	parts := strings.Split(strings.Replace(amount, ",", ".", 1), "/")
+	value, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || len(parts) > 2 {
+		return
+	}
+	if len(parts) == 2 {
+		divider, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil || divider == 0 {
+			return
+		}
+		value /= divider
+	}
+	return value, value >= 0
+}
+
+// mentions reports whether the step mention refers to the ingredient given before,
+// that is the one of the same name when the mention has no quantity or the same one.
+func (recipe *Recipe) mentions(ref Ingredient) bool {
+	for _, item := range recipe.Ingredients {
+		if strings.EqualFold(item.Name, ref.Name) && (len(ref.Quantity) == 0 || ref.Quantity == item.Quantity) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitComment separates the line from its `--` comment.
+func splitComment(line string) (content, comment string) {
+	for i := 0; i+1 < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if line[i] == '-' && line[i+1] == '-' {
+			return line[:i], line[i+2:]
+		}
+	}
+	return line, ""
+}
+
+func removeBlockComments(text string) (string, error) {
+	out := &strings.Builder{}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) {
+			out.WriteString(text[i : i+2])
+			i++
+			continue
+		}
+		if strings.HasPrefix(text[i:], "[-") {
+			end := strings.Index(text[i+2:], "-]")
+			if end < 0 {
+				return "", fmt.Errorf("block comment is not closed")
+			}
+			out.WriteByte(' ')
+			i += end + 3
+			continue
+		}
+		out.WriteByte(text[i])
+	}
+	return out.String(), nil
+}
+
+func unescape(value string) string {
+	out := &strings.Builder{}
+	r := []rune(value)
+	for i := 0; i < len(r); i++ {
+		if r[i] == '\\' && i+1 < len(r) {
+			i++
+		}
+		out.WriteRune(r[i])
+	}
+	return out.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
@@ -0,0 +1,111 @@
+// Package cooklang reads and writes receipts in plain-text markup based on Cooklang.
+//
+//	>> title: Pancakes
+//	>> category: breakfast
+//	>> cook time: 30 minutes
+//	>> servings: 4
+//
+//	Whisk @flour{200%g}, @eggs{2} and @milk{300%ml} in a #bowl.
+//
+//	Fry on a hot pan for ~{2%minutes} on each side. -- temperature: 180
+//
+// Lines starting with `>>` are metadata, paragraphs are steps. Ingredients are marked
+// with `@` and their quantity is given in braces, names of several words need braces,
+// like `@olive oil{}`. Cookware marked with `#` is kept as text. Timers `~{amount%unit}`
+// set the step duration, a timer named `passive` marks a waiting step and a timer at
+// the end of a step is not a part of its text. `--` starts a comment till the end of
+// the line, `[- -]` comments out a block, a comment like `-- temperature: 180` sets the
+// oven temperature of the step. Special characters are escaped with `\`.
+//
+// A paragraph which has nothing but ingredients, one per line or separated by commas,
+// is an ingredient list and not a step. Exported receipts list all ingredients this way
+// to keep their order and quantities. A step mention without quantity or with the same
+// one refers to the ingredient given before, mentions with other quantities are separate
+// ingredients, like sugar for the dough and for the glaze.
+package cooklang
+
+import (
+	"food/src/api/models/receipt"
+	"strings"
+)
+
+const (
+	// file extension of exported receipts
+	Extension = ".cook"
+	MediaType = "text/plain; charset=utf-8"
+
+	passiveTimer = "passive"
+)
+
+// Recipe is the receipt document described by the markup.
+type Recipe struct {
+	Name        string
+	Description string
+	Category    string
+	Cuisine     string
+	Course      string
+	Difficulty  string
+	// times in minutes
+	PrepTime uint
+	CookTime uint
+	RestTime uint
+	// total time in minutes given without its parts
+	TotalTime uint
+	Servings  uint
+	// ingredients in order of their mentions
+	Ingredients []Ingredient
+	Steps       []Step
+}
+
+type Ingredient struct {
+	Name string
+	// quantity with unit as written, like `200 g`, empty when not given
+	Quantity string
+}
+
+type Step struct {
+	Text string
+	// sum of step timers in minutes
+	Duration    *uint
+	Passive     bool
+	Temperature *int
+}
+
+// NewRecipe returns the document of the receipt.
+// Texts are written on a single line, so their whitespaces are collapsed.
+func NewRecipe(r receipt.FullReceipt) Recipe {
+	recipe := Recipe{
+		Name:        singleLine(r.Name),
+		Description: singleLine(r.Description),
+		Category:    singleLine(r.Category),
+		Cuisine:     singleLine(r.Cuisine),
+		Course:      singleLine(r.Course),
+		Difficulty:  r.Difficulty,
+		PrepTime:    r.PrepTime,
+		CookTime:    r.CookTime,
+		RestTime:    r.RestTime,
+		Servings:    r.Servings,
+	}
+	for _, item := range r.Ingredients {
+		if item.Ingredient == nil {
+			continue
+		}
+		recipe.Ingredients = append(recipe.Ingredients, Ingredient{
+			Name:     singleLine(item.Ingredient.Name),
+			Quantity: singleLine(item.Quantity),
+		})
+	}
+	for _, item := range r.Directions {
+		recipe.Steps = append(recipe.Steps, Step{
+			Text:        singleLine(item.Description),
+			Duration:    item.Duration,
+			Passive:     item.Passive,
+			Temperature: item.Temperature,
+		})
+	}
+	return recipe
+}
+
+func singleLine(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
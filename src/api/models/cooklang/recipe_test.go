@@ -0,0 +1,96 @@
+package cooklang
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestRenderParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		recipe Recipe
+	}{
+		{"simple", Recipe{
+			Name:        "Pancakes",
+			Category:    "breakfast",
+			CookTime:    30,
+			Servings:    4,
+			Ingredients: []Ingredient{{"flour", "200 g"}, {"eggs", "2"}, {"milk", "300 ml"}},
+			Steps: []Step{
+				{Text: "Whisk flour, eggs and milk"},
+				{Text: "Fry on a hot pan", Duration: uintPtr(2), Temperature: intPtr(180)},
+			},
+		}},
+		{"repeated ingredients", Recipe{
+			Name:        "Cinnamon rolls",
+			PrepTime:    40,
+			RestTime:    60,
+			Ingredients: []Ingredient{{"sugar", "100 g"}, {"flour", "500 g"}, {"sugar", "50 g"}, {"salt", "1 tsp"}, {"salt", "1 tsp"}},
+			Steps: []Step{
+				{Text: "Knead flour with sugar and salt"},
+				{Text: "Let it rise", Passive: true, Duration: uintPtr(60)},
+				{Text: "Glaze with the rest of sugar", Passive: true},
+			},
+		}},
+		{"unicode", Recipe{
+			Name:        "Борщ",
+			Description: "Червоний борщ",
+			Cuisine:     "українська",
+			TotalTime:   90,
+			Ingredients: []Ingredient{{"буряк", "500 г"}, {"сметана", "до смаку"}, {"crème fraîche", "2 ст. л."}},
+			Steps:       []Step{{Text: "Зварити буряк, подавати зі сметаною та crème fraîche"}},
+		}},
+		{"fractions", Recipe{
+			Name:        "Tea",
+			Ingredients: []Ingredient{{"milk", "1/2 cup"}, {"honey", "1 1/2 tbsp"}, {"ginger", "½ tsp"}, {"water", "0.25 l"}, {"lemon", "1,5"}},
+			Steps:       []Step{{Text: "Mix everything"}},
+		}},
+		{"special characters", Recipe{
+			Name:        "Salad -- quick",
+			Description: "100% fresh, @home #1",
+			Ingredients: []Ingredient{{"tomatoes {ripe}", "2"}, {"olive oil", "2 tbsp"}, {"oil", "1 tbsp"}, {"salt", ""}},
+			Steps: []Step{
+				{Text: "> Cut tomatoes in a #bowl ~ 2 cm [- large -] pieces"},
+				{Text: "Add olive oil -- not the other oil, 5% of salt \\ pepper"},
+				{Text: "salt, oil"},
+			},
+		}},
+	}
+	for _, test := range tests {
+		buf := &bytes.Buffer{}
+		err := Render(buf, test.recipe)
+		if err != nil {
+			t.Errorf("%s: cannot render: %v", test.name, err)
+			continue
+		}
+		parsed, err := Parse(buf.String())
+		if err != nil {
+			t.Errorf("%s: cannot parse:\n%s\n%v", test.name, buf, err)
+			continue
+		}
+		if !reflect.DeepEqual(parsed, test.recipe) {
+			t.Errorf("%s: parsed\n%+v\nexpected\n%+v\nfrom\n%s", test.name, parsed, test.recipe, buf)
+		}
+	}
+}
+
+func TestParseRepeatedMentions(t *testing.T) {
+	text := "Mix @sugar{100%g} with @flour{500%g}, knead the @flour{}\n\nGlaze with @sugar{50%g} and a pinch of @salt\n\nAdd more @salt{}"
+	recipe, err := Parse(text)
+	if err != nil {
+		t.Fatalf("cannot parse: %v", err)
+	}
+	expected := []Ingredient{{"sugar", "100 g"}, {"flour", "500 g"}, {"sugar", "50 g"}, {"salt", ""}}
+	if !reflect.DeepEqual(recipe.Ingredients, expected) {
+		t.Errorf("ingredients are %+v, expected %+v", recipe.Ingredients, expected)
+	}
+}
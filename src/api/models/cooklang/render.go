@@ -0,0 +1,187 @@
+package cooklang
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var quantityPattern = regexp.MustCompile(`^(\d[\d.,/ ]*?)\s+([^\d\s].*)$`)
+
+// Render writes the recipe markup. All ingredients are listed before the steps,
+// their mentions in the steps are marked too.
+func Render(w io.Writer, recipe Recipe) (err error) {
+	buf := &bytes.Buffer{}
+	writeMetadata(buf, "title", recipe.Name)
+	writeMetadata(buf, "description", recipe.Description)
+	writeMetadata(buf, "category", recipe.Category)
+	writeMetadata(buf, "cuisine", recipe.Cuisine)
+	writeMetadata(buf, "course", recipe.Course)
+	writeMetadata(buf, "difficulty", recipe.Difficulty)
+	times := []struct {
+		key     string
+		minutes uint
+	}{
+		{"prep time", recipe.PrepTime},
+		{"cook time", recipe.CookTime},
+		{"rest time", recipe.RestTime},
+		{"total time", recipe.TotalTime},
+	}
+	for _, t := range times {
+		if t.minutes > 0 {
+			writeMetadata(buf, t.key, fmt.Sprintf("%d minutes", t.minutes))
+		}
+	}
+	if recipe.Servings > 0 {
+		writeMetadata(buf, "servings", fmt.Sprintf("%d", recipe.Servings))
+	}
+
+	if len(recipe.Ingredients) > 0 {
+		buf.WriteString("\n")
+		for _, item := range recipe.Ingredients {
+			buf.WriteString(renderIngredient(item.Name, item.Quantity) + "\n")
+		}
+	}
+
+	names := make([]string, 0, len(recipe.Ingredients))
+	for _, item := range recipe.Ingredients {
+		names = append(names, item.Name)
+	}
+	// longer names first so `olive oil` is not marked as `oil`
+	sort.SliceStable(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+
+	for _, step := range recipe.Steps {
+		buf.WriteString("\n" + renderStepText(step.Text, names))
+		if step.Duration != nil || step.Passive {
+			timer := ""
+			if step.Passive {
+				timer = passiveTimer
+			}
+			amount := ""
+			if step.Duration != nil {
+				amount = fmt.Sprintf("%d%%minutes", *step.Duration)
+			}
+			buf.WriteString(fmt.Sprintf(" ~%s{%s}", timer, amount))
+		}
+		if step.Temperature != nil {
+			buf.WriteString(fmt.Sprintf(" -- temperature: %d", *step.Temperature))
+		}
+		buf.WriteString("\n")
+	}
+
+	_, err = buf.WriteTo(w)
+	return
+}
+
+func writeMetadata(buf *bytes.Buffer, key, value string) {
+	if value = singleLine(value); len(value) > 0 {
+		buf.WriteString(fmt.Sprintf(">> %s: %s\n", key, escape(value, `\`)))
+	}
+}
+
+func renderIngredient(name, quantity string) string {
+	amount := escape(quantity, `\{}%`)
+	if matches := quantityPattern.FindStringSubmatch(quantity); matches != nil {
+		amount = escape(matches[1], `\{}%`) + "%" + escape(matches[2], `\{}%`)
+	}
+	return "@" + escape(name, `\@#~{}`) + "{" + amount + "}"
+}
+
+// renderStepText escapes the text and marks the first mention of every ingredient.
+// Mentions are not marked when the step would look like an ingredient list.
+func renderStepText(text string, names []string) string {
+	lower := strings.ToLower(text)
+	type mention struct {
+		start, end int
+	}
+	mentions := []mention{}
+	for _, name := range names {
+		needle := strings.ToLower(name)
+		if len(needle) == 0 || len(needle) != len(name) {
+			continue
+		}
+		for from := 0; from < len(lower); {
+			k := strings.Index(lower[from:], needle)
+			if k < 0 {
+				break
+			}
+			start, end := from+k, from+k+len(needle)
+			from = end
+			if !isWordBoundary(text, start, end) {
+				continue
+			}
+			overlaps := false
+			for _, m := range mentions {
+				overlaps = overlaps || (start < m.end && m.start < end)
+			}
+			if !overlaps {
+				mentions = append(mentions, mention{start, end})
+				break
+			}
+		}
+	}
+	sort.Slice(mentions, func(i, j int) bool {
+		return mentions[i].start < mentions[j].start
+	})
+
+	out := &strings.Builder{}
+	rest := &strings.Builder{}
+	previous := 0
+	for _, m := range mentions {
+		out.WriteString(escapeText(text[previous:m.start], previous == 0))
+		rest.WriteString(text[previous:m.start])
+		out.WriteString("@" + escape(text[m.start:m.end], `\@#~{}`) + "{}")
+		previous = m.end
+	}
+	out.WriteString(escapeText(text[previous:], previous == 0))
+	rest.WriteString(text[previous:])
+
+	if len(mentions) > 0 && len(strings.Trim(rest.String(), listSeparators)) == 0 {
+		return escapeText(text, true)
+	}
+	return out.String()
+}
+
+func isWordBoundary(text string, start, end int) bool {
+	if start > 0 && isWordRune(lastRune(text[:start])) {
+		return false
+	}
+	if end < len(text) && isWordRune([]rune(text[end:])[0]) {
+		return false
+	}
+	return true
+}
+
+func lastRune(text string) rune {
+	r := []rune(text)
+	return r[len(r)-1]
+}
+
+// escapeText escapes markup of the step text, the line start is escaped
+// so the text is not read as metadata.
+func escapeText(text string, lineStart bool) string {
+	text = escape(text, `\@#~{}`)
+	if lineStart && strings.HasPrefix(text, ">") {
+		text = `\` + text
+	}
+	return text
+}
+
+// escape puts `\` in front of the special characters and breaks comment markers.
+func escape(value, special string) string {
+	out := &strings.Builder{}
+	var previous rune
+	for _, r := range value {
+		if strings.ContainsRune(special, r) || (r == '-' && (previous == '-' || previous == '[')) {
+			out.WriteRune('\\')
+		}
+		out.WriteRune(r)
+		previous = r
+	}
+	return out.String()
+}
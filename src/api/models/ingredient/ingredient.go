@@ -2,9 +2,56 @@ package ingredient
 
 import "time"
 
+// allergens, every one is a flag column of ingredients
+const (
+	AllergenGluten    = "gluten"
+	AllergenDairy     = "dairy"
+	AllergenEggs      = "eggs"
+	AllergenNuts      = "nuts"
+	AllergenPeanuts   = "peanuts"
+	AllergenSoy       = "soy"
+	AllergenFish      = "fish"
+	AllergenShellfish = "shellfish"
+	AllergenSesame    = "sesame"
+)
+
+var allergens = map[string]bool{
+	AllergenGluten:    true,
+	AllergenDairy:     true,
+	AllergenEggs:      true,
+	AllergenNuts:      true,
+	AllergenPeanuts:   true,
+	AllergenSoy:       true,
+	AllergenFish:      true,
+	AllergenShellfish: true,
+	AllergenSesame:    true,
+}
+
+// IsValidAllergen reports whether the allergen is known.
+func IsValidAllergen(allergen string) bool {
+	return allergens[allergen]
+}
+
 type Ingredient struct {
 	Id        uint      `json:"id" gorm:"primary_key"`
 	Name string `json:"name"`
+	// used to group shopping list items, like 'dairy' or 'vegetables'
+	Category string `json:"category"`
+	// allergens
+	Gluten bool `json:"gluten"`
+	Dairy bool `json:"dairy"`
+	Eggs bool `json:"eggs"`
+	// tree nuts
+	Nuts bool `json:"nuts"`
+	Peanuts bool `json:"peanuts"`
+	Soy bool `json:"soy"`
+	Fish bool `json:"fish"`
+	Shellfish bool `json:"shellfish"`
+	Sesame bool `json:"sesame"`
+	// meat or poultry
+	Meat bool `json:"meat"`
+	// other products of animal origin, like honey
+	AnimalProduct bool `json:"animal_product"`
 	CreatedAt time.Time `json:"created_at"`
 	DeletedAt *time.Time `json:"-"`
 }
@@ -12,3 +59,17 @@ type Ingredient struct {
 func (Ingredient) TableName() string {
 	return "ingredients"
 }
+
+// Translation holds the name of the ingredient in another language.
+type Translation struct {
+	Id        uint      `json:"id" gorm:"primary_key"`
+	IngredientId uint `json:"ingredient_id"`
+	Language string `json:"language" example:"en"`
+	Name string `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Translation) TableName() string {
+	return "ingredient_translations"
+}
@@ -0,0 +1,321 @@
+package ingredient
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type Dimension string
+
+const (
+	MassDimension   Dimension = "mass"
+	VolumeDimension Dimension = "volume"
+	CountDimension  Dimension = "count"
+	// units which cannot be converted, like `clove` or `pinch`
+	CustomDimension Dimension = "custom"
+)
+
+type Unit struct {
+	Name      string
+	Dimension Dimension
+	// multiplier to the base unit of the dimension
+	Factor float64
+}
+
+var (
+	Gram       = Unit{Name: "g", Dimension: MassDimension, Factor: 1}
+	Kilogram   = Unit{Name: "kg", Dimension: MassDimension, Factor: 1000}
+	Milligram  = Unit{Name: "mg", Dimension: MassDimension, Factor: 0.001}
+	Ounce      = Unit{Name: "oz", Dimension: MassDimension, Factor: 28.3495}
+	Pound      = Unit{Name: "lb", Dimension: MassDimension, Factor: 453.592}
+	Milliliter = Unit{Name: "ml", Dimension: VolumeDimension, Factor: 1}
+	Liter      = Unit{Name: "l", Dimension: VolumeDimension, Factor: 1000}
+	Teaspoon   = Unit{Name: "tsp", Dimension: VolumeDimension, Factor: 5}
+	Tablespoon = Unit{Name: "tbsp", Dimension: VolumeDimension, Factor: 15}
+	Cup        = Unit{Name: "cup", Dimension: VolumeDimension, Factor: 240}
+	Piece      = Unit{Name: "", Dimension: CountDimension, Factor: 1}
+)
+
+var unitAliases = map[string]Unit{
+	"g": Gram, "gr": Gram, "gram": Gram, "grams": Gram, "г": Gram, "гр": Gram,
+	"kg": Kilogram, "kilogram": Kilogram, "kilograms": Kilogram, "кг": Kilogram,
+	"mg": Milligram, "milligram": Milligram, "milligrams": Milligram, "мг": Milligram,
+	"oz": Ounce, "ounce": Ounce, "ounces": Ounce,
+	"lb": Pound, "lbs": Pound, "pound": Pound, "pounds": Pound,
+	"ml": Milliliter, "milliliter": Milliliter, "milliliters": Milliliter, "millilitre": Milliliter, "millilitres": Milliliter, "мл": Milliliter,
+	"l": Liter, "liter": Liter, "liters": Liter, "litre": Liter, "litres": Liter, "л": Liter,
+	"tsp": Teaspoon, "teaspoon": Teaspoon, "teaspoons": Teaspoon, "ч.л.": Teaspoon,
+	"tbsp": Tablespoon, "tablespoon": Tablespoon, "tablespoons": Tablespoon, "ст.л.": Tablespoon,
+	"cup": Cup, "cups": Cup,
+	"": Piece, "pc": Piece, "pcs": Piece, "piece": Piece, "pieces": Piece, "шт": Piece, "шт.": Piece,
+}
+
+var unicodeFractions = map[rune]float64{
+	'½': 0.5, '⅓': 1.0 / 3, '⅔': 2.0 / 3, '¼': 0.25, '¾': 0.75, '⅛': 0.125,
+}
+
+// Quantity is a parsed amount of an ingredient, like `200 g` or `1 1/2 cups`.
+type Quantity struct {
+	Amount float64
+	Unit   Unit
+}
+
+// LookupUnit returns the known unit by its name or alias. Unknown names become custom units.
+func LookupUnit(name string) Unit {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if unit, ok := unitAliases[name]; ok {
+		return unit
+	}
+	return Unit{Name: name, Dimension: CustomDimension, Factor: 1}
+}
+
+// ParseQuantity parses the leading amount and the unit of a free form quantity.
+// Only the first word after the amount is the unit, like `g` of `500 g flour`.
+// It reports false when the text does not start with an amount.
+func ParseQuantity(text string) (q Quantity, ok bool) {
+	text = strings.TrimSpace(text)
+	amount, rest, ok := parseAmount(text)
+	if !ok {
+		return
+	}
+
+	unit := ""
+	if fields := strings.Fields(rest); len(fields) > 0 {
+		unit = fields[0]
+	}
+	q = Quantity{Amount: amount, Unit: LookupUnit(unit)}
+	return
+}
+
+func parseAmount(text string) (amount float64, rest string, ok bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return
+	}
+
+	amount, unit, ok := parseNumber(fields[0])
+	if !ok {
+		return
+	}
+	fields = fields[1:]
+
+	// mixed numbers, like `1 1/2`
+	if len(unit) == 0 && len(fields) > 0 {
+		if fraction, fractionUnit, fractionOk := parseNumber(fields[0]); fractionOk && fraction < 1 {
+			amount += fraction
+			unit = fractionUnit
+			fields = fields[1:]
+		}
+	}
+
+	rest = strings.TrimSpace(unit + " " + strings.Join(fields, " "))
+	return
+}
+
+// parseNumber parses a number at the beginning of the token, returning the unit glued to it (`200g`).
+func parseNumber(token string) (value float64, rest string, ok bool) {
+	runes := []rune(token)
+	if len(runes) == 0 {
+		return
+	}
+
+	if fraction, isFraction := unicodeFractions[runes[0]]; isFraction {
+		return fraction, string(runes[1:]), true
+	}
+
+	end := 0
+	for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.' || runes[end] == ',' || runes[end] == '/') {
+		end++
+	}
+	if end == 0 {
+		return
+	}
+
+	number := string(runes[:end])
+	rest = string(runes[end:])
+	if fraction, isFraction := unicodeFractions[firstRune(rest)]; isFraction {
+		rest = string([]rune(rest)[1:])
+		defer func() { value += fraction }()
+	}
+
+	if parts := strings.SplitN(number, "/", 2); len(parts) == 2 {
+		numerator, err := parseDecimal(parts[0])
+		if err != nil {
+			return
+		}
+		denominator, err := parseDecimal(parts[1])
+		if err != nil || denominator == 0 {
+			return
+		}
+		return numerator / denominator, rest, true
+	}
+
+	value, err := parseDecimal(number)
+	if err != nil {
+		return
+	}
+	return value, rest, true
+}
+
+// parseDecimal parses the number written with commas. A single comma followed by one or two digits
+// is the decimal separator (`2,5`), other commas separate thousands (`1,000` or `1,000.5`).
+func parseDecimal(number string) (float64, error) {
+	groups := strings.Split(number, ",")
+	switch {
+	case len(groups) == 1:
+	case len(groups) == 2 && !strings.Contains(number, ".") && len(groups[1]) > 0 && len(groups[1]) <= 2:
+		number = groups[0] + "." + groups[1]
+	case isThousands(groups):
+		number = strings.Replace(number, ",", "", -1)
+	default:
+		return 0, fmt.Errorf("number `%s` has misplaced commas", number)
+	}
+	return strconv.ParseFloat(number, 64)
+}
+
+// isThousands reports whether the comma separated groups are thousands, like `1,000,000.5`.
+func isThousands(groups []string) bool {
+	first := groups[0]
+	if len(first) == 0 || len(first) > 3 || first[0] == '0' || strings.Contains(first, ".") {
+		return false
+	}
+	for i, group := range groups[1:] {
+		if i == len(groups)-2 {
+			// the last group can have the decimal part
+			group = strings.SplitN(group, ".", 2)[0]
+		}
+		if len(group) != 3 || strings.Contains(group, ".") {
+			return false
+		}
+	}
+	return true
+}
+
+func firstRune(text string) rune {
+	for _, r := range text {
+		return r
+	}
+	return 0
+}
+
+// CanAdd reports whether both quantities can be converted to the same unit.
+func (q Quantity) CanAdd(other Quantity) bool {
+	if q.Unit.Dimension != other.Unit.Dimension {
+		return false
+	}
+	if q.Unit.Dimension == CustomDimension {
+		return q.Unit.Name == other.Unit.Name
+	}
+	return true
+}
+
+// Add sums compatible quantities. The unit is kept when both use the same one,
+// otherwise the result is expressed in the base unit of the dimension.
+func (q Quantity) Add(other Quantity) Quantity {
+	if q.Unit == other.Unit {
+		return Quantity{Amount: q.Amount + other.Amount, Unit: q.Unit}
+	}
+	return Quantity{Amount: q.Base() + other.Base(), Unit: baseUnits[q.Unit.Dimension]}.Normalize()
+}
+
+// Sub subtracts the compatible quantity keeping the unit. The result is negative
+// when the other quantity is bigger.
+func (q Quantity) Sub(other Quantity) Quantity {
+	return Quantity{Amount: q.Amount - other.In(q.Unit).Amount, Unit: q.Unit}
+}
+
+// In converts the quantity to the given unit of the same dimension.
+func (q Quantity) In(unit Unit) Quantity {
+	if q.Unit == unit {
+		return q
+	}
+	return Quantity{Amount: q.Base() / unit.Factor, Unit: unit}
+}
+
+// Scale multiplies the amount keeping the unit.
+func (q Quantity) Scale(factor float64) Quantity {
+	return Quantity{Amount: q.Amount * factor, Unit: q.Unit}
+}
+
+// ScaleQuantity multiplies the amount of a free form quantity, like `200 g` by 1.5 to `300 g`.
+// Quantities without amount, like `to taste`, are kept as they are.
+func ScaleQuantity(text string, factor float64) string {
+	q, ok := ParseQuantity(text)
+	if !ok || factor == 1 {
+		return text
+	}
+	return q.Scale(factor).String()
+}
+
+// IsEmpty reports whether nothing is left, ignoring rounding errors of unit conversions.
+func (q Quantity) IsEmpty() bool {
+	return q.Amount < 0.005
+}
+
+// Base returns the amount in the base unit of the dimension.
+func (q Quantity) Base() float64 {
+	return q.Amount * q.Unit.Factor
+}
+
+var baseUnits = map[Dimension]Unit{
+	MassDimension:   Gram,
+	VolumeDimension: Milliliter,
+	CountDimension:  Piece,
+}
+
+// Normalize switches base units to bigger ones for big amounts, like 1500 g to 1.5 kg.
+func (q Quantity) Normalize() Quantity {
+	switch {
+	case q.Unit == Gram && q.Amount >= 1000:
+		return Quantity{Amount: q.Amount / Kilogram.Factor, Unit: Kilogram}
+	case q.Unit == Milliliter && q.Amount >= 1000:
+		return Quantity{Amount: q.Amount / Liter.Factor, Unit: Liter}
+	}
+	return q
+}
+
+func (q Quantity) String() string {
+	amount := strconv.FormatFloat(math.Round(q.Amount*100)/100, 'f', -1, 64)
+	if len(q.Unit.Name) == 0 {
+		return amount
+	}
+	return amount + " " + q.Unit.Name
+}
+
+// SplitLine splits a free form ingredient line, like `2 cups of flour`, into the quantity
+// and the ingredient name keeping the original wording. Quantity is empty when the line
+// does not start with an amount.
+func SplitLine(line string) (quantity, name string) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return
+	}
+
+	_, glued, ok := parseNumber(fields[0])
+	if !ok {
+		return "", strings.Join(fields, " ")
+	}
+
+	n := 1
+	if len(glued) == 0 && len(fields) > n {
+		// mixed numbers, like `1 1/2`
+		if fraction, fractionUnit, fractionOk := parseNumber(fields[n]); fractionOk && fraction < 1 {
+			glued = fractionUnit
+			n++
+		}
+	}
+	if len(glued) == 0 && len(fields) > n {
+		if _, known := unitAliases[strings.ToLower(fields[n])]; known {
+			n++
+		}
+	}
+
+	rest := fields[n:]
+	if len(rest) > 1 && strings.ToLower(rest[0]) == "of" {
+		rest = rest[1:]
+	}
+	return strings.Join(fields[:n], " "), strings.Join(rest, " ")
+}
@@ -0,0 +1,59 @@
+package ingredient
+
+import "testing"
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		text   string
+		amount float64
+		unit   Unit
+		ok     bool
+	}{
+		{"500 g flour", 500, Gram, true},
+		{"200g", 200, Gram, true},
+		{"1 1/2 cups of milk", 1.5, Cup, true},
+		{"½ tsp", 0.5, Teaspoon, true},
+		{"2,5 кг", 2.5, Kilogram, true},
+		{"3", 3, Piece, true},
+		{"2 cloves garlic", 2, Unit{Name: "cloves", Dimension: CustomDimension, Factor: 1}, true},
+		{"to taste", 0, Unit{}, false},
+		{"0,25 l", 0.25, Liter, true},
+		{"1,000 g", 1000, Gram, true},
+		{"1,000g", 1000, Gram, true},
+		{"12,500,000 g", 12500000, Gram, true},
+		{"1,000.5 g", 1000.5, Gram, true},
+		{"1,5,0 g", 0, Unit{}, false},
+		{"1,00,0 g", 0, Unit{}, false},
+		{"0,125 kg", 0, Unit{}, false},
+		{"1.5,0 g", 0, Unit{}, false},
+		{"1,5.0 g", 0, Unit{}, false},
+		{"1,5/2 cups", 0.75, Cup, true},
+		{"1,000/4 g", 250, Gram, true},
+	}
+	for _, test := range tests {
+		q, ok := ParseQuantity(test.text)
+		if ok != test.ok || q.Amount != test.amount || q.Unit != test.unit {
+			t.Errorf("ParseQuantity(%q) = %v, %t, expected %v %v, %t", test.text, q, ok, test.amount, test.unit, test.ok)
+		}
+	}
+}
+
+func TestScaleQuantity(t *testing.T) {
+	tests := []struct {
+		text     string
+		factor   float64
+		expected string
+	}{
+		{"200 g", 1.5, "300 g"},
+		{"1 1/2 cups", 2, "3 cup"},
+		{"2", 0.5, "1"},
+		{"½ tsp", 3, "1.5 tsp"},
+		{"to taste", 2, "to taste"},
+		{"200 g", 1, "200 g"},
+	}
+	for _, test := range tests {
+		if actual := ScaleQuantity(test.text, test.factor); actual != test.expected {
+			t.Errorf("ScaleQuantity(%q, %v) = %q, expected %q", test.text, test.factor, actual, test.expected)
+		}
+	}
+}
@@ -51,6 +51,66 @@ func (r *IngredientRepository) Update(ingredient *Ingredient) (err error) {
 		return
 	}
 
-	err = r.db.Model(Ingredient{}).Where(&Ingredient{Id: ingredient.Id}).Update(ingredient).Error
+	// map is used to allow clearing of flags
+	err = r.db.Model(Ingredient{}).Where(&Ingredient{Id: ingredient.Id}).
+		Updates(map[string]interface{}{
+			"name":           ingredient.Name,
+			"category":       ingredient.Category,
+			"gluten":         ingredient.Gluten,
+			"dairy":          ingredient.Dairy,
+			"eggs":           ingredient.Eggs,
+			"nuts":           ingredient.Nuts,
+			"peanuts":        ingredient.Peanuts,
+			"soy":            ingredient.Soy,
+			"fish":           ingredient.Fish,
+			"shellfish":      ingredient.Shellfish,
+			"sesame":         ingredient.Sesame,
+			"meat":           ingredient.Meat,
+			"animal_product": ingredient.AnimalProduct,
+		}).Error
+	return
+}
+
+// GetByName finds the ingredient by its name, the comparison follows the column collation.
+func (r *IngredientRepository) GetByName(name string) (ingredient Ingredient, err error) {
+	if name == "" {
+		err = fmt.Errorf("ingredient name cannot be empty")
+		return
+	}
+	err = r.db.Where(&Ingredient{Name: name}).First(&ingredient).Error
+	return
+}
+
+// GetTranslations returns translations of the given ingredients to the language, ingredients without one are skipped.
+func (r *IngredientRepository) GetTranslations(ids []uint, language string) (translations []Translation, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	err = r.db.Where("ingredient_id IN (?) AND language = ?", ids, language).Find(&translations).Error
+	return
+}
+
+// GetTranslationsById returns translations of the ingredient to all languages.
+func (r *IngredientRepository) GetTranslationsById(id uint) (translations []Translation, err error) {
+	if id == 0 {
+		err = fmt.Errorf("ingredient id cannot be empty")
+		return
+	}
+
+	err = r.db.Where(&Translation{IngredientId: id}).Order("language ASC").Find(&translations).Error
+	return
+}
+
+// SaveTranslation creates the translation of the ingredient to its language or updates the existing one.
+func (r *IngredientRepository) SaveTranslation(translation *Translation) (err error) {
+	if translation.IngredientId == 0 || translation.Language == "" {
+		err = fmt.Errorf("ingredient id and language cannot be empty")
+		return
+	}
+
+	err = r.db.Where(&Translation{IngredientId: translation.IngredientId, Language: translation.Language}).
+		Assign(Translation{Name: translation.Name}).
+		FirstOrCreate(translation).Error
 	return
 }
@@ -0,0 +1,87 @@
+package mealplan
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	calendarDateTimeFormat = "20060102T150405"
+	calendarLineLimit      = 75
+	defaultMealDuration    = 30 * time.Minute
+)
+
+var calendarTextEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// WriteCalendar renders entries as an iCalendar (RFC 5545) feed.
+// Events use floating local time, so meals stay at the same hours in any time zone.
+func WriteCalendar(w io.Writer, name string, entries []Entry) (err error) {
+	buf := &bytes.Buffer{}
+	writeCalendarLine(buf, "BEGIN:VCALENDAR")
+	writeCalendarLine(buf, "VERSION:2.0")
+	writeCalendarLine(buf, "PRODID:-//Food API//Meal plan//EN")
+	writeCalendarLine(buf, "CALSCALE:GREGORIAN")
+	writeCalendarLine(buf, "METHOD:PUBLISH")
+	writeCalendarLine(buf, "X-WR-CALNAME:"+escapeCalendarText(name))
+	for _, entry := range entries {
+		writeCalendarEvent(buf, entry)
+	}
+	writeCalendarLine(buf, "END:VCALENDAR")
+
+	_, err = buf.WriteTo(w)
+	return
+}
+
+func writeCalendarEvent(buf *bytes.Buffer, entry Entry) {
+	summary := entry.Slot
+	description := fmt.Sprintf("Servings: %d", entry.Servings)
+	duration := defaultMealDuration
+	if entry.Receipt != nil {
+		summary = fmt.Sprintf("%s: %s", strings.Title(entry.Slot), entry.Receipt.Name)
+		if len(entry.Receipt.Description) > 0 {
+			description = entry.Receipt.Description + "\n" + description
+		}
+		if entry.Receipt.TotalTime > 0 {
+			duration = time.Duration(entry.Receipt.TotalTime) * time.Minute
+		}
+	}
+
+	start := entry.StartsAt()
+	writeCalendarLine(buf, "BEGIN:VEVENT")
+	writeCalendarLine(buf, fmt.Sprintf("UID:meal-plan-entry-%d@food", entry.Id))
+	writeCalendarLine(buf, "DTSTAMP:"+entry.UpdatedAt.UTC().Format(calendarDateTimeFormat)+"Z")
+	writeCalendarLine(buf, "DTSTART:"+start.Format(calendarDateTimeFormat))
+	writeCalendarLine(buf, "DTEND:"+start.Add(duration).Format(calendarDateTimeFormat))
+	writeCalendarLine(buf, "SUMMARY:"+escapeCalendarText(summary))
+	writeCalendarLine(buf, "DESCRIPTION:"+escapeCalendarText(description))
+	writeCalendarLine(buf, "END:VEVENT")
+}
+
+func escapeCalendarText(text string) string {
+	return calendarTextEscaper.Replace(text)
+}
+
+// writeCalendarLine writes the content line folded to 75 octets without splitting UTF-8 characters.
+func writeCalendarLine(buf *bytes.Buffer, line string) {
+	limit := calendarLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines start with a space, which counts toward the limit
+		limit = calendarLineLimit - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
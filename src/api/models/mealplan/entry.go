@@ -0,0 +1,49 @@
+package mealplan
+
+import (
+	"food/src/api/models/receipt"
+	"time"
+)
+
+const (
+	DateFormat = "2006-01-02"
+
+	BreakfastSlot = "breakfast"
+	LunchSlot     = "lunch"
+	DinnerSlot    = "dinner"
+)
+
+// slotStartHours defines when the meal of the slot starts in the calendar feed.
+var slotStartHours = map[string]int{
+	BreakfastSlot: 8,
+	LunchSlot:     13,
+	DinnerSlot:    19,
+}
+
+func IsValidSlot(slot string) bool {
+	_, ok := slotStartHours[slot]
+	return ok
+}
+
+type Entry struct {
+	Id        uint      `json:"id" gorm:"primary_key"`
+	UserId    uint      `json:"user_id"`
+	ReceiptId uint      `json:"receipt_id"`
+	Date      time.Time `json:"date" example:"2019-06-28T00:00:00Z"`
+	// breakfast, lunch or dinner
+	Slot      string           `json:"slot" example:"dinner"`
+	Servings  uint             `json:"servings" example:"2"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+	DeletedAt *time.Time       `json:"-"`
+	Receipt   *receipt.Receipt `json:"receipt,omitempty" gorm:"foreignkey:ReceiptId"`
+}
+
+func (Entry) TableName() string {
+	return "meal_plan_entries"
+}
+
+// StartsAt returns the floating local time when the meal starts.
+func (e Entry) StartsAt() time.Time {
+	return time.Date(e.Date.Year(), e.Date.Month(), e.Date.Day(), slotStartHours[e.Slot], 0, 0, 0, time.UTC)
+}
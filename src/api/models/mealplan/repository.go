@@ -0,0 +1,87 @@
+package mealplan
+
+import (
+	"fmt"
+	"food/src/api/models/receipt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+type EntryRepository struct {
+	db *gorm.DB
+}
+
+func GetEntryRepository(db *gorm.DB) *EntryRepository {
+	return &EntryRepository{db: db}
+}
+
+// GetByDateRange returns entries of the user between from and to dates inclusively,
+// ordered by date and slot time. Entries of receipts in trash or not visible to the user anymore are skipped.
+func (r *EntryRepository) GetByDateRange(userId uint, from, to time.Time) (entries []Entry, err error) {
+	if userId == 0 {
+		err = fmt.Errorf("user id cannot be empty")
+		return
+	}
+
+	err = r.db.Preload("Receipt").
+		Preload("Receipt.Media").
+		Where("user_id = ? AND date BETWEEN ? AND ?", userId, from.Format(DateFormat), to.Format(DateFormat)).
+		Where("receipt_id IN (?)", receipt.GetReceiptRepository(r.db).VisibleIdsQuery(userId)).
+		Order("date ASC").
+		Order(gorm.Expr("FIELD(slot, ?, ?, ?)", BreakfastSlot, LunchSlot, DinnerSlot)).
+		Order("id ASC").
+		Find(&entries).Error
+	return
+}
+
+// GetById returns the entry, its receipt is loaded only when it is visible to the user.
+func (r *EntryRepository) GetById(id, userId uint) (entry Entry, err error) {
+	if id == 0 {
+		err = fmt.Errorf("entry id cannot be empty")
+		return
+	}
+	err = r.db.Where(&Entry{Id: id}).
+		Preload("Receipt", "id IN (?)", receipt.GetReceiptRepository(r.db).VisibleIdsQuery(userId)).
+		Preload("Receipt.Media").
+		First(&entry).Error
+	return
+}
+
+func (r *EntryRepository) Create(entry *Entry) (err error) {
+	if entry == nil {
+		err = fmt.Errorf("entry cannot be empty")
+		return
+	}
+	if entry.Id != 0 {
+		err = fmt.Errorf("entry id should be empty")
+		return
+	}
+	err = r.db.Create(entry).Error
+	return
+}
+
+func (r *EntryRepository) Update(entry *Entry) (err error) {
+	if entry == nil {
+		err = fmt.Errorf("entry cannot be empty")
+		return
+	}
+	if entry.Id == 0 {
+		err = fmt.Errorf("entry id cannot be empty")
+		return
+	}
+
+	err = r.db.Model(&Entry{}).Where(&Entry{Id: entry.Id}).
+		Updates(map[string]interface{}{"date": entry.Date.Format(DateFormat), "slot": entry.Slot, "servings": entry.Servings}).Error
+	return
+}
+
+func (r *EntryRepository) Delete(id uint) (err error) {
+	if id == 0 {
+		err = fmt.Errorf("entry id cannot be empty")
+		return
+	}
+
+	err = r.db.Model(Entry{}).Where(&Entry{Id: id}).Delete(Entry{}).Error
+	return
+}
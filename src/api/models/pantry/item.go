@@ -0,0 +1,64 @@
+package pantry
+
+import (
+	"food/src/api/models/ingredient"
+	"time"
+)
+
+const DateFormat = "2006-01-02"
+
+// Item is a stock of an ingredient the user has at home.
+// One ingredient can be stored several times, like packs with different expiry dates.
+type Item struct {
+	Id           uint   `json:"id" gorm:"primary_key"`
+	UserId       uint   `json:"user_id"`
+	IngredientId uint   `json:"ingredient_id"`
+	Quantity     string `json:"quantity" example:"500 g"`
+	// empty when the item does not expire
+	ExpiresAt  *time.Time             `json:"expires_at" example:"2019-07-10T00:00:00Z"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+	DeletedAt  *time.Time             `json:"-"`
+	Ingredient *ingredient.Ingredient `json:"ingredient,omitempty" gorm:"foreignkey:IngredientId"`
+}
+
+func (Item) TableName() string {
+	return "pantry_items"
+}
+
+// IsExpired reports whether the item is expired at the given day.
+func (i Item) IsExpired(day time.Time) bool {
+	return i.ExpiresAt != nil && i.ExpiresAt.Format(DateFormat) < day.Format(DateFormat)
+}
+
+// Consume takes the needed quantity from the stocks, the ones expiring first are used first.
+// It returns changed stocks, the ids of stocks which are used up and the quantity still needed.
+// Stocks expired at the given day, with unknown or incompatible quantities are kept untouched.
+func Consume(stocks []Item, need ingredient.Quantity, today time.Time) (changed []Item, usedUpIds []uint, left ingredient.Quantity) {
+	left = need
+	for _, stock := range stocks {
+		if left.IsEmpty() {
+			break
+		}
+		if stock.IsExpired(today) {
+			continue
+		}
+
+		q, ok := ingredient.ParseQuantity(stock.Quantity)
+		if !ok || !q.CanAdd(left) {
+			continue
+		}
+
+		rest := q.Sub(left)
+		if rest.IsEmpty() {
+			usedUpIds = append(usedUpIds, stock.Id)
+			left = left.Sub(q)
+			continue
+		}
+
+		stock.Quantity = rest.Normalize().String()
+		changed = append(changed, stock)
+		left = ingredient.Quantity{Unit: left.Unit}
+	}
+	return
+}
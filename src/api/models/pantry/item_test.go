@@ -0,0 +1,43 @@
+package pantry
+
+import (
+	"testing"
+	"time"
+
+	"food/src/api/models/ingredient"
+)
+
+func TestConsumeSkipsExpiredStocks(t *testing.T) {
+	today := time.Date(2019, 7, 10, 12, 0, 0, 0, time.UTC)
+	yesterday := today.AddDate(0, 0, -1)
+	tomorrow := today.AddDate(0, 0, 1)
+	stocks := []Item{
+		{Id: 1, Quantity: "300 g", ExpiresAt: &yesterday},
+		{Id: 2, Quantity: "200 g", ExpiresAt: &today},
+		{Id: 3, Quantity: "1 kg", ExpiresAt: &tomorrow},
+		{Id: 4, Quantity: "1 kg"},
+	}
+	need, _ := ingredient.ParseQuantity("500 g")
+
+	changed, usedUpIds, left := Consume(stocks, need, today)
+	if len(usedUpIds) != 1 || usedUpIds[0] != 2 {
+		t.Errorf("used up %v, expected stock 2 only", usedUpIds)
+	}
+	if len(changed) != 1 || changed[0].Id != 3 || changed[0].Quantity != "0.7 kg" {
+		t.Errorf("changed %v, expected 0.7 kg of stock 3", changed)
+	}
+	if !left.IsEmpty() {
+		t.Errorf("%v is still needed", left)
+	}
+}
+
+func TestConsumeLeavesNeedWhenOnlyExpired(t *testing.T) {
+	today := time.Date(2019, 7, 10, 0, 0, 0, 0, time.UTC)
+	yesterday := today.AddDate(0, 0, -1)
+	need, _ := ingredient.ParseQuantity("2 eggs")
+
+	changed, usedUpIds, left := Consume([]Item{{Id: 1, Quantity: "6 eggs", ExpiresAt: &yesterday}}, need, today)
+	if len(changed) != 0 || len(usedUpIds) != 0 || left != need {
+		t.Errorf("expired stock was used: changed %v, used up %v, left %v", changed, usedUpIds, left)
+	}
+}
@@ -0,0 +1,136 @@
+package pantry
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+type ItemRepository struct {
+	db *gorm.DB
+}
+
+func GetItemRepository(db *gorm.DB) *ItemRepository {
+	return &ItemRepository{db: db}
+}
+
+// stockOrder puts items expiring first on top, items without expiry date go last.
+func stockOrder(query *gorm.DB) *gorm.DB {
+	return query.Order("expires_at IS NULL ASC").Order("expires_at ASC").Order("id ASC")
+}
+
+func (r *ItemRepository) GetAllByUserId(userId uint) (items []Item, err error) {
+	if userId == 0 {
+		err = fmt.Errorf("user id cannot be empty")
+		return
+	}
+	err = stockOrder(r.db.Preload("Ingredient").Where(&Item{UserId: userId})).Find(&items).Error
+	return
+}
+
+// GetExpiringByUserId returns items of the user which expire not later than the given day,
+// already expired items included.
+func (r *ItemRepository) GetExpiringByUserId(userId uint, until time.Time) (items []Item, err error) {
+	if userId == 0 {
+		err = fmt.Errorf("user id cannot be empty")
+		return
+	}
+	query := r.db.Preload("Ingredient").
+		Where(&Item{UserId: userId}).
+		Where("expires_at IS NOT NULL AND expires_at <= ?", until.Format(DateFormat))
+	err = stockOrder(query).Find(&items).Error
+	return
+}
+
+// GetByIngredientIds returns stocks of the given ingredients, the ones expiring first go first.
+func (r *ItemRepository) GetByIngredientIds(userId uint, ingredientIds []uint) (items []Item, err error) {
+	if userId == 0 {
+		err = fmt.Errorf("user id cannot be empty")
+		return
+	}
+	if len(ingredientIds) == 0 {
+		return
+	}
+	query := r.db.Where(&Item{UserId: userId}).Where("ingredient_id IN (?)", ingredientIds)
+	err = stockOrder(query).Find(&items).Error
+	return
+}
+
+func (r *ItemRepository) GetById(id uint) (item Item, err error) {
+	if id == 0 {
+		err = fmt.Errorf("pantry item id cannot be empty")
+		return
+	}
+	err = r.db.Where(&Item{Id: id}).Preload("Ingredient").First(&item).Error
+	return
+}
+
+func (r *ItemRepository) Create(item *Item) (err error) {
+	if item == nil {
+		err = fmt.Errorf("pantry item cannot be empty")
+		return
+	}
+	if item.Id != 0 {
+		err = fmt.Errorf("pantry item id should be empty")
+		return
+	}
+	err = r.db.Omit("Ingredient").Create(item).Error
+	return
+}
+
+func (r *ItemRepository) Update(item *Item) (err error) {
+	if item == nil {
+		err = fmt.Errorf("pantry item cannot be empty")
+		return
+	}
+	if item.Id == 0 {
+		err = fmt.Errorf("pantry item id cannot be empty")
+		return
+	}
+
+	err = updateItem(r.db, item)
+	return
+}
+
+func updateItem(db *gorm.DB, item *Item) error {
+	var expiresAt interface{}
+	if item.ExpiresAt != nil {
+		expiresAt = item.ExpiresAt.Format(DateFormat)
+	}
+	return db.Model(&Item{}).Where(&Item{Id: item.Id}).
+		Updates(map[string]interface{}{"quantity": item.Quantity, "expires_at": expiresAt}).Error
+}
+
+func (r *ItemRepository) Delete(id uint) (err error) {
+	if id == 0 {
+		err = fmt.Errorf("pantry item id cannot be empty")
+		return
+	}
+
+	err = r.db.Model(Item{}).Where(&Item{Id: id}).Delete(Item{}).Error
+	return
+}
+
+// Consume saves changed quantities and removes used up items in one transaction.
+func (r *ItemRepository) Consume(changed []Item, usedUpIds []uint) (err error) {
+	tx := r.db.Begin()
+	for i := range changed {
+		err = updateItem(tx, &changed[i])
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	if len(usedUpIds) > 0 {
+		err = tx.Where("id IN (?)", usedUpIds).Delete(Item{}).Error
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	err = tx.Commit().Error
+	return
+}
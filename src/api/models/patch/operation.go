@@ -0,0 +1,348 @@
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type operation struct {
+	Op    string
+	Path  pointer
+	From  pointer
+	Value interface{}
+}
+
+// Apply applies the JSON Patch operations to the document in order. Nothing is applied
+// when one of them fails, including a failed `test`.
+func Apply(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("document is invalid: %s", err)
+	}
+	operations, err := readOperations(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, op := range operations {
+		target, err = op.apply(target)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d `%s`: %s", k, op.Op, err)
+		}
+	}
+	return json.Marshal(target)
+}
+
+func readOperations(patch []byte) (operations []operation, err error) {
+	value, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("JSON Patch is invalid: %s", err)
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("JSON Patch should be an array of operations")
+	}
+
+	for k, item := range items {
+		members, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("operation %d should be an object", k)
+		}
+		op := operation{}
+		op.Op, _ = members["op"].(string)
+		path, ok := members["path"].(string)
+		if !ok {
+			return nil, fmt.Errorf("operation %d has no path", k)
+		}
+		op.Path, err = parsePointer(path)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %s", k, err)
+		}
+
+		switch op.Op {
+		case "add", "replace", "test":
+			value, ok := members["value"]
+			if !ok {
+				return nil, fmt.Errorf("operation %d `%s` has no value", k, op.Op)
+			}
+			op.Value = value
+		case "move", "copy":
+			from, ok := members["from"].(string)
+			if !ok {
+				return nil, fmt.Errorf("operation %d `%s` has no from", k, op.Op)
+			}
+			op.From, err = parsePointer(from)
+			if err != nil {
+				return nil, fmt.Errorf("operation %d: %s", k, err)
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("operation %d `%s` is not supported", k, op.Op)
+		}
+		operations = append(operations, op)
+	}
+	return
+}
+
+func (op operation) apply(doc interface{}) (interface{}, error) {
+	switch op.Op {
+	case "add":
+		return add(doc, op.Path, op.Value)
+	case "remove":
+		doc, _, err := remove(doc, op.Path)
+		return doc, err
+	case "replace":
+		if len(op.Path) == 0 {
+			return op.Value, nil
+		}
+		doc, _, err := remove(doc, op.Path)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, op.Path, op.Value)
+	case "move":
+		if op.From.contains(op.Path) {
+			return nil, fmt.Errorf("value cannot be moved into itself")
+		}
+		doc, value, err := remove(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, op.Path, value)
+	case "copy":
+		value, err := get(doc, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, op.Path, clone(value))
+	case "test":
+		value, err := get(doc, op.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(value, op.Value) {
+			return nil, fmt.Errorf("value at `%s` is different", op.Path)
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("operation is not supported")
+}
+
+// pointer is a parsed RFC 6901 JSON Pointer, empty one refers to the whole document.
+type pointer []string
+
+func parsePointer(path string) (pointer, error) {
+	if len(path) == 0 {
+		return pointer{}, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("path `%s` should start with `/`", path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for k, token := range tokens {
+		tokens[k] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+	return pointer(tokens), nil
+}
+
+func (p pointer) String() string {
+	out := &strings.Builder{}
+	for _, token := range p {
+		out.WriteString("/" + strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1))
+	}
+	return out.String()
+}
+
+// contains reports whether the other pointer refers to a member inside of the value.
+func (p pointer) contains(other pointer) bool {
+	if len(other) <= len(p) {
+		return false
+	}
+	for k := range p {
+		if p[k] != other[k] {
+			return false
+		}
+	}
+	return true
+}
+
+func get(doc interface{}, path pointer) (interface{}, error) {
+	for k, token := range path {
+		switch container := doc.(type) {
+		case map[string]interface{}:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("path `%s` does not exist", path[:k+1])
+			}
+			doc = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, fmt.Errorf("path `%s`: %s", path[:k+1], err)
+			}
+			doc = container[index]
+		default:
+			return nil, fmt.Errorf("path `%s` does not exist", path[:k+1])
+		}
+	}
+	return doc, nil
+}
+
+// add sets the member of the object or inserts the array item, `-` appends it.
+func add(doc interface{}, path pointer, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	token := path[len(path)-1]
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		container[token] = value
+		return doc, nil
+	case []interface{}:
+		index := len(container)
+		if token != "-" {
+			index, err = arrayIndex(token, len(container))
+			if err != nil {
+				return nil, fmt.Errorf("path `%s`: %s", path, err)
+			}
+		}
+		items := append(container[:index:index], value)
+		items = append(items, container[index:]...)
+		return set(doc, path[:len(path)-1], items)
+	}
+	return nil, fmt.Errorf("parent of `%s` is not an object or array", path)
+}
+
+func remove(doc interface{}, path pointer) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("whole document cannot be removed")
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token := path[len(path)-1]
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		value, ok := container[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("path `%s` does not exist", path)
+		}
+		delete(container, token)
+		return doc, value, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(container)-1)
+		if err != nil {
+			return nil, nil, fmt.Errorf("path `%s`: %s", path, err)
+		}
+		value := container[index]
+		items := append(container[:index:index], container[index+1:]...)
+		doc, err = set(doc, path[:len(path)-1], items)
+		return doc, value, err
+	}
+	return nil, nil, fmt.Errorf("path `%s` does not exist", path)
+}
+
+// set replaces the value at the existing path, arrays are replaced as they change their length.
+func set(doc interface{}, path pointer, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	token := path[len(path)-1]
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		container[token] = value
+	case []interface{}:
+		index, err := arrayIndex(token, len(container)-1)
+		if err != nil {
+			return nil, err
+		}
+		container[index] = value
+	}
+	return doc, nil
+}
+
+// arrayIndex reads the array index which should not be greater than max.
+func arrayIndex(token string, max int) (int, error) {
+	if len(token) == 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("array index `%s` is invalid", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("array index `%s` is invalid", token)
+	}
+	if index > max {
+		return 0, fmt.Errorf("array index `%d` is out of range", index)
+	}
+	return index, nil
+}
+
+func clone(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			object[key] = clone(item)
+		}
+		return object
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for k, item := range v {
+			items[k] = clone(item)
+		}
+		return items
+	}
+	return value
+}
+
+// equal compares the values, numbers are compared by their value like `1` and `1.0`.
+func equal(a, b interface{}) bool {
+	numberA, okA := a.(json.Number)
+	numberB, okB := b.(json.Number)
+	if okA && okB {
+		valueA, errA := numberA.Float64()
+		valueB, errB := numberB.Float64()
+		return errA == nil && errB == nil && valueA == valueB
+	}
+
+	switch v := a.(type) {
+	case map[string]interface{}:
+		other, ok := b.(map[string]interface{})
+		if !ok || len(v) != len(other) {
+			return false
+		}
+		for key, item := range v {
+			otherItem, ok := other[key]
+			if !ok || !equal(item, otherItem) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		other, ok := b.([]interface{})
+		if !ok || len(v) != len(other) {
+			return false
+		}
+		for k := range v {
+			if !equal(v[k], other[k]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,72 @@
+// Package patch applies partial updates to JSON documents, either RFC 7396 merge patches
+// or RFC 6902 JSON Patch operations.
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	MergePatchMediaType = "application/merge-patch+json"
+	JSONPatchMediaType  = "application/json-patch+json"
+)
+
+// IsMediaType reports whether the patch of the media type can be applied.
+// Plain JSON is read as a merge patch.
+func IsMediaType(mediaType string) bool {
+	return mediaType == MergePatchMediaType || mediaType == JSONPatchMediaType || mediaType == "application/json"
+}
+
+// ApplyMediaType applies the patch of the given media type to the document.
+func ApplyMediaType(mediaType string, doc, patch []byte) ([]byte, error) {
+	if mediaType == JSONPatchMediaType {
+		return Apply(doc, patch)
+	}
+	return Merge(doc, patch)
+}
+
+// Merge applies the merge patch to the document. Null members of the patch remove
+// the members of the document, objects are merged recursively and everything else,
+// arrays included, is replaced.
+func Merge(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("document is invalid: %s", err)
+	}
+	changes, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("merge patch is invalid: %s", err)
+	}
+	return json.Marshal(merge(target, changes))
+}
+
+func merge(target, patch interface{}) interface{} {
+	changes, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	object, ok := target.(map[string]interface{})
+	if !ok {
+		object = map[string]interface{}{}
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(object, key)
+			continue
+		}
+		object[key] = merge(object[key], value)
+	}
+	return object
+}
+
+func decode(data []byte) (value interface{}, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err = decoder.Decode(&value)
+	if err == nil && decoder.More() {
+		err = fmt.Errorf("unexpected data after the value")
+	}
+	return
+}
@@ -0,0 +1,183 @@
+package patch
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// canonical returns the JSON with sorted members and without spaces, so documents can be compared as text.
+func canonical(t *testing.T, doc string) string {
+	var value interface{}
+	err := json.Unmarshal([]byte(doc), &value)
+	if err != nil {
+		t.Fatalf("cannot decode %s: %v", doc, err)
+	}
+	out, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("cannot encode %s: %v", doc, err)
+	}
+	return string(out)
+}
+
+// Examples of RFC 6902 Appendix A, empty result means an error is expected.
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		doc    string
+		patch  string
+		result string
+	}{
+		{"add object member", `{"foo": "bar"}`,
+			`[{"op": "add", "path": "/baz", "value": "qux"}]`,
+			`{"baz": "qux", "foo": "bar"}`},
+		{"add array element", `{"foo": ["bar", "baz"]}`,
+			`[{"op": "add", "path": "/foo/1", "value": "qux"}]`,
+			`{"foo": ["bar", "qux", "baz"]}`},
+		{"remove object member", `{"baz": "qux", "foo": "bar"}`,
+			`[{"op": "remove", "path": "/baz"}]`,
+			`{"foo": "bar"}`},
+		{"remove array element", `{"foo": ["bar", "qux", "baz"]}`,
+			`[{"op": "remove", "path": "/foo/1"}]`,
+			`{"foo": ["bar", "baz"]}`},
+		{"replace value", `{"baz": "qux", "foo": "bar"}`,
+			`[{"op": "replace", "path": "/baz", "value": "boo"}]`,
+			`{"baz": "boo", "foo": "bar"}`},
+		{"move value", `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			`[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			`{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`},
+		{"move array element", `{"foo": ["all", "grass", "cows", "eat"]}`,
+			`[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`,
+			`{"foo": ["all", "cows", "eat", "grass"]}`},
+		{"test value", `{"baz": "qux", "foo": ["a", 2, "c"]}`,
+			`[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}]`,
+			`{"baz": "qux", "foo": ["a", 2, "c"]}`},
+		{"test value error", `{"baz": "qux"}`,
+			`[{"op": "test", "path": "/baz", "value": "bar"}]`,
+			``},
+		{"add nested member", `{"foo": "bar"}`,
+			`[{"op": "add", "path": "/child", "value": {"grandchild": {}}}]`,
+			`{"foo": "bar", "child": {"grandchild": {}}}`},
+		{"ignore unknown members", `{"foo": "bar"}`,
+			`[{"op": "add", "path": "/baz", "value": "qux", "xyz": 123}]`,
+			`{"foo": "bar", "baz": "qux"}`},
+		{"add to nonexistent target", `{"foo": "bar"}`,
+			`[{"op": "add", "path": "/baz/bat", "value": "qux"}]`,
+			``},
+		{"escape ordering", `{"/": 9, "~1": 10}`,
+			`[{"op": "test", "path": "/~01", "value": 10}]`,
+			`{"/": 9, "~1": 10}`},
+		{"escaped slash", `{"a/b": 1}`,
+			`[{"op": "replace", "path": "/a~1b", "value": 2}]`,
+			`{"a/b": 2}`},
+		{"compare strings and numbers", `{"/": 9, "~1": 10}`,
+			`[{"op": "test", "path": "/~01", "value": "10"}]`,
+			``},
+		{"add array value", `{"foo": ["bar"]}`,
+			`[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`,
+			`{"foo": ["bar", ["abc", "def"]]}`},
+		{"compare numbers by value", `{"foo": 1}`,
+			`[{"op": "test", "path": "/foo", "value": 1.0}]`,
+			`{"foo": 1}`},
+		{"copy value", `{"foo": {"bar": 1}}`,
+			`[{"op": "copy", "from": "/foo", "path": "/baz"}, {"op": "replace", "path": "/baz/bar", "value": 2}]`,
+			`{"foo": {"bar": 1}, "baz": {"bar": 2}}`},
+		{"replace whole document", `{"foo": 1}`,
+			`[{"op": "replace", "path": "", "value": [1]}]`,
+			`[1]`},
+		{"failed operation applies nothing", `{"foo": 1}`,
+			`[{"op": "remove", "path": "/foo"}, {"op": "test", "path": "/foo", "value": 1}]`,
+			``},
+		{"move into own child", `{"foo": {"bar": 1}}`,
+			`[{"op": "move", "from": "/foo", "path": "/foo/bar/baz"}]`,
+			``},
+		{"index out of range", `{"foo": ["bar", "baz"]}`,
+			`[{"op": "add", "path": "/foo/3", "value": "qux"}]`,
+			``},
+		{"remove index out of range", `{"foo": ["bar"]}`,
+			`[{"op": "remove", "path": "/foo/1"}]`,
+			``},
+		{"remove appended index", `{"foo": ["bar"]}`,
+			`[{"op": "remove", "path": "/foo/-"}]`,
+			``},
+		{"index with leading zero", `{"foo": ["bar", "baz"]}`,
+			`[{"op": "replace", "path": "/foo/01", "value": "qux"}]`,
+			``},
+		{"negative index", `{"foo": ["bar"]}`,
+			`[{"op": "remove", "path": "/foo/-1"}]`,
+			``},
+		{"pointer without slash", `{"foo": 1}`,
+			`[{"op": "remove", "path": "foo"}]`,
+			``},
+		{"pointer into value", `{"foo": 1}`,
+			`[{"op": "add", "path": "/foo/bar", "value": 1}]`,
+			``},
+		{"missing value", `{"foo": 1}`,
+			`[{"op": "add", "path": "/bar"}]`,
+			``},
+		{"missing from", `{"foo": 1}`,
+			`[{"op": "copy", "path": "/bar"}]`,
+			``},
+		{"unknown operation", `{"foo": 1}`,
+			`[{"op": "rename", "path": "/foo"}]`,
+			``},
+		{"patch is not an array", `{"foo": 1}`,
+			`{"op": "remove", "path": "/foo"}`,
+			``},
+	}
+	for _, test := range tests {
+		result, err := Apply([]byte(test.doc), []byte(test.patch))
+		if len(test.result) == 0 {
+			if err == nil {
+				t.Errorf("%s: got %s, expected error", test.name, result)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if canonical(t, string(result)) != canonical(t, test.result) {
+			t.Errorf("%s: got %s, expected %s", test.name, result, test.result)
+		}
+	}
+}
+
+// Examples of RFC 7396 Appendix A.
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		doc    string
+		patch  string
+		result string
+	}{
+		{`{"a": "b"}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "b"}`, `{"b": "c"}`, `{"a": "b", "b": "c"}`},
+		{`{"a": "b"}`, `{"a": null}`, `{}`},
+		{`{"a": "b", "b": "c"}`, `{"a": null}`, `{"b": "c"}`},
+		{`{"a": ["b"]}`, `{"a": "c"}`, `{"a": "c"}`},
+		{`{"a": "c"}`, `{"a": ["b"]}`, `{"a": ["b"]}`},
+		{`{"a": {"b": "c"}}`, `{"a": {"b": "d", "c": null}}`, `{"a": {"b": "d"}}`},
+		{`{"a": [{"b": "c"}]}`, `{"a": [1]}`, `{"a": [1]}`},
+		{`["a", "b"]`, `["c", "d"]`, `["c", "d"]`},
+		{`{"a": "b"}`, `["c"]`, `["c"]`},
+		{`{"a": "foo"}`, `null`, `null`},
+		{`{"a": "foo"}`, `"bar"`, `"bar"`},
+		{`{"e": null}`, `{"a": 1}`, `{"e": null, "a": 1}`},
+		{`[1, 2]`, `{"a": "b", "c": null}`, `{"a": "b"}`},
+		{`{}`, `{"a": {"bb": {"ccc": null}}}`, `{"a": {"bb": {}}}`},
+	}
+	for _, test := range tests {
+		result, err := Merge([]byte(test.doc), []byte(test.patch))
+		if err != nil {
+			t.Errorf("Merge(%s, %s): %v", test.doc, test.patch, err)
+			continue
+		}
+		if canonical(t, string(result)) != canonical(t, test.result) {
+			t.Errorf("Merge(%s, %s) = %s, expected %s", test.doc, test.patch, result, test.result)
+		}
+	}
+
+	_, err := Merge([]byte(`{"a": 1}`), []byte(`{"a": `))
+	if err == nil {
+		t.Errorf("invalid merge patch is applied")
+	}
+}
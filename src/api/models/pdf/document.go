@@ -0,0 +1,186 @@
+// Package pdf is a minimal PDF 1.4 writer for printable documents.
+// It supports the standard Helvetica fonts with Windows-1252 encoding, lines,
+// JPEG, PNG and GIF images and internal links between pages.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+)
+
+// A4 page size in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Document is a PDF document built page by page.
+// Coordinates of all drawing operations start at the top left corner of the page.
+type Document struct {
+	Title  string
+	pages  []*Page
+	images []*Image
+}
+
+func New(title string) *Document {
+	return &Document{Title: title}
+}
+
+// AddPage appends a new empty page.
+func (d *Document) AddPage() *Page {
+	p := &Page{}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// InsertPage inserts a new empty page at the given zero based index.
+func (d *Document) InsertPage(index int) *Page {
+	if index > len(d.pages) {
+		index = len(d.pages)
+	}
+	p := &Page{}
+	d.pages = append(d.pages, nil)
+	copy(d.pages[index+1:], d.pages[index:])
+	d.pages[index] = p
+	return p
+}
+
+// Pages returns pages in the document order.
+func (d *Document) Pages() []*Page {
+	return d.pages
+}
+
+// Write writes the whole document.
+func (d *Document) Write(w io.Writer) (err error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	const (
+		catalogObj = iota + 1
+		pagesObj
+		regularFontObj
+		boldFontObj
+		infoObj
+		firstImageObj
+	)
+	firstPageObj := firstImageObj + len(d.images)
+	pageObj := make(map[*Page]int, len(d.pages))
+	for k, p := range d.pages {
+		pageObj[p] = firstPageObj + 2*k
+	}
+
+	buf := &bytes.Buffer{}
+	offsets := []int{}
+	begin := func() {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf, "%d 0 obj\n", len(offsets))
+	}
+	end := func() {
+		buf.WriteString("endobj\n")
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	begin()
+	fmt.Fprintf(buf, "<< /Type /Catalog /Pages %d 0 R >>\n", pagesObj)
+	end()
+
+	begin()
+	fmt.Fprintf(buf, "<< /Type /Pages /Count %d /Kids [", len(d.pages))
+	for _, p := range d.pages {
+		fmt.Fprintf(buf, " %d 0 R", pageObj[p])
+	}
+	buf.WriteString(" ] >>\n")
+	end()
+
+	for _, font := range []Font{Regular, Bold} {
+		begin()
+		fmt.Fprintf(buf, "<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>\n", fonts[font].name)
+		end()
+	}
+
+	begin()
+	fmt.Fprintf(buf, "<< /Title (%s) /Producer (Food API) >>\n", escape(encode(d.Title)))
+	end()
+
+	for _, img := range d.images {
+		begin()
+		dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s",
+			img.Width, img.Height, img.colorSpace, img.filter)
+		if img.colorSpace == cmykColorSpace {
+			// JPEG files written by Adobe store inverted CMYK values
+			dict += " /Decode [1 0 1 0 1 0 1 0]"
+		}
+		writeStream(buf, dict, img.data)
+		end()
+	}
+
+	for _, p := range d.pages {
+		begin()
+		fmt.Fprintf(buf, "<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.2f %.2f] /Contents %d 0 R", pagesObj, PageWidth, PageHeight, pageObj[p]+1)
+		fmt.Fprintf(buf, " /Resources << /Font << /F1 %d 0 R /F2 %d 0 R >>", regularFontObj, boldFontObj)
+		if len(p.images) > 0 {
+			buf.WriteString(" /XObject <<")
+			for _, img := range p.images {
+				fmt.Fprintf(buf, " /Im%d %d 0 R", img.index, firstImageObj+img.index)
+			}
+			buf.WriteString(" >>")
+		}
+		buf.WriteString(" >>")
+		if len(p.links) > 0 {
+			buf.WriteString(" /Annots [")
+			for _, l := range p.links {
+				target, ok := pageObj[l.target]
+				if !ok {
+					continue
+				}
+				fmt.Fprintf(buf, " << /Type /Annot /Subtype /Link /Border [0 0 0] /Rect [%.2f %.2f %.2f %.2f] /Dest [%d 0 R /XYZ null null null] >>",
+					l.x1, l.y1, l.x2, l.y2, target)
+			}
+			buf.WriteString(" ]")
+		}
+		buf.WriteString(" >>\n")
+		end()
+
+		begin()
+		content, err := compress(p.content.Bytes())
+		if err != nil {
+			return err
+		}
+		writeStream(buf, "/Filter /FlateDecode", content)
+		end()
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(buf, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, catalogObj, infoObj, xref)
+
+	_, err = buf.WriteTo(w)
+	return
+}
+
+func writeStream(buf *bytes.Buffer, dict string, data []byte) {
+	fmt.Fprintf(buf, "<< %s /Length %d >>\nstream\n", dict, len(data))
+	buf.Write(data)
+	buf.WriteString("\nendstream\n")
+}
+
+func compress(data []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	zw := zlib.NewWriter(buf)
+	_, err := zw.Write(data)
+	if err != nil {
+		return nil, err
+	}
+	err = zw.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
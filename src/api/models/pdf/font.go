@@ -0,0 +1,128 @@
+package pdf
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+)
+
+type fontMetrics struct {
+	name string
+	// glyph widths of printable ASCII characters in 1/1000 of the font size
+	widths [95]int
+}
+
+// defaultGlyphWidth is used for characters outside of ASCII
+const defaultGlyphWidth = 556
+
+var fonts = [...]fontMetrics{
+	Regular: {
+		name: "Helvetica",
+		widths: [95]int{
+			278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+			556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+			1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+			667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+			333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+			556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+		},
+	},
+	Bold: {
+		name: "Helvetica-Bold",
+		widths: [95]int{
+			278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+			556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+			975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+			667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+			333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+			611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+		},
+	},
+}
+
+// Width returns the width of the text line in points.
+func Width(font Font, size float64, text string) float64 {
+	total := 0
+	for _, b := range encode(text) {
+		if b >= ' ' && b <= '~' {
+			total += fonts[font].widths[b-' ']
+		} else {
+			total += defaultGlyphWidth
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// Wrap splits the text into lines which fit the width.
+// Words longer than the width are broken.
+func Wrap(font Font, size float64, text string, width float64) (lines []string) {
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if len(line) > 0 {
+				candidate = line + " " + word
+			}
+			if Width(font, size, candidate) <= width {
+				line = candidate
+				continue
+			}
+			if len(line) > 0 {
+				lines = append(lines, line)
+			}
+			for Width(font, size, word) > width && utf8.RuneCountInString(word) > 1 {
+				n, count := 1, utf8.RuneCountInString(word)
+				for n < count && Width(font, size, prefix(word, n+1)) <= width {
+					n++
+				}
+				head := prefix(word, n)
+				lines = append(lines, head)
+				word = word[len(head):]
+			}
+			line = word
+		}
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return
+}
+
+// prefix returns first n runes of the text.
+func prefix(text string, n int) string {
+	for i := range text {
+		if n == 0 {
+			return text[:i]
+		}
+		n--
+	}
+	return text
+}
+
+// encode converts the text to Windows-1252 used by the standard fonts,
+// characters which cannot be represented are replaced by `?`.
+func encode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		b, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+		encoded = append(encoded, b)
+	}
+	return encoded
+}
+
+// escape prepares encoded text to be placed into a literal string.
+func escape(text []byte) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", `\r`, "\n", `\n`)
+	return replacer.Replace(string(text))
+}
@@ -0,0 +1,105 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+const (
+	rgbColorSpace  = "DeviceRGB"
+	grayColorSpace = "DeviceGray"
+	cmykColorSpace = "DeviceCMYK"
+
+	// decoded images are downscaled to keep documents small, JPEG files are embedded as is
+	maxImageSide = 1200
+)
+
+type Image struct {
+	Width  int
+	Height int
+	index  int
+	// PDF color space and stream filter
+	colorSpace string
+	filter     string
+	data       []byte
+}
+
+// AddImage adds JPEG, PNG or GIF image to the document so it can be drawn on pages.
+func (d *Document) AddImage(data []byte) (img *Image, err error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+
+	if format == "jpeg" {
+		img = &Image{
+			Width:      config.Width,
+			Height:     config.Height,
+			colorSpace: rgbColorSpace,
+			filter:     "DCTDecode",
+			data:       data,
+		}
+		switch config.ColorModel {
+		case color.GrayModel:
+			img.colorSpace = grayColorSpace
+		case color.CMYKModel:
+			img.colorSpace = cmykColorSpace
+		}
+	} else {
+		img, err = newRasterImage(data)
+		if err != nil {
+			return
+		}
+	}
+
+	img.index = len(d.images)
+	d.images = append(d.images, img)
+	return
+}
+
+// newRasterImage decodes the image and stores its pixels as RGB placed on white background.
+func newRasterImage(data []byte) (img *Image, err error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+
+	bounds := src.Bounds()
+	if bounds.Empty() {
+		err = fmt.Errorf("image is empty")
+		return
+	}
+	step := 1
+	for bounds.Dx()/step > maxImageSide || bounds.Dy()/step > maxImageSide {
+		step++
+	}
+	width, height := bounds.Dx()/step, bounds.Dy()/step
+
+	pixels := make([]byte, 0, width*height*3)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.RGBAModel.Convert(src.At(bounds.Min.X+x*step, bounds.Min.Y+y*step)).(color.RGBA)
+			// colors are alpha premultiplied, so adding the transparent part gives white background
+			white := 255 - c.A
+			pixels = append(pixels, c.R+white, c.G+white, c.B+white)
+		}
+	}
+
+	compressed, err := compress(pixels)
+	if err != nil {
+		return
+	}
+	img = &Image{
+		Width:      width,
+		Height:     height,
+		colorSpace: rgbColorSpace,
+		filter:     "FlateDecode",
+		data:       compressed,
+	}
+	return
+}
@@ -0,0 +1,51 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type Page struct {
+	content bytes.Buffer
+	images  []*Image
+	links   []link
+}
+
+// link is a clickable area in PDF coordinates which opens the target page.
+type link struct {
+	x1, y1, x2, y2 float64
+	target         *Page
+}
+
+// Text draws a single line of text, y is the baseline.
+func (p *Page) Text(x, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(&p.content, "BT /F%d %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font+1, size, x, PageHeight-y, escape(encode(text)))
+}
+
+// Line draws a black line of the given width.
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%.2f w %.2f %.2f m %.2f %.2f l S\n", width, x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// Image draws the image scaled to the given box, y is the top edge.
+func (p *Page) Image(img *Image, x, y, width, height float64) {
+	used := false
+	for _, item := range p.images {
+		used = used || item == img
+	}
+	if !used {
+		p.images = append(p.images, img)
+	}
+	fmt.Fprintf(&p.content, "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", width, height, x, PageHeight-y-height, img.index)
+}
+
+// Link makes the box clickable, it opens the target page of the same document.
+func (p *Page) Link(x, y, width, height float64, target *Page) {
+	p.links = append(p.links, link{
+		x1:     x,
+		y1:     PageHeight - y - height,
+		x2:     x + width,
+		y2:     PageHeight - y,
+		target: target,
+	})
+}
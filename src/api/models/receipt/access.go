@@ -0,0 +1,46 @@
+package receipt
+
+import "time"
+
+type AccessRole string
+
+const (
+	ViewerRole AccessRole = "viewer"
+	EditorRole AccessRole = "editor"
+	OwnerRole  AccessRole = "owner"
+)
+
+var accessRoleLevels = map[AccessRole]int{
+	ViewerRole: 1,
+	EditorRole: 2,
+	OwnerRole:  3,
+}
+
+// IsGrantable reports whether the role can be given to another user.
+// Ownership cannot be granted, it always belongs to the receipt author.
+func (r AccessRole) IsGrantable() bool {
+	return r == ViewerRole || r == EditorRole
+}
+
+// Allows reports whether the role includes all permissions of the required one.
+func (r AccessRole) Allows(required AccessRole) bool {
+	level, ok := accessRoleLevels[r]
+	if !ok {
+		return false
+	}
+	return level >= accessRoleLevels[required]
+}
+
+type ReceiptAccess struct {
+	Id        uint       `json:"id" gorm:"primary_key"`
+	ReceiptId uint       `json:"receipt_id"`
+	UserId    uint       `json:"user_id"`
+	Role      AccessRole `json:"role" example:"viewer"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"-"`
+}
+
+func (ReceiptAccess) TableName() string {
+	return "receipt_accesses"
+}
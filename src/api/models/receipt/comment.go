@@ -0,0 +1,76 @@
+package receipt
+
+import "time"
+
+type ReceiptComment struct {
+	Id        uint  `json:"id" gorm:"primary_key"`
+	ReceiptId uint  `json:"receipt_id"`
+	UserId    uint  `json:"user_id"`
+	ParentId  *uint `json:"parent_id"`
+	// id of the top level comment of the thread
+	RootId    *uint      `json:"-"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"-"`
+	// deleted comments are kept as placeholders without body, so replies stay in place
+	Deleted bool              `json:"deleted" gorm:"-"`
+	Replies []*ReceiptComment `json:"replies" gorm:"-"`
+}
+
+func (ReceiptComment) TableName() string {
+	return "receipt_comments"
+}
+
+// IsDeleted reports whether the comment was soft deleted.
+func (c ReceiptComment) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+// BuildCommentThreads nests replies into their parents. Given roots keep their order,
+// replies are kept in the order of the given list. Deleted comments without replies are dropped,
+// deleted comments with replies lose their body.
+func BuildCommentThreads(roots []ReceiptComment, replies []ReceiptComment) (threads []*ReceiptComment) {
+	nodes := make(map[uint]*ReceiptComment, len(roots)+len(replies))
+	threads = make([]*ReceiptComment, 0, len(roots))
+	for i := range roots {
+		node := &roots[i]
+		node.Replies = []*ReceiptComment{}
+		nodes[node.Id] = node
+		threads = append(threads, node)
+	}
+	for i := range replies {
+		node := &replies[i]
+		node.Replies = []*ReceiptComment{}
+		nodes[node.Id] = node
+	}
+	for i := range replies {
+		node := &replies[i]
+		if node.ParentId == nil {
+			continue
+		}
+		parent, ok := nodes[*node.ParentId]
+		if !ok {
+			continue
+		}
+		parent.Replies = append(parent.Replies, node)
+	}
+
+	return pruneDeletedComments(threads)
+}
+
+func pruneDeletedComments(comments []*ReceiptComment) (kept []*ReceiptComment) {
+	kept = make([]*ReceiptComment, 0, len(comments))
+	for _, comment := range comments {
+		comment.Replies = pruneDeletedComments(comment.Replies)
+		if comment.IsDeleted() {
+			if len(comment.Replies) == 0 {
+				continue
+			}
+			comment.Deleted = true
+			comment.Body = ""
+		}
+		kept = append(kept, comment)
+	}
+	return
+}
@@ -0,0 +1,122 @@
+package receipt
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// DuplicateThreshold is the similarity from which receipts are reported as likely duplicates.
+const DuplicateThreshold = 0.75
+
+// weights of the similarity parts, parts missing in one of the receipts are left out
+const (
+	duplicateNameWeight       = 0.4
+	duplicateIngredientWeight = 0.4
+	duplicateDirectionWeight  = 0.2
+)
+
+var apostrophes = strings.NewReplacer("'", "", "’", "")
+
+// Duplicate is the receipt which is likely a duplicate of another one.
+type Duplicate struct {
+	ReceiptId uint   `json:"receipt_id"`
+	Name      string `json:"name"`
+	// similarity from 0 to 1
+	Score float64 `json:"score" example:"0.92"`
+}
+
+// DuplicateCluster is the group of receipts which are likely duplicates of each other.
+type DuplicateCluster struct {
+	// lowest similarity of the receipts linked in the cluster
+	Score    float64   `json:"score" example:"0.85"`
+	Receipts []Receipt `json:"receipts"`
+}
+
+// Fingerprint keeps the parts of the receipt compared to find duplicates.
+type Fingerprint struct {
+	ReceiptId   uint
+	Name        string
+	normalized  string
+	nameWords   map[string]bool
+	ingredients map[string]bool
+	directions  map[string]bool
+}
+
+// NewFingerprint prepares the receipt for comparison. Ingredients which are not saved yet
+// are compared by their name.
+func NewFingerprint(r FullReceipt) Fingerprint {
+	f := Fingerprint{
+		ReceiptId:   r.Id,
+		Name:        r.Name,
+		normalized:  NormalizeName(r.Name),
+		nameWords:   words(r.Name),
+		ingredients: map[string]bool{},
+		directions:  map[string]bool{},
+	}
+	for _, item := range r.Ingredients {
+		switch {
+		case item.IngredientId != 0:
+			f.ingredients[fmt.Sprintf("id:%d", item.IngredientId)] = true
+		case item.Ingredient != nil:
+			f.ingredients["name:"+NormalizeName(item.Ingredient.Name)] = true
+		}
+	}
+	for _, item := range r.Directions {
+		for word := range words(item.Description) {
+			f.directions[word] = true
+		}
+	}
+	return f
+}
+
+// NormalizeName lowercases the name and keeps its letters and digits only, words are
+// separated by single spaces.
+func NormalizeName(name string) string {
+	name = apostrophes.Replace(strings.ToLower(name))
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func words(text string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.Fields(NormalizeName(text)) {
+		set[word] = true
+	}
+	return set
+}
+
+// Similarity compares names, ingredient sets and direction texts of the receipts,
+// 1 means they are the same. Receipts without ingredients or directions are compared by the rest.
+func (f Fingerprint) Similarity(other Fingerprint) float64 {
+	nameScore := 1.0
+	if f.normalized != other.normalized {
+		nameScore = jaccard(f.nameWords, other.nameWords)
+	}
+	score := nameScore * duplicateNameWeight
+	weight := duplicateNameWeight
+	if len(f.ingredients) > 0 && len(other.ingredients) > 0 {
+		score += jaccard(f.ingredients, other.ingredients) * duplicateIngredientWeight
+		weight += duplicateIngredientWeight
+	}
+	if len(f.directions) > 0 && len(other.directions) > 0 {
+		score += jaccard(f.directions, other.directions) * duplicateDirectionWeight
+		weight += duplicateDirectionWeight
+	}
+	return score / weight
+}
+
+// jaccard returns the size of the intersection divided by the size of the union of the sets.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for key := range a {
+		if b[key] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
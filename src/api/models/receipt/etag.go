@@ -0,0 +1,58 @@
+package receipt
+
+import "food/src/api/models/tools"
+
+// ETag returns the version of the stored receipt. Fields of the current user, like favorited,
+// and the ones computed from other tables, like labels and ratings, do not change it.
+func (r Receipt) ETag() string {
+	return tools.ETag(r.stored())
+}
+
+// ETag returns the version of the stored receipt with all its rows.
+func (r FullReceipt) ETag() string {
+	rows := []interface{}{r.Receipt.stored()}
+	for _, i := range r.Ingredients {
+		rows = append(rows, i.stored())
+	}
+	for _, d := range r.Directions {
+		rows = append(rows, d.stored())
+	}
+	for _, m := range r.Images {
+		rows = append(rows, m.stored())
+	}
+	return tools.ETag(rows)
+}
+
+func (i ReceiptIngredient) ETag() string {
+	return tools.ETag(i.stored())
+}
+
+func (d ReceiptDirection) ETag() string {
+	return tools.ETag(d.stored())
+}
+
+// stored returns the receipt row without preloaded and computed fields.
+func (r Receipt) stored() interface{} {
+	r.Media = nil
+	r.CookingTime = 0
+	r.Favorited = false
+	r.Labels = nil
+	r.AverageRating = 0
+	r.RatingsCount = 0
+	return []interface{}{r, r.MediaId}
+}
+
+func (i ReceiptIngredient) stored() interface{} {
+	i.Ingredient = nil
+	return i
+}
+
+func (d ReceiptDirection) stored() interface{} {
+	d.Media = nil
+	return []interface{}{d, d.MediaId}
+}
+
+func (m ReceiptImage) stored() interface{} {
+	m.Media = nil
+	return []interface{}{m, m.MediaId}
+}
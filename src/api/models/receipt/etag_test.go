@@ -0,0 +1,64 @@
+package receipt
+
+import (
+	"testing"
+
+	"food/src/api/models/ingredient"
+	"food/src/api/models/media"
+)
+
+func TestReceiptETag(t *testing.T) {
+	mediaId := uint(3)
+	r := Receipt{Id: 1, Name: "Pancakes", Category: "breakfast", CookTime: 20, UserId: 2, MediaId: &mediaId}
+	etag := r.ETag()
+
+	marked := r
+	marked.Favorited = true
+	marked.Labels = []string{"vegetarian"}
+	marked.AverageRating = 4.5
+	marked.RatingsCount = 2
+	marked.Media = &media.Media{Id: mediaId, Link: "cover.png"}
+	if marked.ETag() != etag {
+		t.Errorf("fields which are not stored in the row change the version")
+	}
+
+	changed := r
+	changed.Name = "Crepes"
+	if changed.ETag() == etag {
+		t.Errorf("changed name keeps the version")
+	}
+	otherMedia := uint(4)
+	changed = r
+	changed.MediaId = &otherMedia
+	if changed.ETag() == etag {
+		t.Errorf("changed cover keeps the version")
+	}
+}
+
+func TestFullReceiptETag(t *testing.T) {
+	stepMedia := uint(5)
+	full := FullReceipt{
+		Receipt:     Receipt{Id: 1, Name: "Pancakes", CookTime: 20},
+		Ingredients: []ReceiptIngredient{{Id: 1, ReceiptId: 1, IngredientId: 7, Quantity: "200 g"}},
+		Directions:  []ReceiptDirection{{Id: 1, ReceiptId: 1, Description: "Fry", MediaId: &stepMedia}},
+	}
+	etag := full.ETag()
+
+	preloaded := full
+	preloaded.Ingredients = []ReceiptIngredient{full.Ingredients[0]}
+	preloaded.Ingredients[0].Ingredient = &ingredient.Ingredient{Id: 7, Name: "flour"}
+	preloaded.Directions = []ReceiptDirection{full.Directions[0]}
+	preloaded.Directions[0].Media = &media.Media{Id: stepMedia, Link: "step.png"}
+	preloaded.Favorited = true
+	if preloaded.ETag() != etag || preloaded.Ingredients[0].ETag() != full.Ingredients[0].ETag() ||
+		preloaded.Directions[0].ETag() != full.Directions[0].ETag() {
+		t.Errorf("preloaded rows change the version")
+	}
+
+	changed := full
+	changed.Directions = []ReceiptDirection{full.Directions[0]}
+	changed.Directions[0].MediaId = nil
+	if changed.ETag() == etag || changed.Directions[0].ETag() == full.Directions[0].ETag() {
+		t.Errorf("removed step media keeps the version")
+	}
+}
@@ -0,0 +1,14 @@
+package receipt
+
+import "time"
+
+type ReceiptFavorite struct {
+	Id        uint      `json:"-" gorm:"primary_key"`
+	UserId    uint      `json:"user_id"`
+	ReceiptId uint      `json:"receipt_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (ReceiptFavorite) TableName() string {
+	return "receipt_favorites"
+}
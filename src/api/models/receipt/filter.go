@@ -0,0 +1,110 @@
+package receipt
+
+import "github.com/jinzhu/gorm"
+
+const (
+	SortByRating        = "rating"
+	SortByRatingsCount  = "ratings_count"
+	SortByCreatedAt     = "created_at"
+	SortByTotalTime     = "total_time"
+	descSortOrderPrefix = "-"
+)
+
+var sortColumns = map[string]string{
+	SortByRating:       "receipts.average_rating",
+	SortByRatingsCount: "receipts.ratings_count",
+	SortByCreatedAt:    "receipts.created_at",
+	SortByTotalTime:    "receipts.total_time",
+}
+
+// ListFilter holds optional conditions of the receipts list.
+// Zero value of a field means that the condition is not applied.
+type ListFilter struct {
+	Category  string
+	MinRating float64
+	// easy, medium or hard
+	Difficulty string
+	Cuisine    string
+	Course     string
+	// maximal times in minutes
+	MaxPrepTime  uint
+	MaxCookTime  uint
+	MaxRestTime  uint
+	MaxTotalTime uint
+	// diet labels all receipts should have, like vegan or gluten-free
+	Diets []string
+	// allergens no ingredient should have, like nuts or dairy
+	ExcludeAllergens []string
+	// one of sort columns, prefixed by `-` for descending order
+	Sort string
+}
+
+// IsValidSort reports whether the given sort param is supported.
+func IsValidSort(sort string) bool {
+	if len(sort) == 0 {
+		return true
+	}
+	if sort[:1] == descSortOrderPrefix {
+		sort = sort[1:]
+	}
+	_, ok := sortColumns[sort]
+	return ok
+}
+
+func (f ListFilter) apply(query *gorm.DB) *gorm.DB {
+	if f.Category != "" {
+		query = query.Where("receipts.category = ?", f.Category)
+	}
+	if f.MinRating > 0 {
+		query = query.Where("receipts.average_rating >= ?", f.MinRating)
+	}
+	labels := []struct {
+		column string
+		value  string
+	}{
+		{"receipts.difficulty", f.Difficulty},
+		{"receipts.cuisine", f.Cuisine},
+		{"receipts.course", f.Course},
+	}
+	for _, label := range labels {
+		if label.value != "" {
+			query = query.Where(label.column+" = ?", label.value)
+		}
+	}
+	times := []struct {
+		column string
+		max    uint
+	}{
+		{"receipts.prep_time", f.MaxPrepTime},
+		{"receipts.cook_time", f.MaxCookTime},
+		{"receipts.rest_time", f.MaxRestTime},
+		{"receipts.total_time", f.MaxTotalTime},
+	}
+	for _, t := range times {
+		if t.max > 0 {
+			query = query.Where(t.column+" <= ?", t.max)
+		}
+	}
+	for _, diet := range f.Diets {
+		condition, ok := labelConditions[diet]
+		if !ok {
+			// unknown labels are not on any receipt
+			condition = "1 = 1"
+		}
+		// receipts without ingredients have no labels
+		query = query.Where(hasIngredients("1 = 1")).Where("NOT " + hasIngredients(condition))
+	}
+	for _, allergen := range f.ExcludeAllergens {
+		query = query.Where("NOT " + hasIngredients(allergenCondition(allergen)))
+	}
+
+	sort, order := f.Sort, "ASC"
+	if len(sort) > 0 && sort[:1] == descSortOrderPrefix {
+		sort, order = sort[1:], "DESC"
+	}
+	column, ok := sortColumns[sort]
+	if !ok {
+		return query.Order("receipts.id ASC")
+	}
+	return query.Order(column + " " + order).Order("receipts.id ASC")
+}
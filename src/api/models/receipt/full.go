@@ -0,0 +1,9 @@
+package receipt
+
+// FullReceipt is the receipt together with all its ingredients, directions and images.
+type FullReceipt struct {
+	Receipt
+	Ingredients []ReceiptIngredient `json:"ingredients"`
+	Directions  []ReceiptDirection  `json:"directions"`
+	Images      []ReceiptImage      `json:"images"`
+}
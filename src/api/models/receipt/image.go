@@ -0,0 +1,26 @@
+package receipt
+
+import (
+	"food/src/api/models/media"
+	"time"
+)
+
+// ReceiptImage is a photo of the receipt gallery.
+// Media of the cover image is also referenced by the receipt itself.
+type ReceiptImage struct {
+	Id        uint   `json:"id" gorm:"primary_key"`
+	ReceiptId uint   `json:"receipt_id"`
+	MediaId   uint   `json:"-"`
+	Caption   string `json:"caption"`
+	// zero based position in the gallery
+	Position  uint         `json:"position"`
+	Cover     bool         `json:"cover"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	DeletedAt *time.Time   `json:"-"`
+	Media     *media.Media `gorm:"foreignkey:MediaId" json:"media,omitempty"`
+}
+
+func (ReceiptImage) TableName() string {
+	return "receipt_images"
+}
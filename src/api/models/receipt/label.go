@@ -0,0 +1,46 @@
+package receipt
+
+import "food/src/api/models/ingredient"
+
+// diet labels of receipts, they are computed from flags of the receipt ingredients
+const (
+	LabelVegan      = "vegan"
+	LabelVegetarian = "vegetarian"
+	LabelGlutenFree = "gluten-free"
+	LabelNutFree    = "nut-free"
+)
+
+// Labels lists all diet labels in the order they are shown.
+var Labels = []string{LabelVegan, LabelVegetarian, LabelGlutenFree, LabelNutFree}
+
+// labelConditions match ingredients which the receipt with the label cannot have.
+var labelConditions = map[string]string{
+	LabelVegan: "ingredients.meat = 1 OR ingredients.fish = 1 OR ingredients.shellfish = 1 OR " +
+		"ingredients.dairy = 1 OR ingredients.eggs = 1 OR ingredients.animal_product = 1",
+	LabelVegetarian: "ingredients.meat = 1 OR ingredients.fish = 1 OR ingredients.shellfish = 1",
+	LabelGlutenFree: "ingredients.gluten = 1",
+	LabelNutFree:    "ingredients.nuts = 1 OR ingredients.peanuts = 1",
+}
+
+// IsValidLabel reports whether the diet label is known.
+func IsValidLabel(label string) bool {
+	_, ok := labelConditions[label]
+	return ok
+}
+
+// allergenCondition matches ingredients with the allergen, it should be validated by ingredient.IsValidAllergen.
+func allergenCondition(allergen string) string {
+	if !ingredient.IsValidAllergen(allergen) {
+		// unknown allergens are not in any ingredient
+		return "1 = 0"
+	}
+	// flag columns are named after allergens
+	return "ingredients." + allergen + " = 1"
+}
+
+// hasIngredients returns the condition of receipts having live ingredients which match the condition.
+func hasIngredients(condition string) string {
+	return "EXISTS (SELECT 1 FROM receipt_ingredients " +
+		"JOIN ingredients ON ingredients.id = receipt_ingredients.ingredient_id " +
+		"WHERE receipt_ingredients.receipt_id = receipts.id AND receipt_ingredients.deleted_at IS NULL AND (" + condition + "))"
+}
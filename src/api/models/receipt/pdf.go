@@ -0,0 +1,278 @@
+package receipt
+
+import (
+	"fmt"
+	"food/src/api/models/media"
+	"food/src/api/models/pdf"
+	"io"
+	"io/ioutil"
+	"log"
+	"path"
+	"strings"
+)
+
+const (
+	pdfMargin       = 50.0
+	pdfContentWidth = pdf.PageWidth - 2*pdfMargin
+	pdfPhotoHeight  = 260.0
+	pdfLineSpacing  = 1.35
+	// height of table of contents rows
+	pdfTocRowHeight = 20.0
+)
+
+// WritePDF renders printable A4 document of the receipts.
+// Every receipt starts on a new page, documents with several receipts start with a table of contents.
+func WritePDF(w io.Writer, title string, receipts []FullReceipt) (err error) {
+	l := &pdfLayout{doc: pdf.New(title)}
+
+	if len(receipts) == 0 {
+		l.newPage()
+		l.paragraph(pdf.Bold, 22, title, 0)
+		l.paragraph(pdf.Regular, 11, "There are no receipts yet.", 0)
+	}
+
+	starts := make([]*pdf.Page, 0, len(receipts))
+	for _, r := range receipts {
+		starts = append(starts, l.receipt(r))
+	}
+
+	if len(receipts) > 1 {
+		l.contents(title, receipts, starts)
+	}
+
+	pages := l.doc.Pages()
+	if len(pages) > 1 {
+		for k, page := range pages {
+			number := fmt.Sprintf("%d / %d", k+1, len(pages))
+			page.Text((pdf.PageWidth-pdf.Width(pdf.Regular, 9, number))/2, pdf.PageHeight-pdfMargin/2, pdf.Regular, 9, number)
+		}
+	}
+
+	err = l.doc.Write(w)
+	return
+}
+
+// pdfLayout places blocks of the document one after another and breaks pages.
+type pdfLayout struct {
+	doc  *pdf.Document
+	page *pdf.Page
+	// top of the free space on the current page
+	y float64
+}
+
+func (l *pdfLayout) newPage() {
+	l.page = l.doc.AddPage()
+	l.y = pdfMargin
+}
+
+// ensure starts a new page when the block of the given height does not fit the current one.
+func (l *pdfLayout) ensure(height float64) {
+	if l.y+height > pdf.PageHeight-pdfMargin {
+		l.newPage()
+	}
+}
+
+func (l *pdfLayout) space(height float64) {
+	l.y += height
+}
+
+func (l *pdfLayout) paragraph(font pdf.Font, size float64, text string, indent float64) {
+	l.item("", font, size, text, indent)
+}
+
+// item writes the wrapped text with the label in front of its first line.
+func (l *pdfLayout) item(label string, font pdf.Font, size float64, text string, indent float64) {
+	lineHeight := size * pdfLineSpacing
+	for k, line := range pdf.Wrap(font, size, text, pdfContentWidth-indent) {
+		l.ensure(lineHeight)
+		if k == 0 && len(label) > 0 {
+			l.page.Text(pdfMargin, l.y+size, pdf.Bold, size, label)
+		}
+		l.page.Text(pdfMargin+indent, l.y+size, font, size, line)
+		l.y += lineHeight
+	}
+}
+
+func (l *pdfLayout) heading(text string) {
+	// keep the heading together with the first line below it
+	l.ensure(14*pdfLineSpacing + 11*pdfLineSpacing + 8)
+	l.space(8)
+	l.paragraph(pdf.Bold, 14, text, 0)
+	l.page.Line(pdfMargin, l.y, pdfMargin+pdfContentWidth, l.y, 0.5)
+	l.space(6)
+}
+
+// receipt writes the receipt starting on a new page and returns that page.
+func (l *pdfLayout) receipt(r FullReceipt) *pdf.Page {
+	l.newPage()
+	start := l.page
+
+	l.paragraph(pdf.Bold, 22, r.Name, 0)
+	if details := receiptDetails(r); len(details) > 0 {
+		l.paragraph(pdf.Regular, 10, details, 0)
+	}
+	l.space(8)
+
+	if link := coverLink(r); len(link) > 0 {
+		l.photo(link)
+	}
+
+	if len(r.Description) > 0 {
+		l.paragraph(pdf.Regular, 11, r.Description, 0)
+	}
+
+	if len(r.Ingredients) > 0 {
+		l.heading("Ingredients")
+		for _, item := range r.Ingredients {
+			if item.Ingredient == nil {
+				continue
+			}
+			l.item("•", pdf.Regular, 11, strings.TrimSpace(item.Quantity+" "+item.Ingredient.Name), 14)
+		}
+	}
+
+	if len(r.Directions) > 0 {
+		l.heading("Directions")
+		for k, item := range r.Directions {
+			text := item.Description
+			if details := directionDetails(item); len(details) > 0 {
+				text += " (" + details + ")"
+			}
+			l.item(fmt.Sprintf("%d.", k+1), pdf.Regular, 11, text, 22)
+			l.space(4)
+		}
+	}
+	return start
+}
+
+// photo draws the image scaled to the content width, failures are only logged
+// because the receipt is still useful without its photo.
+func (l *pdfLayout) photo(link string) {
+	data, err := ioutil.ReadFile(path.Join(media.MediaFolderRoot, link))
+	if err != nil {
+		log.Printf("cannot read receipt photo `%s`: %s", link, err)
+		return
+	}
+	img, err := l.doc.AddImage(data)
+	if err != nil {
+		log.Printf("cannot add receipt photo `%s` to pdf: %s", link, err)
+		return
+	}
+
+	width := pdfContentWidth
+	height := width * float64(img.Height) / float64(img.Width)
+	if height > pdfPhotoHeight {
+		width *= pdfPhotoHeight / height
+		height = pdfPhotoHeight
+	}
+	l.ensure(height)
+	l.page.Image(img, pdfMargin+(pdfContentWidth-width)/2, l.y, width, height)
+	l.space(height + 12)
+}
+
+// contents inserts the table of contents in front of the receipts.
+func (l *pdfLayout) contents(title string, receipts []FullReceipt, starts []*pdf.Page) {
+	headerHeight := 70.0
+	perPage := int((pdf.PageHeight - 2*pdfMargin - headerHeight) / pdfTocRowHeight)
+	pageCount := (len(receipts) + perPage - 1) / perPage
+
+	// page numbers are known after all receipts are written
+	numbers := make(map[*pdf.Page]int, len(l.doc.Pages()))
+	for k, page := range l.doc.Pages() {
+		numbers[page] = k + 1 + pageCount
+	}
+
+	for k := 0; k < pageCount; k++ {
+		page := l.doc.InsertPage(k)
+		page.Text(pdfMargin, pdfMargin+22, pdf.Bold, 22, firstLine(pdf.Bold, 22, title, pdfContentWidth))
+		page.Text(pdfMargin, pdfMargin+50, pdf.Bold, 14, "Contents")
+
+		y := pdfMargin + headerHeight
+		for i := k * perPage; i < len(receipts) && i < (k+1)*perPage; i++ {
+			number := fmt.Sprint(numbers[starts[i]])
+			numberWidth := pdf.Width(pdf.Regular, 11, number)
+			page.Text(pdfMargin, y+11, pdf.Regular, 11, firstLine(pdf.Regular, 11, receipts[i].Name, pdfContentWidth-numberWidth-20))
+			page.Text(pdfMargin+pdfContentWidth-numberWidth, y+11, pdf.Regular, 11, number)
+			page.Link(pdfMargin, y, pdfContentWidth, pdfTocRowHeight, starts[i])
+			y += pdfTocRowHeight
+		}
+	}
+}
+
+// firstLine returns the text shortened to one line.
+func firstLine(font pdf.Font, size float64, text string, width float64) string {
+	lines := pdf.Wrap(font, size, text, width)
+	if len(lines) == 0 {
+		return ""
+	}
+	if len(lines) > 1 {
+		return pdf.Wrap(font, size, lines[0], width-pdf.Width(font, size, "..."))[0] + "..."
+	}
+	return lines[0]
+}
+
+func coverLink(r FullReceipt) string {
+	for _, image := range r.Images {
+		if image.Cover && image.Media != nil {
+			return image.Media.Link
+		}
+	}
+	if r.Media != nil {
+		return r.Media.Link
+	}
+	return ""
+}
+
+func receiptDetails(r FullReceipt) string {
+	details := []string{}
+	for _, label := range []string{r.Category, r.Cuisine, r.Course, r.Difficulty} {
+		if len(label) > 0 {
+			details = append(details, strings.Title(label))
+		}
+	}
+	times := []struct {
+		name    string
+		minutes uint
+	}{
+		{"Prep", r.PrepTime},
+		{"Cook", r.CookTime},
+		{"Rest", r.RestTime},
+		{"Total", r.TotalTime},
+	}
+	for _, t := range times {
+		if t.minutes > 0 {
+			details = append(details, t.name+": "+formatMinutes(t.minutes))
+		}
+	}
+	if r.ActiveTime > 0 {
+		details = append(details, "Active: "+formatMinutes(r.ActiveTime))
+	}
+	if r.PassiveTime > 0 {
+		details = append(details, "Passive: "+formatMinutes(r.PassiveTime))
+	}
+	if r.RatingsCount > 0 {
+		details = append(details, fmt.Sprintf("Rating: %.1f (%d)", r.AverageRating, r.RatingsCount))
+	}
+	return strings.Join(details, "  ·  ")
+}
+
+func directionDetails(d ReceiptDirection) string {
+	details := []string{}
+	if d.Duration != nil {
+		details = append(details, formatMinutes(*d.Duration))
+	}
+	if d.Temperature != nil {
+		details = append(details, fmt.Sprintf("%d °C", *d.Temperature))
+	}
+	return strings.Join(details, ", ")
+}
+
+func formatMinutes(minutes uint) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%d min", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%d h", minutes/60)
+	}
+	return fmt.Sprintf("%d h %d min", minutes/60, minutes%60)
+}
@@ -6,29 +6,93 @@ import (
 	"time"
 )
 
+// difficulty levels of receipts
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+var difficulties = map[string]bool{
+	DifficultyEasy:   true,
+	DifficultyMedium: true,
+	DifficultyHard:   true,
+}
+
+// IsValidDifficulty reports whether the difficulty level is known, empty one means it is not set.
+func IsValidDifficulty(difficulty string) bool {
+	return len(difficulty) == 0 || difficulties[difficulty]
+}
+
 type Receipt struct {
 	Id        uint      `json:"id" gorm:"primary_key"`
 	Name string `json:"name"`
 	Description string `json:"description"`
+	// language of the name, description and directions, translated ones when a translation is requested
+	Language string `json:"language" example:"uk"`
 	Category string `json:"category"`
-	CookingTime int `json:"cooking_time"`
+	// preparation time in minutes
+	PrepTime uint `json:"prep_time" example:"15"`
+	// cooking time in minutes
+	CookTime uint `json:"cook_time" example:"40"`
+	// resting time in minutes, like cooling or marinating
+	RestTime uint `json:"rest_time" example:"5"`
+	// sum of prep, cook and rest time in minutes (read only)
+	TotalTime uint `json:"total_time" example:"60"`
+	// deprecated, the total time kept for older clients (read only)
+	CookingTime uint `json:"cooking_time" gorm:"-" example:"60"`
+	// easy, medium or hard
+	Difficulty string `json:"difficulty" example:"easy"`
+	Cuisine string `json:"cuisine" example:"italian"`
+	// like breakfast, main or dessert
+	Course string `json:"course" example:"main"`
+	// number of servings the ingredient quantities are given for, 0 when unknown
+	Servings uint `json:"servings" example:"4"`
+	// private receipts are visible to the owner and users it is shared with only
+	Private bool `json:"private"`
+	// sum of active step durations in minutes (read only)
+	ActiveTime uint `json:"active_time"`
+	// sum of passive step durations in minutes (read only)
+	PassiveTime uint `json:"passive_time"`
+	// average of all review ratings (read only)
+	AverageRating float64 `json:"average_rating" example:"4.5"`
+	// number of review ratings (read only)
+	RatingsCount uint `json:"ratings_count"`
 	UserId uint `json:"user_id"`
 	MediaId *uint `json:"-"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	DeletedAt *time.Time `json:"-"`
 	Media *media.Media `gorm:"foreignkey:MediaId" json:"media,omitempty"`
+	// whether the receipt is in favorites of the current user (read only)
+	Favorited bool `json:"favorited" gorm:"-"`
+	// diet labels computed from the ingredients, like vegan or gluten-free (read only)
+	Labels []string `json:"labels,omitempty" gorm:"-" example:"vegan,nut-free"`
 }
 
 func (Receipt) TableName() string {
 	return "receipts"
 }
 
+// AfterFind fills the fields which are not stored.
+func (r *Receipt) AfterFind() (err error) {
+	r.CookingTime = r.TotalTime
+	return
+}
+
+// refreshTotalTime sums the prep, cook and rest time.
+func (r *Receipt) refreshTotalTime() {
+	r.TotalTime = r.PrepTime + r.CookTime + r.RestTime
+	r.CookingTime = r.TotalTime
+}
+
 type ReceiptIngredient struct {
 	Id        uint      `json:"id" gorm:"primary_key"`
 	Quantity string `json:"quantity"`
 	ReceiptId uint `json:"receipt_id"`
 	IngredientId uint `json:"ingredient_id"`
+	// zero based position in the receipt
+	Position uint `json:"position"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	DeletedAt *time.Time `json:"-"`
@@ -42,10 +106,20 @@ func (ReceiptIngredient) TableName() string {
 type ReceiptDirection struct {
 	Id        uint      `json:"id" gorm:"primary_key"`
 	ReceiptId uint `json:"receipt_id"`
+	// zero based step number
+	Position uint `json:"position"`
 	Description string `json:"description"`
+	// step duration in minutes
+	Duration *uint `json:"duration" example:"15"`
+	// waiting step which needs no attention, like baking or resting
+	Passive bool `json:"passive"`
+	// oven temperature in Celsius degrees
+	Temperature *int `json:"temperature" example:"180"`
+	MediaId *uint `json:"-"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	DeletedAt *time.Time `json:"-"`
+	Media *media.Media `gorm:"foreignkey:MediaId" json:"media,omitempty"`
 }
 
 func (ReceiptDirection) TableName() string {
@@ -2,6 +2,8 @@ package receipt
 
 import (
 	"fmt"
+	"food/src/api/models/media"
+	"time"
 
 	"github.com/jinzhu/gorm"
 )
@@ -28,6 +30,43 @@ func (r *ReceiptRepository) GetAllByCategory(category string) (receipts []Receip
 	return
 }
 
+// GetAllByUserId returns receipts owned by the user.
+func (r *ReceiptRepository) GetAllByUserId(userId uint) (receipts []Receipt, err error) {
+	if userId == 0 {
+		err = fmt.Errorf("user id cannot be empty")
+		return
+	}
+	err = r.db.Preload("Media").Where(&Receipt{UserId: userId}).Order("id ASC").Find(&receipts).Error
+	return
+}
+
+// visibleTo limits the query to public receipts and the private ones owned by the user or shared with the user.
+func (r *ReceiptRepository) visibleTo(query *gorm.DB, userId uint) *gorm.DB {
+	return query.Where("receipts.private = 0 OR receipts.user_id = ? OR receipts.id IN (?)", userId, r.db.Table(ReceiptAccess{}.TableName()).
+		Select("receipt_id").
+		Where("user_id = ? AND deleted_at IS NULL", userId).
+		QueryExpr())
+}
+
+// VisibleIdsQuery returns the subquery of ids of receipts out of trash which are visible to the user,
+// so models referencing receipts can skip the ones the user cannot see anymore.
+func (r *ReceiptRepository) VisibleIdsQuery(userId uint) interface{} {
+	return r.visibleTo(r.db.Table(Receipt{}.TableName()).Select("receipts.id"), userId).
+		Where("receipts.deleted_at IS NULL").
+		QueryExpr()
+}
+
+// GetAllVisible returns public receipts and the private ones owned by the user or shared with the user.
+func (r *ReceiptRepository) GetAllVisible(userId uint, filter ListFilter) (receipts []Receipt, err error) {
+	if userId == 0 {
+		err = fmt.Errorf("user id cannot be empty")
+		return
+	}
+	query := r.visibleTo(r.db.Preload("Media"), userId)
+	err = filter.apply(query).Find(&receipts).Error
+	return
+}
+
 func (r *ReceiptRepository) GetById(id uint) (receipt Receipt, err error) {
 	if id == 0 {
 		err = fmt.Errorf("receipt id cannot be empty")
@@ -39,8 +78,24 @@ func (r *ReceiptRepository) GetById(id uint) (receipt Receipt, err error) {
 	return
 }
 
+// CreateIngredient inserts the ingredient at its position, shifting following ingredients down.
 func (r *ReceiptRepository) CreateIngredient(receiptIngredient *ReceiptIngredient) (err error) {
-	err = r.db.Create(receiptIngredient).Error
+	tx := r.db.Begin()
+	err = tx.Model(&ReceiptIngredient{}).
+		Where("receipt_id = ? AND position >= ?", receiptIngredient.ReceiptId, receiptIngredient.Position).
+		UpdateColumn("position", gorm.Expr("position + 1")).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Create(receiptIngredient).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Commit().Error
 	return
 }
 
@@ -59,23 +114,100 @@ func (r *ReceiptRepository) GetIngredientById(id uint) (ingredient ReceiptIngred
 	return
 }
 
+// DeleteIngredientById deletes the ingredient and closes the gap in positions.
 func (r *ReceiptRepository) DeleteIngredientById(id uint) (err error) {
 	if id == 0 {
 		err = fmt.Errorf("id cannot be empty")
 		return
 	}
 
-	err = r.db.Model(ReceiptIngredient{}).Where(&ReceiptIngredient{Id: id}).Delete(&ReceiptIngredient{}).Error
+	var item ReceiptIngredient
+	err = r.db.Where(&ReceiptIngredient{Id: id}).First(&item).Error
+	if err != nil {
+		return
+	}
+
+	tx := r.db.Begin()
+	err = tx.Model(ReceiptIngredient{}).Where(&ReceiptIngredient{Id: id}).Delete(&ReceiptIngredient{}).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Model(&ReceiptIngredient{}).
+		Where("receipt_id = ? AND position > ?", item.ReceiptId, item.Position).
+		UpdateColumn("position", gorm.Expr("position - 1")).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Commit().Error
+	return
+}
+
+// ReorderIngredients sets positions of the receipt ingredients according to their order in the given list.
+func (r *ReceiptRepository) ReorderIngredients(receiptId uint, ids []uint) (err error) {
+	if receiptId == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	tx := r.db.Begin()
+	for position, id := range ids {
+		err = tx.Model(&ReceiptIngredient{}).
+			Where(&ReceiptIngredient{Id: id, ReceiptId: receiptId}).
+			UpdateColumn("position", position).Error
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	err = tx.Commit().Error
 	return
 }
 
+// CreateDirection inserts the step at its position, shifting following steps down.
 func (r *ReceiptRepository) CreateDirection(direction *ReceiptDirection) (err error) {
-	err = r.db.Create(direction).Error
+	tx := r.db.Begin()
+	err = tx.Model(&ReceiptDirection{}).
+		Where("receipt_id = ? AND position >= ?", direction.ReceiptId, direction.Position).
+		UpdateColumn("position", gorm.Expr("position + 1")).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Create(direction).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Commit().Error
 	return
 }
 
 func (r *ReceiptRepository) UpdateDirection(direction *ReceiptDirection) (err error) {
-	err = r.db.Model(&ReceiptDirection{}).Where(&ReceiptDirection{Id: direction.Id}).Update(direction).Error
+	// map is used to allow clearing of duration and temperature
+	err = r.db.Model(&ReceiptDirection{}).Where(&ReceiptDirection{Id: direction.Id}).
+		Updates(map[string]interface{}{
+			"description": direction.Description,
+			"duration":    direction.Duration,
+			"passive":     direction.Passive,
+			"temperature": direction.Temperature,
+		}).Error
+	return
+}
+
+func (r *ReceiptRepository) UpdateDirectionMedia(id uint, mediaId uint) (err error) {
+	if id == 0 {
+		err = fmt.Errorf("id cannot be empty")
+		return
+	}
+
+	err = r.db.Model(&ReceiptDirection{}).Where(&ReceiptDirection{Id: id}).UpdateColumn("media_id", mediaId).Error
 	return
 }
 
@@ -85,17 +217,61 @@ func (r *ReceiptRepository) GetDirectionById(id uint) (direction ReceiptDirectio
 		return
 	}
 
-	err = r.db.Model(ReceiptDirection{}).Where(&ReceiptDirection{Id: id}).First(&direction).Error
+	err = r.db.Model(ReceiptDirection{}).Preload("Media").Where(&ReceiptDirection{Id: id}).First(&direction).Error
 	return
 }
 
+// DeleteDirectionById deletes the step and closes the gap in positions.
 func (r *ReceiptRepository) DeleteDirectionById(id uint) (err error) {
 	if id == 0 {
 		err = fmt.Errorf("id cannot be empty")
 		return
 	}
 
-	err = r.db.Model(ReceiptDirection{}).Where(&ReceiptDirection{Id: id}).Delete(&ReceiptDirection{}).Error
+	var item ReceiptDirection
+	err = r.db.Where(&ReceiptDirection{Id: id}).First(&item).Error
+	if err != nil {
+		return
+	}
+
+	tx := r.db.Begin()
+	err = tx.Model(ReceiptDirection{}).Where(&ReceiptDirection{Id: id}).Delete(&ReceiptDirection{}).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Model(&ReceiptDirection{}).
+		Where("receipt_id = ? AND position > ?", item.ReceiptId, item.Position).
+		UpdateColumn("position", gorm.Expr("position - 1")).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Commit().Error
+	return
+}
+
+// ReorderDirections sets positions of the receipt steps according to their order in the given list.
+func (r *ReceiptRepository) ReorderDirections(receiptId uint, ids []uint) (err error) {
+	if receiptId == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	tx := r.db.Begin()
+	for position, id := range ids {
+		err = tx.Model(&ReceiptDirection{}).
+			Where(&ReceiptDirection{Id: id, ReceiptId: receiptId}).
+			UpdateColumn("position", position).Error
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	err = tx.Commit().Error
 	return
 }
 
@@ -105,7 +281,9 @@ func (r *ReceiptRepository) GetIngredientsById(id uint) (ingredients []ReceiptIn
 		return
 	}
 
-	err = r.db.Model(ReceiptIngredient{}).Preload("Ingredient").Where(&ReceiptIngredient{ReceiptId: id}).Find(&ingredients).Error
+	err = r.db.Model(ReceiptIngredient{}).Preload("Ingredient").Where(&ReceiptIngredient{ReceiptId: id}).
+		Order("position ASC").Order("id ASC").
+		Find(&ingredients).Error
 	return
 }
 
@@ -117,7 +295,9 @@ func (r *ReceiptRepository) GetDirectionsById(id uint) (directions []ReceiptDire
 		return
 	}
 
-	err = r.db.Model(ReceiptIngredient{}).Where(&ReceiptDirection{ReceiptId: id}).Find(&directions).Error
+	err = r.db.Model(ReceiptDirection{}).Preload("Media").Where(&ReceiptDirection{ReceiptId: id}).
+		Order("position ASC").Order("id ASC").
+		Find(&directions).Error
 	return
 }
 
@@ -130,6 +310,7 @@ func (r *ReceiptRepository) Create(receipt *Receipt) (err error) {
 		err = fmt.Errorf("receipt id should be empty")
 		return
 	}
+	receipt.refreshTotalTime()
 	err = r.db.Create(receipt).Error
 	return
 }
@@ -144,16 +325,1259 @@ func (r *ReceiptRepository) Update(receipt *Receipt) (err error) {
 		return
 	}
 
-	err = r.db.Model(Receipt{}).Where(&Receipt{Id: receipt.Id}).Update(receipt).Error
+	err = r.db.Model(Receipt{}).Where(&Receipt{Id: receipt.Id}).Updates(editableColumns(receipt)).Error
+	return
+}
+
+// editableColumns returns the columns of the receipt set by its owner, empty values included.
+// Rating and step time columns are maintained by RefreshRating and RefreshTimes only,
+// media is the cover of the gallery.
+func editableColumns(receipt *Receipt) map[string]interface{} {
+	receipt.refreshTotalTime()
+	return map[string]interface{}{
+		"name":        receipt.Name,
+		"description": receipt.Description,
+		"language":    receipt.Language,
+		"category":    receipt.Category,
+		"prep_time":   receipt.PrepTime,
+		"cook_time":   receipt.CookTime,
+		"rest_time":   receipt.RestTime,
+		"total_time":  receipt.TotalTime,
+		"difficulty":  receipt.Difficulty,
+		"cuisine":     receipt.Cuisine,
+		"course":      receipt.Course,
+		"servings":    receipt.Servings,
+		"private":     receipt.Private,
+	}
+}
+
+// RefreshRating recalculates the average rating and ratings count of the receipt from its reviews.
+func (r *ReceiptRepository) RefreshRating(id uint) (err error) {
+	if id == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	err = refreshRating(r.db, id)
+	return
+}
+
+func refreshRating(db *gorm.DB, id uint) error {
+	return db.Exec("UPDATE receipts SET "+
+		"average_rating = (SELECT COALESCE(AVG(rating), 0) FROM receipt_reviews WHERE receipt_id = ? AND deleted_at IS NULL), "+
+		"ratings_count = (SELECT COUNT(*) FROM receipt_reviews WHERE receipt_id = ? AND deleted_at IS NULL) "+
+		"WHERE id = ?", id, id, id).Error
+}
+
+// RefreshTimes recalculates active and passive time of the receipt from durations of its steps.
+func (r *ReceiptRepository) RefreshTimes(id uint) (err error) {
+	if id == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	err = refreshTimes(r.db, id)
 	return
 }
 
+func refreshTimes(db *gorm.DB, id uint) error {
+	return db.Exec("UPDATE receipts SET "+
+		"active_time = (SELECT COALESCE(SUM(duration), 0) FROM receipt_directions WHERE receipt_id = ? AND passive = 0 AND deleted_at IS NULL), "+
+		"passive_time = (SELECT COALESCE(SUM(duration), 0) FROM receipt_directions WHERE receipt_id = ? AND passive = 1 AND deleted_at IS NULL) "+
+		"WHERE id = ?", id, id, id).Error
+}
+
+// Delete moves the receipt to trash. Its ingredients, directions and images are deleted
+// at the same time, so Restore can tell them from ones deleted before.
 func (r *ReceiptRepository) Delete(id uint) (err error) {
 	if id == 0 {
-		err = fmt.Errorf("media id cannot be empty")
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	tx := r.db.Begin()
+	err = moveToTrash(tx, id)
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Commit().Error
+	return
+}
+
+func moveToTrash(tx *gorm.DB, id uint) (err error) {
+	// columns keep whole seconds only
+	deletedAt := time.Now().UTC().Truncate(time.Second)
+	for _, model := range []interface{}{&ReceiptIngredient{}, &ReceiptDirection{}, &ReceiptImage{}} {
+		err = tx.Model(model).Where("receipt_id = ?", id).UpdateColumn("deleted_at", deletedAt).Error
+		if err != nil {
+			return
+		}
+	}
+
+	err = tx.Model(&Receipt{}).Where(&Receipt{Id: id}).UpdateColumn("deleted_at", deletedAt).Error
+	return
+}
+
+// GetDeletedByUserId returns receipts of the user which are in trash, recently deleted first.
+func (r *ReceiptRepository) GetDeletedByUserId(userId uint) (receipts []Receipt, err error) {
+	if userId == 0 {
+		err = fmt.Errorf("user id cannot be empty")
+		return
+	}
+
+	err = r.db.Unscoped().Preload("Media").
+		Where("user_id = ? AND deleted_at IS NOT NULL", userId).
+		Order("deleted_at DESC").Order("id DESC").
+		Find(&receipts).Error
+	return
+}
+
+func (r *ReceiptRepository) GetDeletedById(id uint) (receipt Receipt, err error) {
+	if id == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	err = r.db.Unscoped().Preload("Media").
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&receipt).Error
+	return
+}
+
+// GetDeletedIdsBefore returns ids of receipts which were moved to trash before the given time.
+func (r *ReceiptRepository) GetDeletedIdsBefore(before time.Time) (ids []uint, err error) {
+	err = r.db.Unscoped().Model(&Receipt{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("id ASC").
+		Pluck("id", &ids).Error
+	return
+}
+
+// Restore takes the receipt out of trash together with the ingredients, directions and images
+// deleted with it. Items deleted separately before the receipt stay deleted.
+func (r *ReceiptRepository) Restore(id uint) (err error) {
+	item, err := r.GetDeletedById(id)
+	if err != nil {
+		return
+	}
+
+	tx := r.db.Begin()
+	for _, model := range []interface{}{&ReceiptIngredient{}, &ReceiptDirection{}, &ReceiptImage{}} {
+		err = tx.Unscoped().Model(model).
+			Where("receipt_id = ? AND deleted_at >= ?", id, *item.DeletedAt).
+			UpdateColumn("deleted_at", nil).Error
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	err = tx.Unscoped().Model(&Receipt{}).Where("id = ?", id).UpdateColumn("deleted_at", nil).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = refreshTimes(tx, id)
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Commit().Error
+	return
+}
+
+// Purge deletes the receipt permanently together with all rows referencing it and its media.
+// Links of the deleted media are returned, the files are left to the caller to remove once
+// the transaction is committed.
+func (r *ReceiptRepository) Purge(id uint) (links []string, err error) {
+	if id == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	tx := r.db.Begin()
+	links, err = purge(tx, id)
+	if err != nil {
+		tx.Rollback()
+		links = nil
+		return
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		links = nil
+	}
+	return
+}
+
+// purgeTables are all tables referencing receipts or their rows, in the order of deletion.
+// A table added later should be listed here, otherwise its rows keep purged receipts.
+var purgeTables = []string{
+	"receipt_direction_translations",
+	"receipt_translations",
+	"receipt_ingredients",
+	"receipt_directions",
+	"receipt_images",
+	"receipt_accesses",
+	"receipt_reviews",
+	"receipt_comments",
+	"receipt_favorites",
+	"cookbook_receipts",
+	"meal_plan_entries",
+}
+
+func purge(tx *gorm.DB, id uint) (links []string, err error) {
+	var mediaIds []uint
+	for _, query := range []string{
+		"SELECT media_id FROM receipts WHERE id = ? AND media_id IS NOT NULL",
+		"SELECT media_id FROM receipt_images WHERE receipt_id = ?",
+		"SELECT media_id FROM receipt_directions WHERE receipt_id = ? AND media_id IS NOT NULL",
+	} {
+		var ids []uint
+		err = tx.Raw(query, id).Pluck("media_id", &ids).Error
+		if err != nil {
+			return
+		}
+		mediaIds = append(mediaIds, ids...)
+	}
+
+	// replies reference their parents, links are dropped so comments can be deleted at once
+	err = tx.Exec("UPDATE receipt_comments SET parent_id = NULL, root_id = NULL WHERE receipt_id = ?", id).Error
+	if err != nil {
+		return
+	}
+
+	for _, table := range purgeTables {
+		err = tx.Exec("DELETE FROM "+table+" WHERE receipt_id = ?", id).Error
+		if err != nil {
+			return
+		}
+	}
+
+	err = tx.Unscoped().Where("id = ?", id).Delete(&Receipt{}).Error
+	if err != nil || len(mediaIds) == 0 {
+		return
+	}
+
+	// media still used by other receipts is kept
+	unused := tx.Model(&media.Media{}).Where("id IN (?)", mediaIds).
+		Where("id NOT IN (SELECT media_id FROM receipts WHERE media_id IS NOT NULL)").
+		Where("id NOT IN (SELECT media_id FROM receipt_images)").
+		Where("id NOT IN (SELECT media_id FROM receipt_directions WHERE media_id IS NOT NULL)")
+	var unusedIds []uint
+	err = unused.Pluck("id", &unusedIds).Error
+	if err != nil || len(unusedIds) == 0 {
+		return
+	}
+	err = tx.Model(&media.Media{}).Where("id IN (?)", unusedIds).Pluck("link", &links).Error
+	if err != nil {
+		return
+	}
+
+	err = tx.Where("id IN (?)", unusedIds).Delete(&media.Media{}).Error
+	return
+}
+
+func (r *ReceiptRepository) GetAccess(receiptId, userId uint) (access ReceiptAccess, err error) {
+	if receiptId == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+	if userId == 0 {
+		err = fmt.Errorf("user id cannot be empty")
+		return
+	}
+
+	err = r.db.Where(&ReceiptAccess{ReceiptId: receiptId, UserId: userId}).First(&access).Error
+	return
+}
+
+func (r *ReceiptRepository) GetAccessesById(id uint) (accesses []ReceiptAccess, err error) {
+	if id == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	err = r.db.Where(&ReceiptAccess{ReceiptId: id}).Find(&accesses).Error
+	return
+}
+
+func (r *ReceiptRepository) CreateAccess(access *ReceiptAccess) (err error) {
+	if access == nil {
+		err = fmt.Errorf("access cannot be empty")
+		return
+	}
+	if access.Id != 0 {
+		err = fmt.Errorf("access id should be empty")
+		return
+	}
+	err = r.db.Create(access).Error
+	return
+}
+
+func (r *ReceiptRepository) UpdateAccess(access *ReceiptAccess) (err error) {
+	if access == nil {
+		err = fmt.Errorf("access cannot be empty")
+		return
+	}
+	if access.Id == 0 {
+		err = fmt.Errorf("access id cannot be empty")
+		return
+	}
+
+	err = r.db.Model(&ReceiptAccess{}).Where(&ReceiptAccess{Id: access.Id}).Update(access).Error
+	return
+}
+
+// DeleteAccess removes the grant permanently, so the same user can be invited again later.
+func (r *ReceiptRepository) DeleteAccess(receiptId, userId uint) (err error) {
+	if receiptId == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+	if userId == 0 {
+		err = fmt.Errorf("user id cannot be empty")
+		return
+	}
+
+	err = r.db.Unscoped().Where(&ReceiptAccess{ReceiptId: receiptId, UserId: userId}).Delete(&ReceiptAccess{}).Error
+	return
+}
+
+func (r *ReceiptRepository) GetReviewsById(id uint) (reviews []ReceiptReview, err error) {
+	if id == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	err = r.db.Where(&ReceiptReview{ReceiptId: id}).Order("created_at DESC").Find(&reviews).Error
+	return
+}
+
+func (r *ReceiptRepository) GetReviewById(id uint) (review ReceiptReview, err error) {
+	if id == 0 {
+		err = fmt.Errorf("review id cannot be empty")
+		return
+	}
+
+	err = r.db.Where(&ReceiptReview{Id: id}).First(&review).Error
+	return
+}
+
+func (r *ReceiptRepository) GetReviewByUser(receiptId, userId uint) (review ReceiptReview, err error) {
+	if receiptId == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+	if userId == 0 {
+		err = fmt.Errorf("user id cannot be empty")
+		return
+	}
+
+	err = r.db.Where(&ReceiptReview{ReceiptId: receiptId, UserId: userId}).First(&review).Error
+	return
+}
+
+func (r *ReceiptRepository) CreateReview(review *ReceiptReview) (err error) {
+	if review == nil {
+		err = fmt.Errorf("review cannot be empty")
+		return
+	}
+	if review.Id != 0 {
+		err = fmt.Errorf("review id should be empty")
+		return
+	}
+	err = r.db.Create(review).Error
+	return
+}
+
+func (r *ReceiptRepository) UpdateReview(review *ReceiptReview) (err error) {
+	if review == nil {
+		err = fmt.Errorf("review cannot be empty")
+		return
+	}
+	if review.Id == 0 {
+		err = fmt.Errorf("review id cannot be empty")
+		return
+	}
+
+	// review text can be cleared, so it is saved even when empty
+	err = r.db.Model(&ReceiptReview{}).Where(&ReceiptReview{Id: review.Id}).
+		Updates(map[string]interface{}{"rating": review.Rating, "review": review.Review}).Error
+	return
+}
+
+// DeleteReviewById removes the review permanently, so the user can rate the receipt again later.
+func (r *ReceiptRepository) DeleteReviewById(id uint) (err error) {
+	if id == 0 {
+		err = fmt.Errorf("id cannot be empty")
+		return
+	}
+
+	err = r.db.Unscoped().Where(&ReceiptReview{Id: id}).Delete(&ReceiptReview{}).Error
+	return
+}
+
+// rootCommentsQuery selects top level comments of the receipt. Deleted ones are kept
+// while the thread still has replies.
+func (r *ReceiptRepository) rootCommentsQuery(receiptId uint) *gorm.DB {
+	return r.db.Unscoped().Model(&ReceiptComment{}).
+		Where("receipt_comments.receipt_id = ? AND receipt_comments.parent_id IS NULL", receiptId).
+		Where("receipt_comments.deleted_at IS NULL OR EXISTS (?)", r.db.Table("receipt_comments AS replies").
+			Select("1").
+			Where("replies.root_id = receipt_comments.id AND replies.deleted_at IS NULL").
+			QueryExpr())
+}
+
+func (r *ReceiptRepository) CountRootComments(receiptId uint) (count int, err error) {
+	if receiptId == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	err = r.rootCommentsQuery(receiptId).Count(&count).Error
+	return
+}
+
+// GetRootComments returns a page of top level comments of the receipt, oldest first.
+func (r *ReceiptRepository) GetRootComments(receiptId uint, offset, limit int) (comments []ReceiptComment, err error) {
+	if receiptId == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	err = r.rootCommentsQuery(receiptId).
+		Order("receipt_comments.created_at ASC").Order("receipt_comments.id ASC").
+		Offset(offset).Limit(limit).
+		Find(&comments).Error
+	return
+}
+
+// GetCommentReplies returns all replies, including deleted ones, of the given threads.
+func (r *ReceiptRepository) GetCommentReplies(rootIds []uint) (comments []ReceiptComment, err error) {
+	if len(rootIds) == 0 {
+		return
+	}
+
+	err = r.db.Unscoped().Where("root_id IN (?)", rootIds).
+		Order("created_at ASC").Order("id ASC").
+		Find(&comments).Error
+	return
+}
+
+func (r *ReceiptRepository) GetCommentById(id uint) (comment ReceiptComment, err error) {
+	if id == 0 {
+		err = fmt.Errorf("comment id cannot be empty")
+		return
+	}
+
+	err = r.db.Where(&ReceiptComment{Id: id}).First(&comment).Error
+	return
+}
+
+func (r *ReceiptRepository) CreateComment(comment *ReceiptComment) (err error) {
+	if comment == nil {
+		err = fmt.Errorf("comment cannot be empty")
+		return
+	}
+	if comment.Id != 0 {
+		err = fmt.Errorf("comment id should be empty")
+		return
+	}
+	err = r.db.Create(comment).Error
+	return
+}
+
+func (r *ReceiptRepository) UpdateComment(comment *ReceiptComment) (err error) {
+	if comment == nil {
+		err = fmt.Errorf("comment cannot be empty")
+		return
+	}
+	if comment.Id == 0 {
+		err = fmt.Errorf("comment id cannot be empty")
+		return
+	}
+
+	err = r.db.Model(&ReceiptComment{}).Where(&ReceiptComment{Id: comment.Id}).Update(comment).Error
+	return
+}
+
+func (r *ReceiptRepository) DeleteCommentById(id uint) (err error) {
+	if id == 0 {
+		err = fmt.Errorf("id cannot be empty")
+		return
+	}
+
+	err = r.db.Model(ReceiptComment{}).Where(&ReceiptComment{Id: id}).Delete(&ReceiptComment{}).Error
+	return
+}
+
+func (r *ReceiptRepository) AddFavorite(favorite *ReceiptFavorite) (err error) {
+	if favorite == nil {
+		err = fmt.Errorf("favorite cannot be empty")
+		return
+	}
+	if favorite.UserId == 0 || favorite.ReceiptId == 0 {
+		err = fmt.Errorf("user id and receipt id cannot be empty")
+		return
+	}
+
+	err = r.db.Where(&ReceiptFavorite{UserId: favorite.UserId, ReceiptId: favorite.ReceiptId}).
+		FirstOrCreate(favorite).Error
+	return
+}
+
+func (r *ReceiptRepository) RemoveFavorite(userId, receiptId uint) (err error) {
+	if userId == 0 || receiptId == 0 {
+		err = fmt.Errorf("user id and receipt id cannot be empty")
+		return
+	}
+
+	err = r.db.Where(&ReceiptFavorite{UserId: userId, ReceiptId: receiptId}).Delete(&ReceiptFavorite{}).Error
+	return
+}
+
+// GetFavoriteIds returns ids of the given receipts which are favorited by the user.
+func (r *ReceiptRepository) GetFavoriteIds(userId uint, receiptIds []uint) (ids []uint, err error) {
+	if len(receiptIds) == 0 {
+		return
+	}
+
+	err = r.db.Model(&ReceiptFavorite{}).
+		Where("user_id = ? AND receipt_id IN (?)", userId, receiptIds).
+		Pluck("receipt_id", &ids).Error
+	return
+}
+
+// GetFavorites returns receipts favorited by the user, which are still visible to the user.
+func (r *ReceiptRepository) GetFavorites(userId uint) (receipts []Receipt, err error) {
+	if userId == 0 {
+		err = fmt.Errorf("user id cannot be empty")
+		return
+	}
+
+	err = r.visibleTo(r.db.Preload("Media"), userId).
+		Joins("INNER JOIN receipt_favorites ON receipt_favorites.receipt_id = receipts.id").
+		Where("receipt_favorites.user_id = ?", userId).
+		Order("receipt_favorites.created_at DESC").
+		Find(&receipts).Error
+	return
+}
+
+// GetVisibleIds returns ids of the given receipts which are visible to the user.
+func (r *ReceiptRepository) GetVisibleIds(userId uint, receiptIds []uint) (ids []uint, err error) {
+	if len(receiptIds) == 0 {
+		return
+	}
+
+	err = r.visibleTo(r.db.Model(&Receipt{}), userId).
+		Where("receipts.id IN (?)", receiptIds).
+		Pluck("receipts.id", &ids).Error
+	return
+}
+
+// GetByIds returns the receipts with given ids, missing ones are skipped.
+func (r *ReceiptRepository) GetByIds(ids []uint) (receipts []Receipt, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	err = r.db.Preload("Media").Where("id IN (?)", ids).Order("id ASC").Find(&receipts).Error
+	return
+}
+
+// GetIngredientCounts returns the number of receipts and the number of receipts using every ingredient.
+func (r *ReceiptRepository) GetIngredientCounts() (total int, counts map[uint]int, err error) {
+	err = r.db.Model(&Receipt{}).Count(&total).Error
+	if err != nil {
+		return
+	}
+
+	var rows []struct {
+		IngredientId uint
+		Receipts     int
+	}
+	err = r.db.Raw("SELECT receipt_ingredients.ingredient_id, COUNT(DISTINCT receipt_ingredients.receipt_id) AS receipts " +
+		"FROM receipt_ingredients JOIN receipts ON receipts.id = receipt_ingredients.receipt_id AND receipts.deleted_at IS NULL " +
+		"WHERE receipt_ingredients.deleted_at IS NULL GROUP BY receipt_ingredients.ingredient_id").
+		Scan(&rows).Error
+	if err != nil {
+		return
+	}
+
+	counts = make(map[uint]int, len(rows))
+	for _, row := range rows {
+		counts[row.IngredientId] = row.Receipts
+	}
+	return
+}
+
+// versionColumns are the columns similar receipts are ranked by.
+var versionColumns = []struct {
+	table   string
+	columns string
+}{
+	{"receipts", "id, user_id, COALESCE(category, ''), cuisine, course, total_time, private, deleted_at IS NULL"},
+	{"receipt_ingredients", "id, receipt_id, ingredient_id, deleted_at IS NULL"},
+	{"receipt_accesses", "id, receipt_id, user_id, deleted_at IS NULL"},
+	// diet labels are computed from the ingredient flags
+	{"ingredients", "id, gluten, dairy, eggs, nuts, peanuts, fish, shellfish, meat, animal_product, deleted_at IS NULL"},
+}
+
+// GetVersion returns the version of receipts, their ingredients and accesses. It is a checksum
+// of their content, so it changes with any update, also the ones which keep updated_at.
+func (r *ReceiptRepository) GetVersion() (version string, err error) {
+	for _, v := range versionColumns {
+		var row struct{ Version string }
+		err = r.db.Raw("SELECT CONCAT_WS('/', COUNT(*), COALESCE(BIT_XOR(CRC32(CONCAT_WS(',', " + v.columns + "))), 0)) AS version " +
+			"FROM " + v.table).
+			Scan(&row).Error
+		if err != nil {
+			return
+		}
+		version += row.Version + ";"
+	}
+	return
+}
+
+// GetIngredientsByIds returns ingredients of all given receipts.
+func (r *ReceiptRepository) GetIngredientsByIds(ids []uint) (ingredients []ReceiptIngredient, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	err = r.db.Model(ReceiptIngredient{}).Preload("Ingredient").Where("receipt_id IN (?)", ids).
+		Order("receipt_id ASC").Order("position ASC").Order("id ASC").
+		Find(&ingredients).Error
+	return
+}
+
+// GetLabels returns diet labels of the given receipts, receipts without ingredients have none.
+func (r *ReceiptRepository) GetLabels(ids []uint) (labels map[uint][]string, err error) {
+	labels = map[uint][]string{}
+	if len(ids) == 0 {
+		return
+	}
+
+	// the number of ingredients breaking every label
+	columns := "receipt_ingredients.receipt_id"
+	for _, label := range Labels {
+		columns += ", COALESCE(SUM(" + labelConditions[label] + "), 0)"
+	}
+	rows, err := r.db.Raw("SELECT "+columns+" FROM receipt_ingredients "+
+		"JOIN ingredients ON ingredients.id = receipt_ingredients.ingredient_id "+
+		"WHERE receipt_ingredients.receipt_id IN (?) AND receipt_ingredients.deleted_at IS NULL "+
+		"GROUP BY receipt_ingredients.receipt_id", ids).
+		Rows()
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var receiptId uint
+		breaking := make([]int, len(Labels))
+		dest := []interface{}{&receiptId}
+		for k := range breaking {
+			dest = append(dest, &breaking[k])
+		}
+		err = rows.Scan(dest...)
+		if err != nil {
+			return
+		}
+		for k, label := range Labels {
+			if breaking[k] == 0 {
+				labels[receiptId] = append(labels[receiptId], label)
+			}
+		}
+	}
+	err = rows.Err()
+	return
+}
+
+// GetDirectionsByIds returns directions of all given receipts.
+func (r *ReceiptRepository) GetDirectionsByIds(ids []uint) (directions []ReceiptDirection, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	err = r.db.Model(ReceiptDirection{}).Where("receipt_id IN (?)", ids).
+		Order("receipt_id ASC").Order("position ASC").Order("id ASC").
+		Find(&directions).Error
+	return
+}
+
+// Merge moves favorites, cookbook entries, meal plan entries, reviews, comments and images
+// of the duplicates to the receipt and moves the duplicates to trash. Favorites, cookbook
+// entries and reviews of users which already have them on the receipt stay with the duplicates.
+func (r *ReceiptRepository) Merge(id uint, duplicateIds []uint) (err error) {
+	if id == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	tx := r.db.Begin()
+	for _, duplicateId := range duplicateIds {
+		err = merge(tx, id, duplicateId)
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	err = refreshRating(tx, id)
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Commit().Error
+	return
+}
+
+func merge(tx *gorm.DB, id, duplicateId uint) (err error) {
+	// rows unique per receipt are moved unless the receipt has them already,
+	// derived tables let MySQL read the updated table
+	unique := map[string]string{
+		"receipt_favorites": "user_id",
+		"cookbook_receipts": "cookbook_id",
+		"receipt_reviews":   "user_id",
+	}
+	for table, column := range unique {
+		err = tx.Exec("UPDATE "+table+" SET receipt_id = ? WHERE receipt_id = ? AND "+column+" NOT IN "+
+			"(SELECT "+column+" FROM (SELECT "+column+" FROM "+table+" WHERE receipt_id = ?) AS existing)",
+			id, duplicateId, id).Error
+		if err != nil {
+			return
+		}
+	}
+
+	for _, table := range []string{"meal_plan_entries", "receipt_comments"} {
+		err = tx.Exec("UPDATE "+table+" SET receipt_id = ? WHERE receipt_id = ?", id, duplicateId).Error
+		if err != nil {
+			return
+		}
+	}
+
+	// images follow the gallery of the receipt, it keeps its cover
+	var last struct{ Position *uint }
+	err = tx.Raw("SELECT MAX(position) AS position FROM receipt_images WHERE receipt_id = ? AND deleted_at IS NULL", id).
+		Scan(&last).Error
+	if err != nil {
+		return
+	}
+	offset := uint(0)
+	if last.Position != nil {
+		offset = *last.Position + 1
+	}
+	err = tx.Model(&ReceiptImage{}).Where("receipt_id = ?", duplicateId).
+		UpdateColumns(map[string]interface{}{
+			"receipt_id": id,
+			"cover":      false,
+			"position":   gorm.Expr("position + ?", offset),
+		}).Error
+	if err != nil {
+		return
+	}
+
+	return moveToTrash(tx, duplicateId)
+}
+
+// SaveFull creates the receipt or replaces the existing one together with all its ingredients
+// and directions in one transaction. Existing ingredients and directions are updated in place,
+// see matchRows. Ingredients without ingredient id are created from their Ingredient field.
+func (r *ReceiptRepository) SaveFull(receipt *Receipt, ingredients []ReceiptIngredient, directions []ReceiptDirection) (err error) {
+	if receipt == nil {
+		err = fmt.Errorf("receipt cannot be empty")
+		return
+	}
+
+	tx := r.db.Begin()
+	err = saveFull(tx, receipt, ingredients, directions)
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Commit().Error
+	return
+}
+
+// SaveFullBatch creates all the receipts in one transaction, nothing is saved when one of them fails.
+func (r *ReceiptRepository) SaveFullBatch(receipts []FullReceipt) (err error) {
+	tx := r.db.Begin()
+	for i := range receipts {
+		item := &receipts[i]
+		if item.Id != 0 {
+			tx.Rollback()
+			err = fmt.Errorf("receipt id should be empty")
+			return
+		}
+		err = saveFull(tx, &item.Receipt, item.Ingredients, item.Directions)
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	err = tx.Commit().Error
+	return
+}
+
+func saveFull(tx *gorm.DB, receipt *Receipt, ingredients []ReceiptIngredient, directions []ReceiptDirection) (err error) {
+	if receipt.Id == 0 {
+		receipt.refreshTotalTime()
+		err = tx.Create(receipt).Error
+		if err != nil {
+			return
+		}
+		// nothing to update in the new receipt
+		for i := range ingredients {
+			ingredients[i].Id = 0
+		}
+		for i := range directions {
+			directions[i].Id = 0
+		}
+	} else {
+		err = tx.Model(Receipt{}).Where(&Receipt{Id: receipt.Id}).Updates(editableColumns(receipt)).Error
+		if err != nil {
+			return
+		}
+	}
+
+	err = saveFullIngredients(tx, receipt.Id, ingredients)
+	if err != nil {
+		return
+	}
+	err = saveFullDirections(tx, receipt.Id, directions)
+	if err != nil {
+		return
+	}
+
+	err = refreshTimes(tx, receipt.Id)
+	return
+}
+
+// matchRows pairs the items with the existing rows by id. When no item has id, like ones of
+// clients which do not know ids, the items are paired with the rows at the same positions.
+// It returns the index of the matched row of every item, -1 for new items, and which rows are matched.
+func matchRows(itemIds, rowIds []uint) (matches []int, matched []bool) {
+	byPosition := true
+	for _, id := range itemIds {
+		byPosition = byPosition && id == 0
+	}
+
+	matched = make([]bool, len(rowIds))
+	matches = make([]int, len(itemIds))
+	for i, id := range itemIds {
+		matches[i] = -1
+		for k, rowId := range rowIds {
+			if matched[k] {
+				continue
+			}
+			if (id != 0 && id == rowId) || (byPosition && k == i) {
+				matches[i] = k
+				matched[k] = true
+				break
+			}
+		}
+	}
+	return
+}
+
+// saveFullIngredients updates matched ingredients of the receipt in place, creates new ones
+// and deletes the rest. Ingredients without ingredient id are created from their Ingredient field.
+func saveFullIngredients(tx *gorm.DB, receiptId uint, ingredients []ReceiptIngredient) (err error) {
+	var rows []ReceiptIngredient
+	err = tx.Where(&ReceiptIngredient{ReceiptId: receiptId}).Order("position ASC").Order("id ASC").Find(&rows).Error
+	if err != nil {
+		return
+	}
+
+	itemIds := make([]uint, 0, len(ingredients))
+	for _, item := range ingredients {
+		itemIds = append(itemIds, item.Id)
+	}
+	rowIds := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		rowIds = append(rowIds, row.Id)
+	}
+	matches, matched := matchRows(itemIds, rowIds)
+
+	for k, row := range rows {
+		if !matched[k] {
+			err = tx.Where(&ReceiptIngredient{Id: row.Id}).Delete(&ReceiptIngredient{}).Error
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	for i := range ingredients {
+		item := &ingredients[i]
+		if item.IngredientId == 0 {
+			if item.Ingredient == nil {
+				err = fmt.Errorf("ingredient cannot be empty")
+				return
+			}
+			// the same new ingredient can be shared by several items
+			if item.Ingredient.Id == 0 {
+				err = tx.Create(item.Ingredient).Error
+				if err != nil {
+					return
+				}
+			}
+			item.IngredientId = item.Ingredient.Id
+		}
+		item.ReceiptId = receiptId
+		item.Position = uint(i)
+
+		if matches[i] < 0 {
+			item.Id = 0
+			err = tx.Omit("Ingredient").Create(item).Error
+			if err != nil {
+				return
+			}
+			continue
+		}
+
+		row := rows[matches[i]]
+		item.Id = row.Id
+		if row.Quantity == item.Quantity && row.IngredientId == item.IngredientId && row.Position == item.Position {
+			continue
+		}
+		err = tx.Model(&ReceiptIngredient{}).Where(&ReceiptIngredient{Id: row.Id}).
+			Updates(map[string]interface{}{
+				"quantity":      item.Quantity,
+				"ingredient_id": item.IngredientId,
+				"position":      item.Position,
+			}).Error
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// saveFullDirections updates matched steps of the receipt in place keeping their media,
+// creates new ones and deletes the rest.
+func saveFullDirections(tx *gorm.DB, receiptId uint, directions []ReceiptDirection) (err error) {
+	var rows []ReceiptDirection
+	err = tx.Where(&ReceiptDirection{ReceiptId: receiptId}).Order("position ASC").Order("id ASC").Find(&rows).Error
+	if err != nil {
+		return
+	}
+
+	itemIds := make([]uint, 0, len(directions))
+	for _, item := range directions {
+		itemIds = append(itemIds, item.Id)
+	}
+	rowIds := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		rowIds = append(rowIds, row.Id)
+	}
+	matches, matched := matchRows(itemIds, rowIds)
+
+	for k, row := range rows {
+		if !matched[k] {
+			err = tx.Where(&ReceiptDirection{Id: row.Id}).Delete(&ReceiptDirection{}).Error
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	for i := range directions {
+		item := &directions[i]
+		item.ReceiptId = receiptId
+		item.Position = uint(i)
+
+		if matches[i] < 0 {
+			item.Id = 0
+			err = tx.Create(item).Error
+			if err != nil {
+				return
+			}
+			continue
+		}
+
+		row := rows[matches[i]]
+		item.Id = row.Id
+		item.MediaId = row.MediaId
+		if row.Description == item.Description && equalUint(row.Duration, item.Duration) && row.Passive == item.Passive &&
+			equalInt(row.Temperature, item.Temperature) && row.Position == item.Position {
+			continue
+		}
+		// map is used to allow clearing of duration and temperature
+		err = tx.Model(&ReceiptDirection{}).Where(&ReceiptDirection{Id: row.Id}).
+			Updates(map[string]interface{}{
+				"description": item.Description,
+				"duration":    item.Duration,
+				"passive":     item.Passive,
+				"temperature": item.Temperature,
+				"position":    item.Position,
+			}).Error
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func equalUint(a, b *uint) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+func equalInt(a, b *int) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+func (r *ReceiptRepository) GetImagesById(id uint) (images []ReceiptImage, err error) {
+	if id == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	err = r.db.Preload("Media").Where(&ReceiptImage{ReceiptId: id}).
+		Order("position ASC").Order("id ASC").
+		Find(&images).Error
+	return
+}
+
+func (r *ReceiptRepository) GetImageById(id uint) (image ReceiptImage, err error) {
+	if id == 0 {
+		err = fmt.Errorf("image id cannot be empty")
+		return
+	}
+
+	err = r.db.Preload("Media").Where(&ReceiptImage{Id: id}).First(&image).Error
+	return
+}
+
+// setCover makes the image the only cover of the receipt gallery and references its media
+// from the receipt. Nil image removes the cover.
+func setCover(tx *gorm.DB, receiptId uint, image *ReceiptImage) (err error) {
+	err = tx.Model(&ReceiptImage{}).Where(&ReceiptImage{ReceiptId: receiptId}).UpdateColumn("cover", false).Error
+	if err != nil {
+		return
+	}
+
+	var mediaId *uint
+	if image != nil {
+		err = tx.Model(&ReceiptImage{}).Where(&ReceiptImage{Id: image.Id}).UpdateColumn("cover", true).Error
+		if err != nil {
+			return
+		}
+		mediaId = &image.MediaId
+	}
+
+	err = tx.Model(&Receipt{}).Where(&Receipt{Id: receiptId}).UpdateColumn("media_id", mediaId).Error
+	return
+}
+
+// CreateImage inserts the image at its position, shifting following images down.
+func (r *ReceiptRepository) CreateImage(image *ReceiptImage) (err error) {
+	if image == nil {
+		err = fmt.Errorf("image cannot be empty")
+		return
+	}
+	if image.Id != 0 {
+		err = fmt.Errorf("image id should be empty")
+		return
+	}
+
+	tx := r.db.Begin()
+	err = tx.Model(&ReceiptImage{}).
+		Where("receipt_id = ? AND position >= ?", image.ReceiptId, image.Position).
+		UpdateColumn("position", gorm.Expr("position + 1")).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Omit("Media").Create(image).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	if image.Cover {
+		err = setCover(tx, image.ReceiptId, image)
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	err = tx.Commit().Error
+	return
+}
+
+// UpdateImage saves the caption and makes the image the cover when requested.
+func (r *ReceiptRepository) UpdateImage(image *ReceiptImage) (err error) {
+	if image == nil {
+		err = fmt.Errorf("image cannot be empty")
+		return
+	}
+	if image.Id == 0 {
+		err = fmt.Errorf("image id cannot be empty")
+		return
+	}
+
+	tx := r.db.Begin()
+	err = tx.Model(&ReceiptImage{}).Where(&ReceiptImage{Id: image.Id}).UpdateColumn("caption", image.Caption).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	if image.Cover {
+		err = setCover(tx, image.ReceiptId, image)
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	err = tx.Commit().Error
+	return
+}
+
+// DeleteImageById deletes the image and closes the gap in positions.
+// When the cover is deleted, the first remaining image becomes the cover.
+func (r *ReceiptRepository) DeleteImageById(id uint) (err error) {
+	if id == 0 {
+		err = fmt.Errorf("image id cannot be empty")
+		return
+	}
+
+	var item ReceiptImage
+	err = r.db.Where(&ReceiptImage{Id: id}).First(&item).Error
+	if err != nil {
+		return
+	}
+
+	tx := r.db.Begin()
+	err = tx.Where(&ReceiptImage{Id: id}).Delete(&ReceiptImage{}).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	err = tx.Model(&ReceiptImage{}).
+		Where("receipt_id = ? AND position > ?", item.ReceiptId, item.Position).
+		UpdateColumn("position", gorm.Expr("position - 1")).Error
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	if item.Cover {
+		var next ReceiptImage
+		err = tx.Where(&ReceiptImage{ReceiptId: item.ReceiptId}).Order("position ASC").Order("id ASC").First(&next).Error
+		if gorm.IsRecordNotFoundError(err) {
+			err = setCover(tx, item.ReceiptId, nil)
+		} else if err == nil {
+			err = setCover(tx, item.ReceiptId, &next)
+		}
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	err = tx.Commit().Error
+	return
+}
+
+// ReorderImages sets positions of the receipt images according to their order in the given list.
+func (r *ReceiptRepository) ReorderImages(receiptId uint, ids []uint) (err error) {
+	if receiptId == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	tx := r.db.Begin()
+	for position, id := range ids {
+		err = tx.Model(&ReceiptImage{}).
+			Where(&ReceiptImage{Id: id, ReceiptId: receiptId}).
+			UpdateColumn("position", position).Error
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}
+
+	err = tx.Commit().Error
+	return
+}
+
+// GetTranslations returns translations of the given receipts to the language, receipts without one are skipped.
+func (r *ReceiptRepository) GetTranslations(ids []uint, language string) (translations []ReceiptTranslation, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	err = r.db.Where("receipt_id IN (?) AND language = ?", ids, language).Find(&translations).Error
+	return
+}
+
+// GetDirectionTranslations returns translations of the given steps to the language, steps without one are skipped.
+func (r *ReceiptRepository) GetDirectionTranslations(directionIds []uint, language string) (translations []DirectionTranslation, err error) {
+	if len(directionIds) == 0 {
+		return
+	}
+
+	err = r.db.Where("direction_id IN (?) AND language = ?", directionIds, language).Find(&translations).Error
+	return
+}
+
+// GetTranslationsById returns translations of the receipt and its live steps to all languages.
+func (r *ReceiptRepository) GetTranslationsById(id uint) (translations Translations, err error) {
+	if id == 0 {
+		err = fmt.Errorf("receipt id cannot be empty")
+		return
+	}
+
+	err = r.db.Where(&ReceiptTranslation{ReceiptId: id}).Order("language ASC").Find(&translations.Receipt).Error
+	if err != nil {
+		return
+	}
+	err = r.db.Table(DirectionTranslation{}.TableName()).
+		Select("receipt_direction_translations.*").
+		Joins("JOIN receipt_directions ON receipt_directions.id = receipt_direction_translations.direction_id").
+		Where("receipt_direction_translations.receipt_id = ? AND receipt_directions.deleted_at IS NULL", id).
+		Order("receipt_directions.position ASC").Order("receipt_direction_translations.language ASC").
+		Find(&translations.Directions).Error
+	return
+}
+
+// SaveTranslation creates the translation of the receipt to its language or updates the existing one.
+func (r *ReceiptRepository) SaveTranslation(translation *ReceiptTranslation) (err error) {
+	if translation.ReceiptId == 0 || translation.Language == "" {
+		err = fmt.Errorf("receipt id and language cannot be empty")
+		return
+	}
+
+	err = r.db.Where(&ReceiptTranslation{ReceiptId: translation.ReceiptId, Language: translation.Language}).
+		Assign(ReceiptTranslation{Name: translation.Name, Description: translation.Description}).
+		FirstOrCreate(translation).Error
+	return
+}
+
+// SaveDirectionTranslation creates the translation of the step to its language or updates the existing one.
+func (r *ReceiptRepository) SaveDirectionTranslation(translation *DirectionTranslation) (err error) {
+	if translation.DirectionId == 0 || translation.Language == "" {
+		err = fmt.Errorf("direction id and language cannot be empty")
 		return
 	}
 
-	err = r.db.Model(Receipt{}).Where(&Receipt{Id: id}).Delete(Receipt{}).Error
+	err = r.db.Where(&DirectionTranslation{DirectionId: translation.DirectionId, Language: translation.Language}).
+		Assign(DirectionTranslation{ReceiptId: translation.ReceiptId, Description: translation.Description}).
+		FirstOrCreate(translation).Error
 	return
 }
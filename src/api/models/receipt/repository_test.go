@@ -0,0 +1,207 @@
+package receipt
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"food/src/api/database/dbtest"
+	"food/src/api/models/media"
+	"github.com/jinzhu/gorm"
+)
+
+const migrationsDir = "../../../../migrations"
+
+var (
+	tablePattern     = regexp.MustCompile("(?i)(?:CREATE|ALTER)\\s+TABLE\\s+`?(\\w+)`?")
+	referencePattern = regexp.MustCompile("(?i)`(?:receipt_id|direction_id)`\\s+INT|REFERENCES\\s+`?(?:receipts|receipt_directions)`?\\s*\\(")
+)
+
+// TestPurgeTablesCoverMigrations fails when a migration adds a table referencing receipts
+// which purge does not clean.
+func TestPurgeTablesCoverMigrations(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("cannot find migrations: %v", err)
+	}
+
+	purged := map[string]bool{}
+	for _, table := range purgeTables {
+		purged[table] = true
+	}
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatalf("cannot read migration: %v", err)
+		}
+		for _, statement := range strings.Split(string(content), ";") {
+			table := tablePattern.FindStringSubmatch(statement)
+			if table == nil || table[1] == "receipts" || !referencePattern.MatchString(statement) {
+				continue
+			}
+			if !purged[table[1]] {
+				t.Errorf("table `%s` of %s references receipts, but is not purged", table[1], filepath.Base(file))
+			}
+		}
+	}
+}
+
+// createReceiptRows adds the receipt with a row in every table referencing it.
+func createReceiptRows(t *testing.T, db *gorm.DB, userId uint, deletedAt *time.Time) Receipt {
+	cover := media.Media{Link: "cover.png", Format: "image/png"}
+	step := media.Media{Link: "step.png", Format: "image/png"}
+	for _, m := range []*media.Media{&cover, &step} {
+		err := db.Create(m).Error
+		if err != nil {
+			t.Fatalf("cannot create media: %v", err)
+		}
+	}
+	r := Receipt{Name: "Pancakes", Category: "breakfast", CookTime: 20, UserId: userId, MediaId: &cover.Id}
+	err := db.Create(&r).Error
+	if err != nil {
+		t.Fatalf("cannot create receipt: %v", err)
+	}
+	direction := ReceiptDirection{ReceiptId: r.Id, Description: "Fry", MediaId: &step.Id}
+	err = db.Create(&direction).Error
+	if err != nil {
+		t.Fatalf("cannot create direction: %v", err)
+	}
+
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		{"INSERT INTO ingredients (name) VALUES ('flour')", nil},
+		{"INSERT INTO receipt_ingredients (receipt_id, ingredient_id, quantity) SELECT ?, MAX(id), '200 g' FROM ingredients", []interface{}{r.Id}},
+		{"INSERT INTO receipt_images (receipt_id, media_id, cover) VALUES (?, ?, 1)", []interface{}{r.Id, cover.Id}},
+		{"INSERT INTO receipt_translations (receipt_id, language, name, description) VALUES (?, 'uk', 'Млинці', 'Тонкі')", []interface{}{r.Id}},
+		{"INSERT INTO receipt_direction_translations (receipt_id, direction_id, language, description) VALUES (?, ?, 'uk', 'Смажити')", []interface{}{r.Id, direction.Id}},
+		{"INSERT INTO receipt_accesses (receipt_id, user_id, role) VALUES (?, ?, 'viewer')", []interface{}{r.Id, userId}},
+		{"INSERT INTO receipt_reviews (receipt_id, user_id, rating) VALUES (?, ?, 5)", []interface{}{r.Id, userId}},
+		{"INSERT INTO receipt_comments (receipt_id, user_id, body) VALUES (?, ?, 'Tasty')", []interface{}{r.Id, userId}},
+		{"INSERT INTO receipt_comments (receipt_id, user_id, parent_id, root_id, body) SELECT ?, ?, MAX(id), MAX(id), 'Thanks' FROM receipt_comments", []interface{}{r.Id, userId}},
+		{"INSERT INTO receipt_favorites (receipt_id, user_id) VALUES (?, ?)", []interface{}{r.Id, userId}},
+		{"INSERT INTO cookbooks (user_id, name) VALUES (?, 'Breakfasts')", []interface{}{userId}},
+		{"INSERT INTO cookbook_receipts (cookbook_id, receipt_id) SELECT MAX(id), ? FROM cookbooks", []interface{}{r.Id}},
+		{"INSERT INTO meal_plan_entries (user_id, receipt_id, date, slot) VALUES (?, ?, '2019-07-01', 'breakfast')", []interface{}{userId, r.Id}},
+	}
+	for _, s := range statements {
+		err = db.Exec(s.query, s.args...).Error
+		if err != nil {
+			t.Fatalf("cannot run `%s`: %v", s.query, err)
+		}
+	}
+
+	if deletedAt != nil {
+		err = db.Model(&r).UpdateColumn("deleted_at", *deletedAt).Error
+		if err != nil {
+			t.Fatalf("cannot delete receipt: %v", err)
+		}
+	}
+	return r
+}
+
+func countRows(t *testing.T, db *gorm.DB, table string, receiptId uint) (count int) {
+	column := "receipt_id"
+	if table == "receipts" {
+		column = "id"
+	}
+	err := db.Table(table).Where(column+" = ?", receiptId).Count(&count).Error
+	if err != nil {
+		t.Fatalf("cannot count rows of `%s`: %v", table, err)
+	}
+	return
+}
+
+func TestPurge(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	repo := GetReceiptRepository(db)
+	userId := dbtest.CreateUser(t, db, "cook")
+
+	now := time.Now().UTC()
+	expired := now.Add(-48 * time.Hour)
+	recent := now.Add(-time.Hour)
+	purged := createReceiptRows(t, db, userId, &expired)
+	trashed := createReceiptRows(t, db, userId, &recent)
+	kept := createReceiptRows(t, db, userId, nil)
+
+	ids, err := repo.GetDeletedIdsBefore(now.Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatalf("cannot get expired receipts: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != purged.Id {
+		t.Errorf("expired receipts are %v, expected %d", ids, purged.Id)
+	}
+
+	links, err := repo.Purge(purged.Id)
+	if err != nil {
+		t.Fatalf("cannot purge receipt: %v", err)
+	}
+	if len(links) != 2 {
+		t.Errorf("removed media links are %v, expected cover and step", links)
+	}
+	for _, table := range append(purgeTables, "receipts") {
+		if count := countRows(t, db, table, purged.Id); count != 0 {
+			t.Errorf("%d rows of `%s` are left", count, table)
+		}
+		for _, other := range []Receipt{trashed, kept} {
+			if countRows(t, db, table, other.Id) == 0 {
+				t.Errorf("rows of `%s` of receipt %d are purged", table, other.Id)
+			}
+		}
+	}
+
+	var mediaCount int
+	err = db.Model(&media.Media{}).Where("id = ?", *purged.MediaId).Count(&mediaCount).Error
+	if err != nil || mediaCount != 0 {
+		t.Errorf("cover media is left, count %d, error %v", mediaCount, err)
+	}
+}
+
+func TestGetVersion(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	repo := GetReceiptRepository(db)
+	userId := dbtest.CreateUser(t, db, "cook")
+	r := createReceiptRows(t, db, userId, nil)
+	// the other receipt adds one more ingredient
+	createReceiptRows(t, db, userId, nil)
+
+	version, err := repo.GetVersion()
+	if err != nil {
+		t.Fatalf("cannot get version: %v", err)
+	}
+	changes := []struct {
+		name  string
+		query string
+	}{
+		{"cuisine", "UPDATE receipts SET cuisine = 'french' WHERE id = ?"},
+		{"privacy", "UPDATE receipts SET private = 1 WHERE id = ?"},
+		{"ingredient", "UPDATE receipt_ingredients SET ingredient_id = (SELECT MAX(id) FROM ingredients) WHERE receipt_id = ?"},
+		{"ingredient flag", "UPDATE ingredients SET dairy = 1 WHERE id IN (SELECT ingredient_id FROM receipt_ingredients WHERE receipt_id = ?)"},
+		{"access", "UPDATE receipt_accesses SET deleted_at = NOW() WHERE receipt_id = ?"},
+	}
+	for _, change := range changes {
+		err = db.Exec(change.query, r.Id).Error
+		if err != nil {
+			t.Fatalf("cannot change %s: %v", change.name, err)
+		}
+		changed, err := repo.GetVersion()
+		if err != nil {
+			t.Fatalf("cannot get version: %v", err)
+		}
+		if changed == version {
+			t.Errorf("version is kept after %s change", change.name)
+		}
+		version = changed
+	}
+
+	same, err := repo.GetVersion()
+	if err != nil || same != version {
+		t.Errorf("version changed from %s to %s without changes, error %v", version, same, err)
+	}
+}
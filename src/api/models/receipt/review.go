@@ -0,0 +1,23 @@
+package receipt
+
+import "time"
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+type ReceiptReview struct {
+	Id        uint       `json:"id" gorm:"primary_key"`
+	ReceiptId uint       `json:"receipt_id"`
+	UserId    uint       `json:"user_id"`
+	Rating    uint       `json:"rating" example:"5"`
+	Review    string     `json:"review"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"-"`
+}
+
+func (ReceiptReview) TableName() string {
+	return "receipt_reviews"
+}
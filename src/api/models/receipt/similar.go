@@ -0,0 +1,110 @@
+package receipt
+
+import "math"
+
+// weights of the similarity parts, tags are compared when any of the receipts has them
+// and total time when both receipts have it
+const (
+	similarIngredientWeight = 0.5
+	similarCategoryWeight   = 0.15
+	similarTagWeight        = 0.15
+	similarTimeWeight       = 0.2
+)
+
+// SimilarReceipt is the receipt recommended by its similarity to another one.
+type SimilarReceipt struct {
+	Receipt
+	// similarity from 0 to 1
+	Similarity float64 `json:"similarity" example:"0.64"`
+}
+
+// RarityIndex weights ingredients by how rare they are among all receipts, like IDF of TF-IDF.
+type RarityIndex struct {
+	// number of receipts
+	total int
+	// number of receipts by ingredient id
+	counts map[uint]int
+}
+
+func NewRarityIndex(total int, counts map[uint]int) RarityIndex {
+	return RarityIndex{total: total, counts: counts}
+}
+
+// Weight is the smoothed inverse document frequency of the ingredient,
+// ingredients of every receipt still weigh 1.
+func (idx RarityIndex) Weight(ingredientId uint) float64 {
+	return math.Log(float64(idx.total+1)/float64(idx.counts[ingredientId]+1)) + 1
+}
+
+// Similarity compares the receipts by their ingredients weighted by rarity, category, tags
+// and total time, 0 means they have nothing in common.
+func (idx RarityIndex) Similarity(a, b FullReceipt) float64 {
+	ingredients := map[uint]int{}
+	for _, item := range a.Ingredients {
+		ingredients[item.IngredientId] |= 1
+	}
+	for _, item := range b.Ingredients {
+		ingredients[item.IngredientId] |= 2
+	}
+	common, all := 0.0, 0.0
+	for id, sides := range ingredients {
+		weight := idx.Weight(id)
+		all += weight
+		if sides == 3 {
+			common += weight
+		}
+	}
+
+	tags := map[string]int{}
+	for _, tag := range a.tags() {
+		tags[tag] |= 1
+	}
+	for _, tag := range b.tags() {
+		tags[tag] |= 2
+	}
+	commonTags := 0
+	for _, sides := range tags {
+		if sides == 3 {
+			commonTags++
+		}
+	}
+
+	sameCategory := len(a.Category) > 0 && a.Category == b.Category
+	if common == 0 && !sameCategory && commonTags == 0 {
+		// close total time alone does not make receipts similar
+		return 0
+	}
+
+	score := 0.0
+	if common > 0 {
+		score = common / all * similarIngredientWeight
+	}
+	weight := similarIngredientWeight + similarCategoryWeight
+	if sameCategory {
+		score += similarCategoryWeight
+	}
+	if len(tags) > 0 {
+		weight += similarTagWeight
+		score += float64(commonTags) / float64(len(tags)) * similarTagWeight
+	}
+	if a.TotalTime > 0 && b.TotalTime > 0 {
+		weight += similarTimeWeight
+		score += math.Min(float64(a.TotalTime), float64(b.TotalTime)) /
+			math.Max(float64(a.TotalTime), float64(b.TotalTime)) * similarTimeWeight
+	}
+	return score / weight
+}
+
+// tags returns the cuisine, course and diet labels of the receipt.
+func (r Receipt) tags() (tags []string) {
+	if len(r.Cuisine) > 0 {
+		tags = append(tags, "cuisine:"+r.Cuisine)
+	}
+	if len(r.Course) > 0 {
+		tags = append(tags, "course:"+r.Course)
+	}
+	for _, label := range r.Labels {
+		tags = append(tags, "label:"+label)
+	}
+	return
+}
@@ -0,0 +1,38 @@
+package receipt
+
+import "testing"
+
+func TestSimilarityTags(t *testing.T) {
+	idx := NewRarityIndex(10, map[uint]int{1: 2, 2: 5, 3: 1})
+	pasta := FullReceipt{
+		Receipt:     Receipt{Id: 1, Category: "pasta", Cuisine: "italian", Course: "main", TotalTime: 30},
+		Ingredients: []ReceiptIngredient{{IngredientId: 1}, {IngredientId: 2}},
+	}
+	risotto := FullReceipt{
+		Receipt:     Receipt{Id: 2, Category: "rice", Cuisine: "italian", Course: "main", TotalTime: 40},
+		Ingredients: []ReceiptIngredient{{IngredientId: 3}},
+	}
+	curry := risotto
+	curry.Cuisine = "thai"
+	curry.Course = "soup"
+
+	if idx.Similarity(pasta, curry) != 0 {
+		t.Errorf("receipts without common ingredients, category and tags are similar")
+	}
+	sameTags := idx.Similarity(pasta, risotto)
+	if sameTags <= 0 {
+		t.Errorf("receipts with the same cuisine and course are not similar")
+	}
+
+	pasta.Labels = []string{LabelVegetarian}
+	risotto.Labels = []string{LabelVegetarian, LabelGlutenFree}
+	if similarity := idx.Similarity(pasta, risotto); similarity <= 0 || similarity == sameTags {
+		t.Errorf("diet labels do not change the similarity %f", similarity)
+	}
+
+	withIngredient := risotto
+	withIngredient.Ingredients = []ReceiptIngredient{{IngredientId: 1}, {IngredientId: 3}}
+	if idx.Similarity(pasta, withIngredient) <= idx.Similarity(pasta, risotto) {
+		t.Errorf("common ingredient does not make receipts more similar")
+	}
+}
@@ -0,0 +1,39 @@
+package receipt
+
+import "time"
+
+// ReceiptTranslation holds the name and description of the receipt in another language.
+type ReceiptTranslation struct {
+	Id          uint      `json:"id" gorm:"primary_key"`
+	ReceiptId   uint      `json:"receipt_id"`
+	Language    string    `json:"language" example:"en"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (ReceiptTranslation) TableName() string {
+	return "receipt_translations"
+}
+
+// DirectionTranslation holds the description of the receipt step in another language.
+type DirectionTranslation struct {
+	Id          uint      `json:"id" gorm:"primary_key"`
+	ReceiptId   uint      `json:"receipt_id"`
+	DirectionId uint      `json:"direction_id"`
+	Language    string    `json:"language" example:"en"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (DirectionTranslation) TableName() string {
+	return "receipt_direction_translations"
+}
+
+// Translations are all translations of the receipt and its steps.
+type Translations struct {
+	Receipt    []ReceiptTranslation   `json:"receipt"`
+	Directions []DirectionTranslation `json:"directions"`
+}
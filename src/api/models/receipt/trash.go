@@ -0,0 +1,11 @@
+package receipt
+
+import "time"
+
+// TrashItem is a deleted receipt which can still be restored.
+type TrashItem struct {
+	Receipt
+	DeletedAt time.Time `json:"deleted_at"`
+	// time when the receipt is deleted permanently
+	PurgeAt time.Time `json:"purge_at"`
+}
@@ -0,0 +1,144 @@
+package schemaorg
+
+import (
+	"fmt"
+	"food/src/api/models/receipt"
+	"strings"
+	"time"
+)
+
+const (
+	Context = "https://schema.org"
+	// MediaType is the content type of JSON-LD documents.
+	MediaType = "application/ld+json"
+)
+
+// diets of schema.org by diet labels of receipts, other labels have no diet
+var diets = map[string]string{
+	receipt.LabelVegan:      Context + "/VeganDiet",
+	receipt.LabelVegetarian: Context + "/VegetarianDiet",
+	receipt.LabelGlutenFree: Context + "/GlutenFreeDiet",
+}
+
+// Document is the JSON-LD representation of a receipt as schema.org Recipe.
+type Document struct {
+	Context            string           `json:"@context"`
+	Type               string           `json:"@type"`
+	Identifier         string           `json:"identifier"`
+	Name               string           `json:"name"`
+	Description        string           `json:"description,omitempty"`
+	Image              []string         `json:"image,omitempty"`
+	RecipeCategory     string           `json:"recipeCategory,omitempty"`
+	RecipeCuisine      string           `json:"recipeCuisine,omitempty"`
+	RecipeIngredient   []string         `json:"recipeIngredient"`
+	RecipeInstructions []HowToStep      `json:"recipeInstructions"`
+	PrepTime           string           `json:"prepTime,omitempty"`
+	CookTime           string           `json:"cookTime,omitempty"`
+	TotalTime          string           `json:"totalTime,omitempty"`
+	SuitableForDiet    []string         `json:"suitableForDiet,omitempty"`
+	DateCreated        string           `json:"dateCreated"`
+	DateModified       string           `json:"dateModified"`
+	AggregateRating    *AggregateRating `json:"aggregateRating,omitempty"`
+}
+
+type HowToStep struct {
+	Type     string `json:"@type"`
+	Position int    `json:"position"`
+	Text     string `json:"text"`
+	Image    string `json:"image,omitempty"`
+}
+
+type AggregateRating struct {
+	Type        string  `json:"@type"`
+	RatingValue float64 `json:"ratingValue"`
+	RatingCount uint    `json:"ratingCount"`
+}
+
+// NewDocument builds the Recipe document of the receipt.
+// mediaUrl converts a media link into an absolute URL.
+func NewDocument(r receipt.FullReceipt, mediaUrl func(link string) string) Document {
+	d := Document{
+		Context:            Context,
+		Type:               "Recipe",
+		Identifier:         fmt.Sprint(r.Id),
+		Name:               r.Name,
+		Description:        r.Description,
+		RecipeCategory:     r.Category,
+		RecipeCuisine:      r.Cuisine,
+		RecipeIngredient:   make([]string, 0, len(r.Ingredients)),
+		RecipeInstructions: make([]HowToStep, 0, len(r.Directions)),
+		DateCreated:        r.CreatedAt.UTC().Format(time.RFC3339),
+		DateModified:       r.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+
+	// the cover goes first as the main image
+	for _, image := range r.Images {
+		if image.Media != nil && image.Cover {
+			d.Image = append(d.Image, mediaUrl(image.Media.Link))
+		}
+	}
+	for _, image := range r.Images {
+		if image.Media != nil && !image.Cover {
+			d.Image = append(d.Image, mediaUrl(image.Media.Link))
+		}
+	}
+	if len(d.Image) == 0 && r.Media != nil {
+		d.Image = append(d.Image, mediaUrl(r.Media.Link))
+	}
+
+	for _, label := range r.Labels {
+		if diet, ok := diets[label]; ok {
+			d.SuitableForDiet = append(d.SuitableForDiet, diet)
+		}
+	}
+
+	for _, item := range r.Ingredients {
+		if item.Ingredient == nil {
+			continue
+		}
+		d.RecipeIngredient = append(d.RecipeIngredient, strings.TrimSpace(item.Quantity+" "+item.Ingredient.Name))
+	}
+
+	for k, item := range r.Directions {
+		step := HowToStep{Type: "HowToStep", Position: k + 1, Text: item.Description}
+		if item.Media != nil {
+			step.Image = mediaUrl(item.Media.Link)
+		}
+		d.RecipeInstructions = append(d.RecipeInstructions, step)
+	}
+
+	if r.PrepTime > 0 {
+		d.PrepTime = FormatDuration(time.Duration(r.PrepTime) * time.Minute)
+	}
+	if r.CookTime > 0 {
+		d.CookTime = FormatDuration(time.Duration(r.CookTime) * time.Minute)
+	}
+	minutes := r.TotalTime
+	if minutes == 0 {
+		minutes = r.ActiveTime + r.PassiveTime
+	}
+	if minutes > 0 {
+		d.TotalTime = FormatDuration(time.Duration(minutes) * time.Minute)
+	}
+
+	if r.RatingsCount > 0 {
+		d.AggregateRating = &AggregateRating{
+			Type:        "AggregateRating",
+			RatingValue: r.AverageRating,
+			RatingCount: r.RatingsCount,
+		}
+	}
+	return d
+}
+
+// FormatDuration formats the duration as ISO 8601 with minute precision, like `PT1H30M`.
+func FormatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	if minutes < 60 {
+		return fmt.Sprintf("PT%dM", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("PT%dH", minutes/60)
+	}
+	return fmt.Sprintf("PT%dH%dM", minutes/60, minutes%60)
+}
@@ -0,0 +1,51 @@
+package schemaorg
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Extract finds schema.org Recipe in the HTML page. JSON-LD is preferred to microdata.
+// Relative image links are resolved against the base URL, which can be nil.
+func Extract(page []byte, base *url.URL) (recipe Recipe, err error) {
+	root, err := html.Parse(bytes.NewReader(page))
+	if err != nil {
+		return
+	}
+
+	// the page can declare its own base URL
+	if baseNode := findNode(root, func(n *html.Node) bool { return n.Type == html.ElementNode && n.Data == "base" }); baseNode != nil {
+		if href, ok := attr(baseNode, "href"); ok {
+			if link, parseErr := url.Parse(href); parseErr == nil {
+				if base != nil {
+					link = base.ResolveReference(link)
+				}
+				base = link
+			}
+		}
+	}
+
+	scripts := findNodes(root, func(n *html.Node) bool {
+		scriptType, _ := attr(n, "type")
+		return n.Type == html.ElementNode && n.Data == "script" &&
+			strings.EqualFold(strings.TrimSpace(scriptType), "application/ld+json")
+	})
+	for _, script := range scripts {
+		if script.FirstChild == nil {
+			continue
+		}
+		if found, ok := parseJSONLD(script.FirstChild.Data, base); ok {
+			return found, nil
+		}
+	}
+
+	if found, ok := parseMicrodata(root, base); ok {
+		return found, nil
+	}
+
+	err = ErrNoRecipe
+	return
+}
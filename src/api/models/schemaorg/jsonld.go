@@ -0,0 +1,182 @@
+package schemaorg
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// parseJSONLD looks for the Recipe in a JSON-LD document,
+// it can be the document itself, an item of an array or of `@graph`.
+func parseJSONLD(data string, base *url.URL) (recipe Recipe, ok bool) {
+	var document interface{}
+	if err := json.Unmarshal([]byte(data), &document); err != nil {
+		return
+	}
+
+	node := findRecipeNode(document)
+	if node == nil {
+		return
+	}
+
+	recipe.Name = cleanText(stringValue(node["name"]))
+	recipe.Description = cleanText(stringValue(node["description"]))
+	for _, value := range stringValues(node["recipeCategory"]) {
+		recipe.Categories = appendText(recipe.Categories, value)
+	}
+	for _, value := range stringValues(node["recipeCuisine"]) {
+		recipe.Cuisines = appendText(recipe.Cuisines, value)
+	}
+	for _, value := range imageValues(node["image"]) {
+		if link := resolveURL(base, value); len(link) > 0 {
+			recipe.Images = append(recipe.Images, link)
+		}
+	}
+
+	ingredients := node["recipeIngredient"]
+	if ingredients == nil {
+		// used by older documents
+		ingredients = node["ingredients"]
+	}
+	for _, value := range stringValues(ingredients) {
+		recipe.Ingredients = appendText(recipe.Ingredients, value)
+	}
+	recipe.Instructions = instructionValues(node["recipeInstructions"], nil)
+
+	recipe.PrepTime, _ = ParseDuration(stringValue(node["prepTime"]))
+	recipe.CookTime, _ = ParseDuration(stringValue(node["cookTime"]))
+	recipe.TotalTime, _ = ParseDuration(stringValue(node["totalTime"]))
+	if yields := stringValues(node["recipeYield"]); len(yields) > 0 {
+		recipe.Yield = cleanText(yields[0])
+	}
+	return recipe, true
+}
+
+func findRecipeNode(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if node := findRecipeNode(item); node != nil {
+				return node
+			}
+		}
+	case map[string]interface{}:
+		if hasType(v["@type"], "Recipe") {
+			return v
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findRecipeNode(graph)
+		}
+	}
+	return nil
+}
+
+func hasType(value interface{}, name string) bool {
+	return hasTypeName(stringValues(value), name)
+}
+
+func hasTypeName(types []string, name string) bool {
+	for _, t := range types {
+		// types can be given as full IRIs, like `http://schema.org/Recipe`
+		if t == name || strings.HasSuffix(t, "/"+name) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		if len(v) > 0 {
+			return stringValue(v[0])
+		}
+	case map[string]interface{}:
+		if text, ok := v["text"]; ok {
+			return stringValue(text)
+		}
+		if name, ok := v["name"]; ok {
+			return stringValue(name)
+		}
+	}
+	return ""
+}
+
+func stringValues(value interface{}) (values []string) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if s := stringValue(item); len(s) > 0 {
+				values = append(values, s)
+			}
+		}
+	default:
+		if s := stringValue(v); len(s) > 0 {
+			values = append(values, s)
+		}
+	}
+	return
+}
+
+// imageValues supports URL strings and ImageObject nodes, single or listed.
+func imageValues(value interface{}) (values []string) {
+	switch v := value.(type) {
+	case string:
+		values = append(values, v)
+	case []interface{}:
+		for _, item := range v {
+			values = append(values, imageValues(item)...)
+		}
+	case map[string]interface{}:
+		if link := stringValue(v["url"]); len(link) > 0 {
+			values = append(values, link)
+		} else if link := stringValue(v["contentUrl"]); len(link) > 0 {
+			values = append(values, link)
+		}
+	}
+	return
+}
+
+// instructionValues flattens plain text, HowToStep and HowToSection instructions into steps.
+func instructionValues(value interface{}, steps []string) []string {
+	switch v := value.(type) {
+	case string:
+		// single text block, one step per line
+		for _, line := range strings.Split(tagPattern.ReplaceAllString(v, "\n"), "\n") {
+			steps = appendText(steps, line)
+		}
+	case []interface{}:
+		for _, item := range v {
+			steps = instructionValues(item, steps)
+		}
+	case map[string]interface{}:
+		if items, ok := v["itemListElement"]; ok {
+			return instructionValues(items, steps)
+		}
+		text := stringValue(v["text"])
+		if len(text) == 0 {
+			text = stringValue(v["name"])
+		}
+		steps = appendText(steps, text)
+	}
+	return steps
+}
+
+func resolveURL(base *url.URL, value string) string {
+	link, err := url.Parse(strings.TrimSpace(value))
+	if err != nil || len(value) == 0 {
+		return ""
+	}
+	if base != nil {
+		link = base.ResolveReference(link)
+	}
+	if link.Scheme != "http" && link.Scheme != "https" {
+		return ""
+	}
+	return link.String()
+}
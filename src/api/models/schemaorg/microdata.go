@@ -0,0 +1,181 @@
+package schemaorg
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// parseMicrodata reads the first element with Recipe itemtype and its itemprop descendants.
+func parseMicrodata(root *html.Node, base *url.URL) (recipe Recipe, ok bool) {
+	scope := findNode(root, func(n *html.Node) bool {
+		_, isScope := attr(n, "itemscope")
+		itemType, _ := attr(n, "itemtype")
+		return isScope && hasTypeName(strings.Fields(itemType), "Recipe")
+	})
+	if scope == nil {
+		return
+	}
+
+	visitProps(scope, func(n *html.Node, prop string) {
+		switch prop {
+		case "name":
+			if len(recipe.Name) == 0 {
+				recipe.Name = cleanText(propValue(n))
+			}
+		case "description":
+			if len(recipe.Description) == 0 {
+				recipe.Description = cleanText(propValue(n))
+			}
+		case "recipeCategory":
+			recipe.Categories = appendText(recipe.Categories, propValue(n))
+		case "recipeCuisine":
+			recipe.Cuisines = appendText(recipe.Cuisines, propValue(n))
+		case "image":
+			if link := resolveURL(base, propValue(n)); len(link) > 0 {
+				recipe.Images = append(recipe.Images, link)
+			}
+		case "recipeIngredient", "ingredients":
+			recipe.Ingredients = appendText(recipe.Ingredients, propValue(n))
+		case "recipeInstructions":
+			recipe.Instructions = append(recipe.Instructions, microdataInstructions(n)...)
+		case "prepTime":
+			recipe.PrepTime, _ = ParseDuration(propValue(n))
+		case "cookTime":
+			recipe.CookTime, _ = ParseDuration(propValue(n))
+		case "totalTime":
+			recipe.TotalTime, _ = ParseDuration(propValue(n))
+		case "recipeYield":
+			if len(recipe.Yield) == 0 {
+				recipe.Yield = cleanText(propValue(n))
+			}
+		}
+	})
+	return recipe, true
+}
+
+// visitProps calls fn for every itemprop of the scope. Nested scopes are passed as a whole
+// and their own properties are not visited.
+func visitProps(scope *html.Node, fn func(n *html.Node, prop string)) {
+	for c := scope.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+		props, hasProps := attr(c, "itemprop")
+		if hasProps {
+			for _, prop := range strings.Fields(props) {
+				fn(c, prop)
+			}
+		}
+		if _, nested := attr(c, "itemscope"); nested && hasProps {
+			continue
+		}
+		visitProps(c, fn)
+	}
+}
+
+// microdataInstructions reads steps of HowToStep items or a plain text block.
+func microdataInstructions(n *html.Node) (steps []string) {
+	if _, nested := attr(n, "itemscope"); nested {
+		visitProps(n, func(step *html.Node, prop string) {
+			switch prop {
+			case "text":
+				steps = appendText(steps, propValue(step))
+			case "itemListElement", "step":
+				steps = append(steps, microdataInstructions(step)...)
+			}
+		})
+		if len(steps) > 0 {
+			return
+		}
+	}
+
+	// list items are separate steps
+	items := findNodes(n, func(c *html.Node) bool { return c.Type == html.ElementNode && c.Data == "li" })
+	if len(items) > 0 {
+		for _, item := range items {
+			steps = appendText(steps, textContent(item))
+		}
+		return
+	}
+	return appendText(steps, textContent(n))
+}
+
+// propValue returns the value of the itemprop according to the element kind.
+func propValue(n *html.Node) string {
+	if content, ok := attr(n, "content"); ok {
+		return content
+	}
+	switch n.Data {
+	case "img", "audio", "video", "source", "embed", "iframe":
+		value, _ := attr(n, "src")
+		return value
+	case "a", "area", "link":
+		value, _ := attr(n, "href")
+		return value
+	case "time":
+		if value, ok := attr(n, "datetime"); ok {
+			return value
+		}
+	case "data", "meter":
+		if value, ok := attr(n, "value"); ok {
+			return value
+		}
+	}
+	return textContent(n)
+}
+
+func attr(n *html.Node, name string) (value string, ok bool) {
+	if n.Type != html.ElementNode {
+		return
+	}
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val, true
+		}
+	}
+	return
+}
+
+func textContent(n *html.Node) string {
+	var builder strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			builder.WriteString(n.Data)
+			builder.WriteString(" ")
+		}
+		if n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style") {
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return builder.String()
+}
+
+func findNode(n *html.Node, match func(*html.Node) bool) *html.Node {
+	if match(n) {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findNode(c, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func findNodes(n *html.Node, match func(*html.Node) bool) (nodes []*html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if match(c) {
+			nodes = append(nodes, c)
+			continue
+		}
+		nodes = append(nodes, findNodes(c, match)...)
+	}
+	return
+}
@@ -0,0 +1,76 @@
+package schemaorg
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recipe holds the fields of schema.org Recipe which can be mapped to a receipt.
+type Recipe struct {
+	Name        string
+	Description string
+	Categories  []string
+	Cuisines    []string
+	// absolute image URLs, the first one is the main image
+	Images       []string
+	Ingredients  []string
+	Instructions []string
+	PrepTime     time.Duration
+	CookTime     time.Duration
+	TotalTime    time.Duration
+	Yield        string
+}
+
+// ErrNoRecipe is returned when the page has no schema.org Recipe.
+var ErrNoRecipe = fmt.Errorf("page has no schema.org recipe")
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseDuration parses ISO 8601 durations used by schema.org, like `PT1H30M` or `P0DT20M`.
+func ParseDuration(value string) (d time.Duration, ok bool) {
+	matches := isoDurationPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(value)))
+	if matches == nil || value == "P" || value == "PT" {
+		return
+	}
+
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute}
+	for i, unit := range units {
+		if len(matches[i+1]) == 0 {
+			continue
+		}
+		n, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return
+		}
+		d += time.Duration(n) * unit
+	}
+	if len(matches[4]) > 0 {
+		seconds, err := strconv.ParseFloat(matches[4], 64)
+		if err != nil {
+			return
+		}
+		d += time.Duration(seconds * float64(time.Second))
+	}
+	return d, true
+}
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// cleanText removes markup and entities which are often left in JSON-LD strings
+// and collapses whitespaces.
+func cleanText(value string) string {
+	value = tagPattern.ReplaceAllString(value, " ")
+	value = html.UnescapeString(value)
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func appendText(values []string, value string) []string {
+	if value = cleanText(value); len(value) > 0 {
+		return append(values, value)
+	}
+	return values
+}
@@ -0,0 +1,190 @@
+package shopping
+
+import (
+	"food/src/api/models/ingredient"
+	"food/src/api/models/pantry"
+	"food/src/api/models/receipt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OtherCategory groups items of ingredients without category
+const OtherCategory = "other"
+
+type List struct {
+	Id        uint       `json:"id" gorm:"primary_key"`
+	UserId    uint       `json:"user_id"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"-"`
+	Items     []Item     `json:"-" gorm:"foreignkey:ShoppingListId"`
+	// items grouped by ingredient category (read only)
+	Groups []ItemGroup `json:"groups,omitempty" gorm:"-"`
+}
+
+func (List) TableName() string {
+	return "shopping_lists"
+}
+
+type Item struct {
+	Id             uint   `json:"id" gorm:"primary_key"`
+	ShoppingListId uint   `json:"shopping_list_id"`
+	IngredientId   *uint  `json:"ingredient_id"`
+	Name           string `json:"name"`
+	Category       string `json:"category"`
+	Quantity       string `json:"quantity" example:"1.2 kg"`
+	Checked        bool   `json:"checked"`
+	// added by the user, not generated from receipts
+	Manual    bool       `json:"manual"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"-"`
+}
+
+func (Item) TableName() string {
+	return "shopping_list_items"
+}
+
+type ItemGroup struct {
+	Category string `json:"category"`
+	Items    []Item `json:"items"`
+}
+
+// GroupItems groups items by category, categories and items are sorted by name.
+func GroupItems(items []Item) (groups []ItemGroup) {
+	byCategory := make(map[string][]Item)
+	for _, item := range items {
+		category := item.Category
+		if len(category) == 0 {
+			category = OtherCategory
+		}
+		byCategory[category] = append(byCategory[category], item)
+	}
+
+	groups = make([]ItemGroup, 0, len(byCategory))
+	for category, categoryItems := range byCategory {
+		sort.SliceStable(categoryItems, func(i, j int) bool {
+			return strings.ToLower(categoryItems[i].Name) < strings.ToLower(categoryItems[j].Name)
+		})
+		groups = append(groups, ItemGroup{Category: category, Items: categoryItems})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		// items without category go last
+		if groups[i].Category == OtherCategory || groups[j].Category == OtherCategory {
+			return groups[j].Category == OtherCategory && groups[i].Category != OtherCategory
+		}
+		return groups[i].Category < groups[j].Category
+	})
+	return
+}
+
+// aggregate collects quantities of one ingredient across receipts.
+type aggregate struct {
+	ingredient ingredient.Ingredient
+	quantities []ingredient.Quantity
+	// quantities which cannot be parsed, like `to taste`
+	texts []string
+	// the user has some of the ingredient in the pantry
+	stocked bool
+}
+
+func (a *aggregate) add(quantity string) {
+	q, ok := ingredient.ParseQuantity(quantity)
+	if !ok {
+		if len(quantity) > 0 {
+			a.texts = append(a.texts, quantity)
+		}
+		return
+	}
+
+	for i, existing := range a.quantities {
+		if existing.CanAdd(q) {
+			a.quantities[i] = existing.Add(q)
+			return
+		}
+	}
+	a.quantities = append(a.quantities, q)
+}
+
+// subtract takes the pantry stock off the needed quantities.
+// Stocks with unknown amount are considered to be enough.
+func (a *aggregate) subtract(stock pantry.Item) {
+	a.stocked = true
+	// amounts like `to taste` are covered by any stock
+	a.texts = nil
+
+	q, ok := ingredient.ParseQuantity(stock.Quantity)
+	if !ok {
+		a.quantities = nil
+		return
+	}
+
+	for i, need := range a.quantities {
+		if !need.CanAdd(q) {
+			continue
+		}
+		if rest := need.Sub(q); rest.IsEmpty() {
+			a.quantities = append(a.quantities[:i], a.quantities[i+1:]...)
+		} else {
+			a.quantities[i] = rest
+		}
+		return
+	}
+}
+
+// isCovered reports whether the pantry has everything needed.
+func (a *aggregate) isCovered() bool {
+	return a.stocked && len(a.quantities) == 0 && len(a.texts) == 0
+}
+
+func (a *aggregate) quantity() string {
+	parts := make([]string, 0, len(a.quantities)+len(a.texts))
+	for _, q := range a.quantities {
+		parts = append(parts, q.Normalize().String())
+	}
+	parts = append(parts, a.texts...)
+	return strings.Join(parts, " + ")
+}
+
+// BuildItems aggregates quantities of receipt ingredients by ingredient, converting compatible units.
+// Not expired pantry stocks are subtracted, ingredients fully available in the pantry are skipped.
+func BuildItems(receiptIngredients []receipt.ReceiptIngredient, stocks []pantry.Item, today time.Time) (items []Item) {
+	order := make([]uint, 0)
+	aggregates := make(map[uint]*aggregate)
+	for _, ri := range receiptIngredients {
+		a, ok := aggregates[ri.IngredientId]
+		if !ok {
+			a = &aggregate{}
+			if ri.Ingredient != nil {
+				a.ingredient = *ri.Ingredient
+			}
+			aggregates[ri.IngredientId] = a
+			order = append(order, ri.IngredientId)
+		}
+		a.add(ri.Quantity)
+	}
+
+	for _, stock := range stocks {
+		if a, ok := aggregates[stock.IngredientId]; ok && !stock.IsExpired(today) {
+			a.subtract(stock)
+		}
+	}
+
+	items = make([]Item, 0, len(order))
+	for _, ingredientId := range order {
+		a := aggregates[ingredientId]
+		if a.isCovered() {
+			continue
+		}
+		id := ingredientId
+		items = append(items, Item{
+			IngredientId: &id,
+			Name:         a.ingredient.Name,
+			Category:     a.ingredient.Category,
+			Quantity:     a.quantity(),
+		})
+	}
+	return
+}
@@ -0,0 +1,104 @@
+package shopping
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+)
+
+type ListRepository struct {
+	db *gorm.DB
+}
+
+func GetListRepository(db *gorm.DB) *ListRepository {
+	return &ListRepository{db: db}
+}
+
+func (r *ListRepository) GetAllByUserId(userId uint) (lists []List, err error) {
+	if userId == 0 {
+		err = fmt.Errorf("user id cannot be empty")
+		return
+	}
+	err = r.db.Where(&List{UserId: userId}).Order("created_at DESC").Find(&lists).Error
+	return
+}
+
+func (r *ListRepository) GetById(id uint) (list List, err error) {
+	if id == 0 {
+		err = fmt.Errorf("shopping list id cannot be empty")
+		return
+	}
+	err = r.db.Where(&List{Id: id}).Preload("Items").First(&list).Error
+	return
+}
+
+// Create saves the list together with its items.
+func (r *ListRepository) Create(list *List) (err error) {
+	if list == nil {
+		err = fmt.Errorf("shopping list cannot be empty")
+		return
+	}
+	if list.Id != 0 {
+		err = fmt.Errorf("shopping list id should be empty")
+		return
+	}
+	err = r.db.Create(list).Error
+	return
+}
+
+func (r *ListRepository) Delete(id uint) (err error) {
+	if id == 0 {
+		err = fmt.Errorf("shopping list id cannot be empty")
+		return
+	}
+
+	err = r.db.Model(List{}).Where(&List{Id: id}).Delete(List{}).Error
+	return
+}
+
+func (r *ListRepository) GetItemById(id uint) (item Item, err error) {
+	if id == 0 {
+		err = fmt.Errorf("item id cannot be empty")
+		return
+	}
+	err = r.db.Where(&Item{Id: id}).First(&item).Error
+	return
+}
+
+func (r *ListRepository) CreateItem(item *Item) (err error) {
+	if item == nil {
+		err = fmt.Errorf("item cannot be empty")
+		return
+	}
+	if item.Id != 0 {
+		err = fmt.Errorf("item id should be empty")
+		return
+	}
+	err = r.db.Create(item).Error
+	return
+}
+
+func (r *ListRepository) UpdateItem(item *Item) (err error) {
+	if item == nil {
+		err = fmt.Errorf("item cannot be empty")
+		return
+	}
+	if item.Id == 0 {
+		err = fmt.Errorf("item id cannot be empty")
+		return
+	}
+
+	err = r.db.Model(&Item{}).Where(&Item{Id: item.Id}).
+		Updates(map[string]interface{}{"checked": item.Checked, "quantity": item.Quantity}).Error
+	return
+}
+
+func (r *ListRepository) DeleteItemById(id uint) (err error) {
+	if id == 0 {
+		err = fmt.Errorf("item id cannot be empty")
+		return
+	}
+
+	err = r.db.Model(Item{}).Where(&Item{Id: id}).Delete(&Item{}).Error
+	return
+}
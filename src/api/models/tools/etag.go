@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	weakETagPrefix = "W/"
+	// translatedETagSeparator separates the tag of the stored item from the language of its translation
+	translatedETagSeparator = "-"
+)
+
+// ETag returns the strong entity tag of the value, it changes with any field of its JSON.
+func ETag(value interface{}) string {
+	content, err := json.Marshal(value)
+	if err != nil {
+		// values which cannot be encoded cannot be sent either
+		return `""`
+	}
+	sum := sha1.Sum(content)
+	return fmt.Sprintf(`"%x"`, sum[:12])
+}
+
+// MatchesETag reports whether the If-Match or If-None-Match header lists the entity tag.
+// Weak comparison is used for If-None-Match, then `W/` tags match their strong ones.
+func MatchesETag(header, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if strings.HasPrefix(tag, weakETagPrefix) {
+			if !weak {
+				continue
+			}
+			tag = strings.TrimPrefix(tag, weakETagPrefix)
+		}
+		if len(tag) > 0 && tag == strings.TrimPrefix(etag, weakETagPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// TranslatedETag returns the entity tag of the item translated to the language. It keeps the tag of the stored item,
+// which If-Match is checked against, and adds the language with the tag of the translation, so cached
+// responses change with translations too. The stored tag is returned for the original language.
+func TranslatedETag(etag, language string, translated interface{}) string {
+	if len(language) == 0 {
+		return etag
+	}
+	return strings.TrimSuffix(etag, `"`) + translatedETagSeparator + language +
+		translatedETagSeparator + strings.TrimPrefix(ETag(translated), `"`)
+}
+
+// MatchesStoredETag reports whether the If-Match header lists the entity tag of the stored item,
+// tags of its translations match it too.
+func MatchesStoredETag(header, etag string) bool {
+	tags := strings.Split(header, ",")
+	for i, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if index := strings.Index(tag, translatedETagSeparator); index >= 0 && strings.HasPrefix(tag, `"`) {
+			tag = tag[:index] + `"`
+		}
+		tags[i] = tag
+	}
+	return MatchesETag(strings.Join(tags, ","), etag, false)
+}
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"strconv"
+	"strings"
+)
+
+// languages of receipts and their translations
+const (
+	LanguageEnglish   = "en"
+	LanguageUkrainian = "uk"
+)
+
+var languages = map[string]bool{
+	LanguageEnglish:   true,
+	LanguageUkrainian: true,
+}
+
+// IsValidLanguage reports whether the language is supported.
+func IsValidLanguage(language string) bool {
+	return languages[language]
+}
+
+// NegotiateLanguage picks the supported language the client prefers the most by the Accept-Language header,
+// empty one means that no supported language is acceptable.
+func NegotiateLanguage(header string) string {
+	best, bestQuality := "", 0.0
+	for _, item := range strings.Split(header, ",") {
+		params := strings.Split(item, ";")
+		tag := strings.ToLower(strings.TrimSpace(params[0]))
+		// regional variants, like en-US, match their language
+		if i := strings.IndexByte(tag, '-'); i >= 0 {
+			tag = tag[:i]
+		}
+		if !languages[tag] {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				var err error
+				quality, err = strconv.ParseFloat(param[2:], 64)
+				if err != nil {
+					quality = 0
+				}
+			}
+		}
+		// q=0 means the language is not acceptable
+		if quality > bestQuality {
+			best, bestQuality = tag, quality
+		}
+	}
+	return best
+}
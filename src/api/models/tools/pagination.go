@@ -0,0 +1,30 @@
+package tools
+
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
+
+type Pagination struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+	Total   int `json:"total"`
+}
+
+// NewPagination returns pagination with given params, falling back to defaults for invalid ones.
+func NewPagination(page, perPage int) Pagination {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = DefaultPerPage
+	}
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+	return Pagination{Page: page, PerPage: perPage}
+}
+
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
@@ -0,0 +1,11 @@
+package tools
+
+import "unicode/utf8"
+
+// Truncate cuts the string to the given number of characters.
+func Truncate(value string, length int) string {
+	if utf8.RuneCountInString(value) <= length {
+		return value
+	}
+	return string([]rune(value)[:length])
+}
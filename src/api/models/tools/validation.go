@@ -47,4 +47,25 @@ func NewNotPermittedErr(err error) *NotPermittedErr {
 	return vErr
 }
 
+// PreconditionFailedErr tells the item was changed since the client read it.
+type PreconditionFailedErr struct {
+	err error
+}
+
+func (e *PreconditionFailedErr) Error() string {
+	if e == nil {
+		return "null"
+	}
+
+	if e.err == nil {
+		return "e.err = null"
+	}
+	return e.err.Error()
+}
+
+func NewPreconditionFailedErr(err error) *PreconditionFailedErr {
+	vErr := &PreconditionFailedErr{err: err}
+	return vErr
+}
+
 var Validator = validator.New(&validator.Config{TagName: "validate"})
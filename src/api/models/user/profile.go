@@ -9,6 +9,8 @@ type Profile struct {
 	Id                            uint         `json:"id" gorm:"primary_key"`
 	Username                      string       `json:"username"`
 	Password                      string       `json:"password"`
+	// secret of the meal plan calendar feed
+	CalendarToken                 *string      `json:"-"`
 	CreatedAt                     time.Time    `json:"-"`
 	UpdatedAt                     time.Time    `json:"-"`
 	DeletedAt                     *time.Time   `json:"-"`
@@ -43,6 +43,15 @@ func (r *ProfileRepository) GetByUsername(username string) (user Profile, err er
 	return
 }
 
+func (r *ProfileRepository) GetByCalendarToken(token string) (user Profile, err error) {
+	if len(token) == 0 {
+		err = fmt.Errorf("user calendar token cannot be empty")
+		return
+	}
+
+	err = r.db.Where("calendar_token = ?", token).First(&user).Error
+	return
+}
 
 func (r *ProfileRepository) Create(user Profile) (createdUser Profile, err error) {
 	if user.Id != 0 {
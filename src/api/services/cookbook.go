@@ -0,0 +1,312 @@
+package services
+
+import (
+	"fmt"
+	"food/src/api/models/cookbook"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"github.com/jinzhu/gorm"
+	"io"
+	"strings"
+)
+
+func GetCookbookService(db *gorm.DB) *Cookbook {
+	return &Cookbook{
+		cookbookRepo: cookbook.GetCookbookRepository(db),
+		receiptRepo:  receipt.GetReceiptRepository(db),
+		receiptSvc:   GetReceiptService(db),
+	}
+}
+
+type Cookbook struct {
+	cookbookRepo *cookbook.CookbookRepository
+	receiptRepo  *receipt.ReceiptRepository
+	receiptSvc   *Receipt
+}
+
+type CreateCookbookRequest struct {
+	// (required)
+	Name        string `json:"name" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
+	Description string `json:"description" maxLength:"255" validate:"max=255"`
+}
+
+func (u *CreateCookbookRequest) TrimSpaces() {
+	u.Name = strings.TrimSpace(u.Name)
+	u.Description = strings.TrimSpace(u.Description)
+}
+
+type UpdateCookbookRequest struct {
+	CreateCookbookRequest
+}
+
+type AddCookbookReceiptRequest struct {
+	// (required)
+	ReceiptId uint `json:"receipt_id" minimum:"1" binding:"required" validate:"min=1"`
+	// zero based position, receipt is appended to the end when omitted
+	Position *uint `json:"position"`
+}
+
+type ReorderCookbookReceiptsRequest struct {
+	// all receipt ids of the cookbook in the new order (required)
+	ReceiptIds []uint `json:"receipt_ids" binding:"required" validate:"required,min=1"`
+}
+
+func (s *Cookbook) GetCookbooks(userId uint) (cookbooks []cookbook.Cookbook, err error) {
+	cookbooks, err = s.cookbookRepo.GetAllByUserId(userId)
+	return
+}
+
+// GetCookbook returns the cookbook with its ordered receipts.
+// Receipts which are not visible to the user anymore are skipped.
+func (s *Cookbook) GetCookbook(id, userId uint) (i cookbook.Cookbook, err error) {
+	_, err = s.getOwnCookbook(id, userId)
+	if err != nil {
+		return
+	}
+
+	i, err = s.cookbookRepo.GetWithReceiptsById(id)
+	if err != nil {
+		return
+	}
+
+	ids := make([]uint, 0, len(i.Receipts))
+	for _, item := range i.Receipts {
+		ids = append(ids, item.ReceiptId)
+	}
+	visibleIds, err := s.receiptRepo.GetVisibleIds(userId, ids)
+	if err != nil {
+		return
+	}
+	visible := make(map[uint]bool, len(visibleIds))
+	for _, id := range visibleIds {
+		visible[id] = true
+	}
+
+	items := make([]cookbook.CookbookReceipt, 0, len(i.Receipts))
+	receipts := make([]receipt.Receipt, 0, len(i.Receipts))
+	for _, item := range i.Receipts {
+		if item.Receipt == nil || !visible[item.ReceiptId] {
+			continue
+		}
+		items = append(items, item)
+		receipts = append(receipts, *item.Receipt)
+	}
+
+	err = s.receiptSvc.markReceipts(userId, receipts)
+	if err != nil {
+		return
+	}
+	for k := range items {
+		items[k].Receipt = &receipts[k]
+	}
+	i.Receipts = items
+	return
+}
+
+// WriteCookbookPDF writes printable document of all visible cookbook receipts with a table of contents.
+func (s *Cookbook) WriteCookbookPDF(w io.Writer, id, userId uint) (err error) {
+	i, err := s.GetCookbook(id, userId)
+	if err != nil {
+		return
+	}
+
+	receipts := make([]receipt.FullReceipt, 0, len(i.Receipts))
+	for _, item := range i.Receipts {
+		full, err := s.receiptSvc.loadFullReceipt(*item.Receipt, userId)
+		if err != nil {
+			return err
+		}
+		receipts = append(receipts, full)
+	}
+
+	err = receipt.WritePDF(w, i.Name, receipts)
+	return
+}
+
+func (s *Cookbook) CreateCookbook(userId uint, request CreateCookbookRequest) (i cookbook.Cookbook, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	i = cookbook.Cookbook{
+		UserId:      userId,
+		Name:        request.Name,
+		Description: request.Description,
+	}
+	err = s.cookbookRepo.Create(&i)
+	return
+}
+
+func (s *Cookbook) UpdateCookbook(id, userId uint, request UpdateCookbookRequest) (i cookbook.Cookbook, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	i, err = s.getOwnCookbook(id, userId)
+	if err != nil {
+		return
+	}
+
+	i.Name = request.Name
+	i.Description = request.Description
+	err = s.cookbookRepo.Update(&i)
+	if err != nil {
+		return
+	}
+
+	i, err = s.cookbookRepo.GetById(id)
+	return
+}
+
+func (s *Cookbook) DeleteCookbook(id, userId uint) (err error) {
+	_, err = s.getOwnCookbook(id, userId)
+	if _, ok := err.(*tools.ValidationErr); ok {
+		// cookbook is already deleted
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	err = s.cookbookRepo.Delete(id)
+	return
+}
+
+func (s *Cookbook) AddCookbookReceipt(id, userId uint, request AddCookbookReceiptRequest) (i cookbook.Cookbook, err error) {
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	_, err = s.getOwnCookbook(id, userId)
+	if err != nil {
+		return
+	}
+
+	_, err = s.receiptSvc.getPermittedReceipt(request.ReceiptId, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+
+	items, err := s.cookbookRepo.GetReceipts(id)
+	if err != nil {
+		return
+	}
+	for _, item := range items {
+		if item.ReceiptId == request.ReceiptId {
+			err = tools.NewValidationErr(fmt.Errorf("receipt is already in the cookbook"))
+			return
+		}
+	}
+
+	position := uint(len(items))
+	if request.Position != nil && *request.Position < position {
+		position = *request.Position
+	}
+
+	err = s.cookbookRepo.AddReceipt(&cookbook.CookbookReceipt{
+		CookbookId: id,
+		ReceiptId:  request.ReceiptId,
+		Position:   position,
+	})
+	if err != nil {
+		return
+	}
+
+	i, err = s.GetCookbook(id, userId)
+	return
+}
+
+func (s *Cookbook) RemoveCookbookReceipt(id, receiptId, userId uint) (err error) {
+	_, err = s.getOwnCookbook(id, userId)
+	if err != nil {
+		return
+	}
+
+	err = s.cookbookRepo.RemoveReceipt(id, receiptId)
+	if gorm.IsRecordNotFoundError(err) {
+		err = nil
+		return
+	}
+	return
+}
+
+func (s *Cookbook) ReorderCookbookReceipts(id, userId uint, request ReorderCookbookReceiptsRequest) (i cookbook.Cookbook, err error) {
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	_, err = s.getOwnCookbook(id, userId)
+	if err != nil {
+		return
+	}
+
+	items, err := s.cookbookRepo.GetReceipts(id)
+	if err != nil {
+		return
+	}
+
+	receiptIds := make([]uint, 0, len(items))
+	for _, item := range items {
+		receiptIds = append(receiptIds, item.ReceiptId)
+	}
+	if !isPermutation(receiptIds, request.ReceiptIds) {
+		err = tools.NewValidationErr(fmt.Errorf("receipt ids should contain every receipt of the cookbook exactly once"))
+		return
+	}
+
+	err = s.cookbookRepo.ReorderReceipts(id, request.ReceiptIds)
+	if err != nil {
+		return
+	}
+
+	i, err = s.GetCookbook(id, userId)
+	return
+}
+
+func (s *Cookbook) getOwnCookbook(id, userId uint) (i cookbook.Cookbook, err error) {
+	i, err = s.cookbookRepo.GetById(id)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if i.UserId != userId {
+		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismatch"))
+		return
+	}
+	return
+}
+
+// isPermutation reports whether the given ids contain every existing id exactly once.
+func isPermutation(existingIds, ids []uint) bool {
+	if len(existingIds) != len(ids) {
+		return false
+	}
+
+	seen := make(map[uint]bool, len(existingIds))
+	for _, id := range existingIds {
+		seen[id] = false
+	}
+	for _, id := range ids {
+		used, ok := seen[id]
+		if !ok || used {
+			return false
+		}
+		seen[id] = true
+	}
+	return true
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"food/src/api/models/mealplan"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"food/src/api/models/user"
+	"github.com/jinzhu/gorm"
+	"io"
+	"time"
+)
+
+const (
+	// MaxMealPlanRange limits the date range of one meal plan request
+	MaxMealPlanRange = 92 * 24 * time.Hour
+
+	calendarTokenBytes = 32
+	calendarFeedPast   = 31 * 24 * time.Hour
+	calendarFeedFuture = 366 * 24 * time.Hour
+)
+
+func GetMealPlanService(db *gorm.DB) *MealPlan {
+	return &MealPlan{
+		entryRepo:   mealplan.GetEntryRepository(db),
+		profileRepo: user.GetProfileRepository(db),
+		receiptSvc:  GetReceiptService(db),
+	}
+}
+
+type MealPlan struct {
+	entryRepo   *mealplan.EntryRepository
+	profileRepo *user.ProfileRepository
+	receiptSvc  *Receipt
+}
+
+type CreateMealPlanEntryRequest struct {
+	// (required)
+	ReceiptId uint `json:"receipt_id" minimum:"1" binding:"required" validate:"min=1"`
+	// (required)
+	Date string `json:"date" example:"2019-06-28" binding:"required" validate:"required"`
+	// breakfast, lunch or dinner (required)
+	Slot string `json:"slot" enums:"breakfast,lunch,dinner" binding:"required" validate:"required"`
+	// (required)
+	Servings uint `json:"servings" minimum:"1" maximum:"100" binding:"required" validate:"min=1,max=100"`
+}
+
+type UpdateMealPlanEntryRequest struct {
+	// (required)
+	Date string `json:"date" example:"2019-06-28" binding:"required" validate:"required"`
+	// breakfast, lunch or dinner (required)
+	Slot string `json:"slot" enums:"breakfast,lunch,dinner" binding:"required" validate:"required"`
+	// (required)
+	Servings uint `json:"servings" minimum:"1" maximum:"100" binding:"required" validate:"min=1,max=100"`
+}
+
+type CopyMealPlanEntryRequest struct {
+	// (required)
+	Date string `json:"date" example:"2019-06-28" binding:"required" validate:"required"`
+	// breakfast, lunch or dinner (required)
+	Slot string `json:"slot" enums:"breakfast,lunch,dinner" binding:"required" validate:"required"`
+}
+
+func parseMealPlanDate(value string) (date time.Time, err error) {
+	date, err = time.Parse(mealplan.DateFormat, value)
+	if err != nil {
+		err = tools.NewValidationErr(fmt.Errorf("date `%s` should have format YYYY-MM-DD", value))
+	}
+	return
+}
+
+func parseMealPlanSlot(date, slot string) (day time.Time, err error) {
+	if !mealplan.IsValidSlot(slot) {
+		err = tools.NewValidationErr(fmt.Errorf("slot `%s` is not supported", slot))
+		return
+	}
+	day, err = parseMealPlanDate(date)
+	return
+}
+
+// ParseMealPlanRange parses the inclusive date range and checks its length.
+func ParseMealPlanRange(from, to string) (fromDate, toDate time.Time, err error) {
+	fromDate, err = parseMealPlanDate(from)
+	if err != nil {
+		return
+	}
+	toDate, err = parseMealPlanDate(to)
+	if err != nil {
+		return
+	}
+	if toDate.Before(fromDate) {
+		err = tools.NewValidationErr(fmt.Errorf("date range end should not be before its start"))
+		return
+	}
+	if toDate.Sub(fromDate) > MaxMealPlanRange {
+		err = tools.NewValidationErr(fmt.Errorf("date range should not be longer than %d days", MaxMealPlanRange/(24*time.Hour)))
+		return
+	}
+	return
+}
+
+func (s *MealPlan) GetEntries(userId uint, from, to time.Time) (entries []mealplan.Entry, err error) {
+	entries, err = s.entryRepo.GetByDateRange(userId, from, to)
+	return
+}
+
+func (s *MealPlan) CreateEntry(userId uint, request CreateMealPlanEntryRequest) (i mealplan.Entry, err error) {
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	date, err := parseMealPlanSlot(request.Date, request.Slot)
+	if err != nil {
+		return
+	}
+
+	_, err = s.receiptSvc.getPermittedReceipt(request.ReceiptId, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+
+	i = mealplan.Entry{
+		UserId:    userId,
+		ReceiptId: request.ReceiptId,
+		Date:      date,
+		Slot:      request.Slot,
+		Servings:  request.Servings,
+	}
+	err = s.entryRepo.Create(&i)
+	if err != nil {
+		return
+	}
+
+	i, err = s.entryRepo.GetById(i.Id, userId)
+	return
+}
+
+// UpdateEntry moves the entry to another date or slot and changes its servings.
+func (s *MealPlan) UpdateEntry(id, userId uint, request UpdateMealPlanEntryRequest) (i mealplan.Entry, err error) {
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	date, err := parseMealPlanSlot(request.Date, request.Slot)
+	if err != nil {
+		return
+	}
+
+	i, err = s.getVisibleEntry(id, userId)
+	if err != nil {
+		return
+	}
+
+	i.Date = date
+	i.Slot = request.Slot
+	i.Servings = request.Servings
+	err = s.entryRepo.Update(&i)
+	if err != nil {
+		return
+	}
+
+	i, err = s.entryRepo.GetById(i.Id, userId)
+	return
+}
+
+func (s *MealPlan) CopyEntry(id, userId uint, request CopyMealPlanEntryRequest) (i mealplan.Entry, err error) {
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	date, err := parseMealPlanSlot(request.Date, request.Slot)
+	if err != nil {
+		return
+	}
+
+	origin, err := s.getVisibleEntry(id, userId)
+	if err != nil {
+		return
+	}
+
+	i = mealplan.Entry{
+		UserId:    userId,
+		ReceiptId: origin.ReceiptId,
+		Date:      date,
+		Slot:      request.Slot,
+		Servings:  origin.Servings,
+	}
+	err = s.entryRepo.Create(&i)
+	if err != nil {
+		return
+	}
+
+	i, err = s.entryRepo.GetById(i.Id, userId)
+	return
+}
+
+func (s *MealPlan) DeleteEntry(id, userId uint) (err error) {
+	_, err = s.getOwnEntry(id, userId)
+	if _, ok := err.(*tools.ValidationErr); ok {
+		// entry is already deleted
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	err = s.entryRepo.Delete(id)
+	return
+}
+
+// GetCalendarToken returns the secret of the user calendar feed, generating it on first use.
+func (s *MealPlan) GetCalendarToken(userId uint) (token string, err error) {
+	profile, err := s.profileRepo.GetById(userId)
+	if err != nil {
+		return
+	}
+
+	if profile.CalendarToken != nil && len(*profile.CalendarToken) > 0 {
+		token = *profile.CalendarToken
+		return
+	}
+
+	token, err = s.ResetCalendarToken(userId)
+	return
+}
+
+// ResetCalendarToken generates a new secret, so the previous feed URL stops working.
+func (s *MealPlan) ResetCalendarToken(userId uint) (token string, err error) {
+	tokenBytes := make([]byte, calendarTokenBytes)
+	_, err = rand.Read(tokenBytes)
+	if err != nil {
+		return
+	}
+
+	token = hex.EncodeToString(tokenBytes)
+	err = s.profileRepo.Update(userId, map[string]interface{}{"calendar_token": token})
+	return
+}
+
+// WriteCalendar renders the meal plan of the token owner as an iCalendar feed.
+func (s *MealPlan) WriteCalendar(w io.Writer, token string) (err error) {
+	profile, err := s.profileRepo.GetByCalendarToken(token)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewNotPermittedErr(fmt.Errorf("calendar token is not valid"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	entries, err := s.entryRepo.GetByDateRange(profile.Id, now.Add(-calendarFeedPast), now.Add(calendarFeedFuture))
+	if err != nil {
+		return
+	}
+
+	err = mealplan.WriteCalendar(w, "Meal plan", entries)
+	return
+}
+
+func (s *MealPlan) getOwnEntry(id, userId uint) (i mealplan.Entry, err error) {
+	i, err = s.entryRepo.GetById(id, userId)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if i.UserId != userId {
+		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismatch"))
+		return
+	}
+	return
+}
+
+// getVisibleEntry returns the own entry of the receipt the user can still see,
+// entries of receipts made private or unshared can be deleted only.
+func (s *MealPlan) getVisibleEntry(id, userId uint) (i mealplan.Entry, err error) {
+	i, err = s.getOwnEntry(id, userId)
+	if err != nil {
+		return
+	}
+
+	_, err = s.receiptSvc.getPermittedReceipt(i.ReceiptId, userId, receipt.ViewerRole)
+	return
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"food/src/api/database/dbtest"
+	"food/src/api/models/mealplan"
+)
+
+func TestMealPlanHidesPrivateReceipts(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	receiptSvc := GetReceiptService(db)
+	s := GetMealPlanService(db)
+
+	ownerId := dbtest.CreateUser(t, db, "owner")
+	userId := dbtest.CreateUser(t, db, "cook")
+	r := newTestReceipt(t, receiptSvc, ownerId, false)
+
+	today := time.Now().UTC().Format(mealplan.DateFormat)
+	entry, err := s.CreateEntry(userId, CreateMealPlanEntryRequest{ReceiptId: r.Id, Date: today, Slot: mealplan.DinnerSlot, Servings: 2})
+	if err != nil {
+		t.Fatalf("cannot plan receipt: %v", err)
+	}
+	if entry.Receipt == nil || entry.Receipt.Name != r.Name {
+		t.Errorf("planned receipt is not loaded: %+v", entry.Receipt)
+	}
+	token, err := s.GetCalendarToken(userId)
+	if err != nil {
+		t.Fatalf("cannot get calendar token: %v", err)
+	}
+
+	private := true
+	update := UpdateReceiptRequest(newCreateReceiptRequest(r))
+	update.Private = &private
+	_, err = receiptSvc.UpdateReceipt(r.Id, ownerId, update, "")
+	if err != nil {
+		t.Fatalf("cannot make receipt private: %v", err)
+	}
+
+	day, _ := time.Parse(mealplan.DateFormat, today)
+	entries, err := s.GetEntries(userId, day, day)
+	if err != nil {
+		t.Fatalf("cannot get entries: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("entries of the private receipt are listed: %+v", entries)
+	}
+	var calendar bytes.Buffer
+	err = s.WriteCalendar(&calendar, token)
+	if err != nil {
+		t.Fatalf("cannot write calendar: %v", err)
+	}
+	if bytes.Contains(calendar.Bytes(), []byte(r.Name)) {
+		t.Errorf("calendar shows the private receipt:\n%s", calendar.String())
+	}
+
+	_, err = s.UpdateEntry(entry.Id, userId, UpdateMealPlanEntryRequest{Date: today, Slot: mealplan.LunchSlot, Servings: 3})
+	if !isNotPermitted(err) {
+		t.Errorf("entry of the private receipt is updated, error %v", err)
+	}
+	_, err = s.CopyEntry(entry.Id, userId, CopyMealPlanEntryRequest{Date: today, Slot: mealplan.LunchSlot})
+	if !isNotPermitted(err) {
+		t.Errorf("entry of the private receipt is copied, error %v", err)
+	}
+	err = s.DeleteEntry(entry.Id, userId)
+	if err != nil {
+		t.Errorf("cannot delete entry of the private receipt: %v", err)
+	}
+
+	// the owner still sees the receipt in own plan
+	own, err := s.CreateEntry(ownerId, CreateMealPlanEntryRequest{ReceiptId: r.Id, Date: today, Slot: mealplan.DinnerSlot, Servings: 2})
+	if err != nil {
+		t.Fatalf("cannot plan own receipt: %v", err)
+	}
+	if own.Receipt == nil {
+		t.Errorf("own private receipt is not loaded")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"food/src/api/models/tools"
 	"github.com/pkg/errors"
 	"io"
+	"log"
 	"mime/multipart"
 	"os"
 	"path"
@@ -29,6 +30,19 @@ type Options struct {
 }
 
 func (s *Media) ProcessMedia(formFile *multipart.FileHeader, opts Options) (newMedia media.Media, err error) {
+	fd, err := formFile.Open()
+	if err != nil {
+		err = errors.Wrap(err, "Error occurred when open form file")
+		return
+	}
+	defer fd.Close()
+
+	newMedia, err = s.StoreMedia(fd, filepath.Ext(formFile.Filename), formFile.Header.Get("Content-Type"), opts)
+	return
+}
+
+// StoreMedia saves the content to a new upload folder and creates the media record.
+func (s *Media) StoreMedia(src io.Reader, ext string, format string, opts Options) (newMedia media.Media, err error) {
 	dateBytes, _ := time.Now().MarshalBinary()
 	hashBytes := md5.Sum(dateBytes)
 
@@ -39,7 +53,7 @@ func (s *Media) ProcessMedia(formFile *multipart.FileHeader, opts Options) (newM
 		err =errors.Wrap(err, "Error occurred when create new folder.")
 		return
 	}
-	filename := opts.Filename + filepath.Ext(formFile.Filename)
+	filename := opts.Filename + ext
 	mediaPath := path.Join(currentUploadFolder, filename)
 	filePath := path.Join(folderPath, filename)
 	newFile, err := os.Create(filePath)
@@ -47,19 +61,15 @@ func (s *Media) ProcessMedia(formFile *multipart.FileHeader, opts Options) (newM
 		err = errors.Wrap(err, "Error occurred when create new file.")
 		return
 	}
-	fd, err := formFile.Open()
-	if err != nil {
-		err = errors.Wrap(err, "Error occurred when open form file")
-		return
-	}
-	_, err = io.Copy(newFile, fd)
+	defer newFile.Close()
+
+	_, err = io.Copy(newFile, src)
 	if err != nil {
 		err = errors.Wrap(err, "Error occurred when fill file")
 		return
 	}
-	fd.Close()
 
-	newMedia = media.Media{Link: mediaPath, Format: formFile.Header.Get("Content-Type")}
+	newMedia = media.Media{Link: mediaPath, Format: format}
 	err = s.Save(&newMedia)
 
 	if err != nil {
@@ -105,7 +115,7 @@ func (s *Media) Delete(mediaId uint) (err error) {
 		return
 	}
 
-	err = os.Remove(path.Join(media.MediaFolderRoot, existingMedia.Link))
+	err = removeMediaFile(existingMedia.Link)
 	if err != nil {
 		err = errors.Wrap(err, "Error occurred when remove file")
 		return
@@ -124,3 +134,28 @@ func (s *Media) Delete(mediaId uint) (err error) {
 
 	return nil
 }
+
+// RemoveFiles removes files of media which records are already deleted.
+// Files which cannot be removed are logged and skipped.
+func (s *Media) RemoveFiles(links []string) {
+	for _, link := range links {
+		err := removeMediaFile(link)
+		if err != nil && !os.IsNotExist(err) {
+			log.Printf("remove media file `%s` error: `%s`", link, err)
+		}
+	}
+}
+
+// removeMediaFile removes the file and its upload folder when nothing else is left there.
+func removeMediaFile(link string) (err error) {
+	err = os.Remove(path.Join(media.MediaFolderRoot, link))
+	if err != nil {
+		return
+	}
+
+	if folder := path.Dir(link); folder != "." {
+		// the folder can still have other files, then it is kept
+		_ = os.Remove(path.Join(media.MediaFolderRoot, folder))
+	}
+	return
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"fmt"
+	"food/src/api/models/ingredient"
+	"food/src/api/models/pantry"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"github.com/jinzhu/gorm"
+	"strings"
+	"time"
+)
+
+// MaxExpiringWithinDays limits how far ahead expiring pantry items can be looked up
+const MaxExpiringWithinDays = 365
+
+func GetPantryService(db *gorm.DB) *Pantry {
+	return &Pantry{
+		itemRepo:       pantry.GetItemRepository(db),
+		ingredientRepo: ingredient.GetMediaRepository(db),
+		receiptRepo:    receipt.GetReceiptRepository(db),
+		receiptSvc:     GetReceiptService(db),
+	}
+}
+
+type Pantry struct {
+	itemRepo       *pantry.ItemRepository
+	ingredientRepo *ingredient.IngredientRepository
+	receiptRepo    *receipt.ReceiptRepository
+	receiptSvc     *Receipt
+}
+
+type CreatePantryItemRequest struct {
+	// (required)
+	IngredientId uint   `json:"ingredient_id" minimum:"1" binding:"required" validate:"min=1"`
+	Quantity     string `json:"quantity" example:"500 g" maxLength:"255" validate:"max=255"`
+	// YYYY-MM-DD, empty when the item does not expire
+	ExpiresAt string `json:"expires_at" example:"2019-07-10"`
+}
+
+func (u *CreatePantryItemRequest) TrimSpaces() {
+	u.Quantity = strings.TrimSpace(u.Quantity)
+	u.ExpiresAt = strings.TrimSpace(u.ExpiresAt)
+}
+
+type UpdatePantryItemRequest struct {
+	Quantity string `json:"quantity" example:"500 g" maxLength:"255" validate:"max=255"`
+	// YYYY-MM-DD, empty when the item does not expire
+	ExpiresAt string `json:"expires_at" example:"2019-07-10"`
+}
+
+func (u *UpdatePantryItemRequest) TrimSpaces() {
+	u.Quantity = strings.TrimSpace(u.Quantity)
+	u.ExpiresAt = strings.TrimSpace(u.ExpiresAt)
+}
+
+func parseExpiryDate(value string) (date *time.Time, err error) {
+	if len(value) == 0 {
+		return
+	}
+
+	parsed, err := time.Parse(pantry.DateFormat, value)
+	if err != nil {
+		err = tools.NewValidationErr(fmt.Errorf("expiry date `%s` should have format YYYY-MM-DD", value))
+		return
+	}
+	date = &parsed
+	return
+}
+
+func (s *Pantry) GetItems(userId uint) (items []pantry.Item, err error) {
+	items, err = s.itemRepo.GetAllByUserId(userId)
+	return
+}
+
+// GetExpiringItems returns items which expire within the given number of days, expired ones included.
+func (s *Pantry) GetExpiringItems(userId uint, days int) (items []pantry.Item, err error) {
+	if days < 0 || days > MaxExpiringWithinDays {
+		err = tools.NewValidationErr(fmt.Errorf("days should be between 0 and %d", MaxExpiringWithinDays))
+		return
+	}
+
+	items, err = s.itemRepo.GetExpiringByUserId(userId, time.Now().AddDate(0, 0, days))
+	return
+}
+
+func (s *Pantry) CreateItem(userId uint, request CreatePantryItemRequest) (item pantry.Item, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	expiresAt, err := parseExpiryDate(request.ExpiresAt)
+	if err != nil {
+		return
+	}
+
+	_, err = s.ingredientRepo.GetById(request.IngredientId)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("ingredient not found"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	item = pantry.Item{
+		UserId:       userId,
+		IngredientId: request.IngredientId,
+		Quantity:     request.Quantity,
+		ExpiresAt:    expiresAt,
+	}
+	err = s.itemRepo.Create(&item)
+	if err != nil {
+		return
+	}
+
+	item, err = s.itemRepo.GetById(item.Id)
+	return
+}
+
+func (s *Pantry) UpdateItem(id, userId uint, request UpdatePantryItemRequest) (item pantry.Item, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	expiresAt, err := parseExpiryDate(request.ExpiresAt)
+	if err != nil {
+		return
+	}
+
+	item, err = s.getOwnItem(id, userId)
+	if err != nil {
+		return
+	}
+
+	item.Quantity = request.Quantity
+	item.ExpiresAt = expiresAt
+	err = s.itemRepo.Update(&item)
+	if err != nil {
+		return
+	}
+
+	item, err = s.itemRepo.GetById(item.Id)
+	return
+}
+
+func (s *Pantry) DeleteItem(id, userId uint) (err error) {
+	_, err = s.getOwnItem(id, userId)
+	if _, ok := err.(*tools.ValidationErr); ok {
+		// item is already deleted
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	err = s.itemRepo.Delete(id)
+	return
+}
+
+// CookReceipt subtracts ingredients of the cooked receipt from the pantry and returns the pantry.
+// Ingredients with quantities which cannot be parsed or converted and expired stocks are left untouched.
+func (s *Pantry) CookReceipt(receiptId, userId uint) (items []pantry.Item, err error) {
+	_, err = s.receiptSvc.getPermittedReceipt(receiptId, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+
+	receiptIngredients, err := s.receiptRepo.GetIngredientsByIds([]uint{receiptId})
+	if err != nil {
+		return
+	}
+
+	stocks, err := s.itemRepo.GetByIngredientIds(userId, ingredientIds(receiptIngredients))
+	if err != nil {
+		return
+	}
+	stocksByIngredient := make(map[uint][]pantry.Item)
+	for _, stock := range stocks {
+		stocksByIngredient[stock.IngredientId] = append(stocksByIngredient[stock.IngredientId], stock)
+	}
+
+	today := time.Now()
+	changed := make(map[uint]pantry.Item)
+	usedUp := make(map[uint]bool)
+	for _, ri := range receiptIngredients {
+		need, ok := ingredient.ParseQuantity(ri.Quantity)
+		if !ok {
+			continue
+		}
+
+		changedStocks, usedUpIds, _ := pantry.Consume(stocksByIngredient[ri.IngredientId], need, today)
+		for _, stock := range changedStocks {
+			changed[stock.Id] = stock
+		}
+		for _, id := range usedUpIds {
+			usedUp[id] = true
+			delete(changed, id)
+		}
+
+		// the same ingredient can be listed in the receipt several times
+		rest := make([]pantry.Item, 0, len(stocksByIngredient[ri.IngredientId]))
+		for _, stock := range stocksByIngredient[ri.IngredientId] {
+			if usedUp[stock.Id] {
+				continue
+			}
+			if changedStock, ok := changed[stock.Id]; ok {
+				stock = changedStock
+			}
+			rest = append(rest, stock)
+		}
+		stocksByIngredient[ri.IngredientId] = rest
+	}
+
+	changedItems := make([]pantry.Item, 0, len(changed))
+	for _, stock := range changed {
+		changedItems = append(changedItems, stock)
+	}
+	usedUpIds := make([]uint, 0, len(usedUp))
+	for id := range usedUp {
+		usedUpIds = append(usedUpIds, id)
+	}
+	err = s.itemRepo.Consume(changedItems, usedUpIds)
+	if err != nil {
+		return
+	}
+
+	items, err = s.itemRepo.GetAllByUserId(userId)
+	return
+}
+
+func (s *Pantry) getOwnItem(id, userId uint) (item pantry.Item, err error) {
+	item, err = s.itemRepo.GetById(id)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if item.UserId != userId {
+		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismatch"))
+		return
+	}
+	return
+}
@@ -5,6 +5,7 @@ import (
 	"food/src/api/models/ingredient"
 	"food/src/api/models/receipt"
 	"food/src/api/models/tools"
+	"food/src/api/models/user"
 	"github.com/jinzhu/gorm"
 	"mime/multipart"
 	"strings"
@@ -14,6 +15,7 @@ func GetReceiptService(db *gorm.DB) *Receipt {
 	return &Receipt{
 		receiptRepo: receipt.GetReceiptRepository(db),
 		ingredientRepo: ingredient.GetMediaRepository(db),
+		profileRepo: user.GetProfileRepository(db),
 		mediaSvc: GetMediaService(db),
 	}
 }
@@ -21,20 +23,58 @@ func GetReceiptService(db *gorm.DB) *Receipt {
 type Receipt struct {
 	receiptRepo *receipt.ReceiptRepository
 	ingredientRepo *ingredient.IngredientRepository
+	profileRepo *user.ProfileRepository
 	mediaSvc *Media
 }
 
-func (s *Receipt) GetAllReceiptsByCategory(category string) (receipts []receipt.Receipt, err error) {
-	receipts, err = s.receiptRepo.GetAllByCategory(category)
+func (s *Receipt) GetAllReceipts(userId uint, filter receipt.ListFilter) (receipts []receipt.Receipt, err error) {
+	if !receipt.IsValidSort(filter.Sort) {
+		err = tools.NewValidationErr(fmt.Errorf("sort `%s` is not supported", filter.Sort))
+		return
+	}
+	if filter.MinRating < 0 || filter.MinRating > receipt.MaxRating {
+		err = tools.NewValidationErr(fmt.Errorf("min rating should be between 0 and %d", receipt.MaxRating))
+		return
+	}
+	err = checkDifficulty(filter.Difficulty)
+	if err != nil {
+		return
+	}
+	for _, diet := range filter.Diets {
+		if !receipt.IsValidLabel(diet) {
+			err = tools.NewValidationErr(fmt.Errorf("diet `%s` is not supported", diet))
+			return
+		}
+	}
+	for _, allergen := range filter.ExcludeAllergens {
+		if !ingredient.IsValidAllergen(allergen) {
+			err = tools.NewValidationErr(fmt.Errorf("allergen `%s` is not supported", allergen))
+			return
+		}
+	}
+	receipts, err = s.receiptRepo.GetAllVisible(userId, filter)
+	if err != nil {
+		return
+	}
+
+	err = s.markReceipts(userId, receipts)
 	return
 }
 
-func (s *Receipt) GetAllReceiptIngredientsById(id uint) (ingredients []receipt.ReceiptIngredient, err error) {
-	_, err = s.receiptRepo.GetById(id)
-	if gorm.IsRecordNotFoundError(err) {
-		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+func (s *Receipt) GetReceipt(id, userId uint) (i receipt.Receipt, err error) {
+	i, err = s.getPermittedReceipt(id, userId, receipt.ViewerRole)
+	if err != nil {
 		return
 	}
+
+	receipts := []receipt.Receipt{i}
+	err = s.markReceipts(userId, receipts)
+	i = receipts[0]
+	return
+}
+
+func (s *Receipt) GetAllReceiptIngredientsById(id, userId uint) (ingredients []receipt.ReceiptIngredient, err error) {
+	_, err = s.getPermittedReceipt(id, userId, receipt.ViewerRole)
 	if err != nil {
 		return
 	}
@@ -42,12 +82,8 @@ func (s *Receipt) GetAllReceiptIngredientsById(id uint) (ingredients []receipt.R
 	return
 }
 
-func (s *Receipt) GetAllReceiptDirectionsById(id uint) (directions []receipt.ReceiptDirection, err error) {
-	_, err = s.receiptRepo.GetById(id)
-	if gorm.IsRecordNotFoundError(err) {
-		err = tools.NewValidationErr(fmt.Errorf("item not found"))
-		return
-	}
+func (s *Receipt) GetAllReceiptDirectionsById(id, userId uint) (directions []receipt.ReceiptDirection, err error) {
+	_, err = s.getPermittedReceipt(id, userId, receipt.ViewerRole)
 	if err != nil {
 		return
 	}
@@ -63,10 +99,44 @@ func (s *Receipt) GetAllIngredients() (receipts []ingredient.Ingredient, err err
 type CreateIngredientRequest struct {
 	// (required)
 	Name    string     `json:"name" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
+	Category    string     `json:"category" maxLength:"255" validate:"max=255"`
+	// allergens
+	Gluten bool `json:"gluten"`
+	Dairy bool `json:"dairy"`
+	Eggs bool `json:"eggs"`
+	// tree nuts
+	Nuts bool `json:"nuts"`
+	Peanuts bool `json:"peanuts"`
+	Soy bool `json:"soy"`
+	Fish bool `json:"fish"`
+	Shellfish bool `json:"shellfish"`
+	Sesame bool `json:"sesame"`
+	// meat or poultry
+	Meat bool `json:"meat"`
+	// other products of animal origin, like honey
+	AnimalProduct bool `json:"animal_product"`
 }
 
 func (u *CreateIngredientRequest) TrimSpaces() {
 	u.Name = strings.TrimSpace(u.Name)
+	u.Category = strings.ToLower(strings.TrimSpace(u.Category))
+}
+
+// setIngredient copies the requested fields to the ingredient.
+func (u *CreateIngredientRequest) setIngredient(i *ingredient.Ingredient) {
+	i.Name = u.Name
+	i.Category = u.Category
+	i.Gluten = u.Gluten
+	i.Dairy = u.Dairy
+	i.Eggs = u.Eggs
+	i.Nuts = u.Nuts
+	i.Peanuts = u.Peanuts
+	i.Soy = u.Soy
+	i.Fish = u.Fish
+	i.Shellfish = u.Shellfish
+	i.Sesame = u.Sesame
+	i.Meat = u.Meat
+	i.AnimalProduct = u.AnimalProduct
 }
 
 type UpdateIngredientRequest struct {
@@ -77,34 +147,167 @@ type CreateReceiptRequest struct {
 	// (required)
 	Name    string     `json:"name" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
 	Description    string     `json:"description" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
+	// language of the name, description and directions
+	Language string `json:"language" enums:"en,uk"`
 	Category    string     `json:"category" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
-	CookingTime    int     `json:"cooking_time" minimum:"1" binding:"required" validate:"min=1"`
+	// preparation time in minutes
+	PrepTime uint `json:"prep_time" maximum:"10080" validate:"max=10080"`
+	// cooking time in minutes
+	CookTime uint `json:"cook_time" maximum:"10080" validate:"max=10080"`
+	// resting time in minutes, like cooling or marinating
+	RestTime uint `json:"rest_time" maximum:"10080" validate:"max=10080"`
+	// deprecated, total cooking time in minutes, the cook time is changed to make it up when it differs from the current total
+	CookingTime uint `json:"cooking_time" maximum:"10080" validate:"max=10080"`
+	Difficulty string `json:"difficulty" enums:"easy,medium,hard"`
+	Cuisine string `json:"cuisine" maxLength:"50" validate:"max=50"`
+	// like breakfast, main or dessert
+	Course string `json:"course" maxLength:"50" validate:"max=50"`
+	// number of servings the ingredient quantities are given for
+	Servings uint `json:"servings" maximum:"1000" validate:"max=1000"`
+	// visible to the owner and users it is shared with only, kept when omitted
+	Private *bool `json:"private"`
 }
 
 func (u *CreateReceiptRequest) TrimSpaces() {
 	u.Name = strings.TrimSpace(u.Name)
 	u.Description = strings.TrimSpace(u.Description)
 	u.Category = strings.TrimSpace(u.Category)
+	u.Language = strings.ToLower(strings.TrimSpace(u.Language))
+	u.Difficulty = strings.ToLower(strings.TrimSpace(u.Difficulty))
+	u.Cuisine = strings.TrimSpace(u.Cuisine)
+	u.Course = strings.TrimSpace(u.Course)
+}
+
+func (u *CreateReceiptRequest) setReceipt(r *receipt.Receipt) {
+	r.Name = u.Name
+	r.Description = u.Description
+	r.Language = u.Language
+	r.Category = u.Category
+	setTimes(r, u.PrepTime, u.CookTime, u.RestTime, u.CookingTime)
+	r.Difficulty = u.Difficulty
+	r.Cuisine = u.Cuisine
+	r.Course = u.Course
+	r.Servings = u.Servings
+	if u.Private != nil {
+		r.Private = *u.Private
+	}
+}
+
+func newCreateReceiptRequest(r receipt.Receipt) CreateReceiptRequest {
+	private := r.Private
+	return CreateReceiptRequest{
+		Name:        r.Name,
+		Description: r.Description,
+		Language:    r.Language,
+		Category:    r.Category,
+		PrepTime:    r.PrepTime,
+		CookTime:    r.CookTime,
+		RestTime:    r.RestTime,
+		CookingTime: r.TotalTime,
+		Difficulty:  r.Difficulty,
+		Cuisine:     r.Cuisine,
+		Course:      r.Course,
+		Servings:    r.Servings,
+		Private:     &private,
+	}
+}
+
+// checkLanguage tells whether the language is supported, empty one means it is not set.
+func checkLanguage(language string) error {
+	if len(language) > 0 && !tools.IsValidLanguage(language) {
+		return tools.NewValidationErr(fmt.Errorf("language `%s` is not supported", language))
+	}
+	return nil
+}
+
+// checkTimes requires at least one of the receipt times, the cooking time of older clients counts too.
+// The cooking time which changes the total of the receipt should leave room for the prep and rest time.
+func checkTimes(prepTime, cookTime, restTime, cookingTime, total uint) error {
+	if prepTime == 0 && cookTime == 0 && restTime == 0 && cookingTime == 0 {
+		return tools.NewValidationErr(fmt.Errorf("prep, cook or rest time is required"))
+	}
+	if cookingTime != 0 && cookingTime != total && prepTime+restTime > cookingTime {
+		return tools.NewValidationErr(fmt.Errorf("cooking time %d is less than prep and rest time", cookingTime))
+	}
+	return nil
+}
+
+// setTimes sets the prep, cook and rest time of the receipt. Older clients send the total cooking time,
+// when it is given and differs from the current total the cook time makes it up.
+func setTimes(r *receipt.Receipt, prepTime, cookTime, restTime, cookingTime uint) {
+	changed := cookingTime != 0 && cookingTime != r.TotalTime
+	r.PrepTime = prepTime
+	r.CookTime = cookTime
+	r.RestTime = restTime
+	if changed || prepTime+cookTime+restTime == 0 {
+		r.CookTime = cookingTime - prepTime - restTime
+	}
+}
+
+// checkDifficulty tells whether the difficulty level is known.
+func checkDifficulty(difficulty string) error {
+	if !receipt.IsValidDifficulty(difficulty) {
+		return tools.NewValidationErr(fmt.Errorf("difficulty `%s` should be easy, medium or hard", difficulty))
+	}
+	return nil
 }
 
 type UpdateReceiptRequest struct {
 	// (required)
 	Name    string     `json:"name" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
 	Description    string     `json:"description" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
+	// language of the name, description and directions
+	Language string `json:"language" enums:"en,uk"`
 	Category    string     `json:"category" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
-	CookingTime    int     `json:"cooking_time" minimum:"1" binding:"required" validate:"min=1"`
+	// preparation time in minutes
+	PrepTime uint `json:"prep_time" maximum:"10080" validate:"max=10080"`
+	// cooking time in minutes
+	CookTime uint `json:"cook_time" maximum:"10080" validate:"max=10080"`
+	// resting time in minutes, like cooling or marinating
+	RestTime uint `json:"rest_time" maximum:"10080" validate:"max=10080"`
+	// deprecated, total cooking time in minutes, the cook time is changed to make it up when it differs from the current total
+	CookingTime uint `json:"cooking_time" maximum:"10080" validate:"max=10080"`
+	Difficulty string `json:"difficulty" enums:"easy,medium,hard"`
+	Cuisine string `json:"cuisine" maxLength:"50" validate:"max=50"`
+	// like breakfast, main or dessert
+	Course string `json:"course" maxLength:"50" validate:"max=50"`
+	// number of servings the ingredient quantities are given for
+	Servings uint `json:"servings" maximum:"1000" validate:"max=1000"`
+	// visible to the owner and users it is shared with only, kept when omitted
+	Private *bool `json:"private"`
 }
 
 func (u *UpdateReceiptRequest) TrimSpaces() {
 	u.Name = strings.TrimSpace(u.Name)
 	u.Description = strings.TrimSpace(u.Description)
 	u.Category = strings.TrimSpace(u.Category)
+	u.Language = strings.ToLower(strings.TrimSpace(u.Language))
+	u.Difficulty = strings.ToLower(strings.TrimSpace(u.Difficulty))
+	u.Cuisine = strings.TrimSpace(u.Cuisine)
+	u.Course = strings.TrimSpace(u.Course)
+}
+
+func (u *UpdateReceiptRequest) setReceipt(r *receipt.Receipt) {
+	r.Name = u.Name
+	r.Description = u.Description
+	r.Language = u.Language
+	r.Category = u.Category
+	setTimes(r, u.PrepTime, u.CookTime, u.RestTime, u.CookingTime)
+	r.Difficulty = u.Difficulty
+	r.Cuisine = u.Cuisine
+	r.Course = u.Course
+	r.Servings = u.Servings
+	if u.Private != nil {
+		r.Private = *u.Private
+	}
 }
 
 type CreateReceiptIngredientRequest struct {
 	// (required)
 	Quantity    string     `json:"quantity" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
 	IngredientId    uint     `json:"ingredient_id" minimum:"1" binding:"required" validate:"min=1"`
+	// zero based position, ingredient is appended to the end when omitted
+	Position *uint `json:"position"`
 }
 
 func (u *CreateReceiptIngredientRequest) TrimSpaces() {
@@ -121,16 +324,69 @@ func (u *UpdateReceiptIngredientRequest) TrimSpaces() {
 }
 
 type CreateReceiptDirectionRequest struct {
+	UpdateReceiptDirectionRequest
+	// zero based position, step is appended to the end when omitted
+	Position *uint `json:"position"`
+}
+
+type UpdateReceiptDirectionRequest struct {
 	// (required)
 	Description    string     `json:"description" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
+	// step duration in minutes
+	Duration *uint `json:"duration" minimum:"1" maximum:"10080" validate:"omitempty,min=1,max=10080"`
+	// waiting step which needs no attention, like baking or resting
+	Passive bool `json:"passive"`
+	// oven temperature in Celsius degrees
+	Temperature *int `json:"temperature" minimum:"1" maximum:"500" validate:"omitempty,min=1,max=500"`
 }
 
-func (u *CreateReceiptDirectionRequest) TrimSpaces() {
+func (u *UpdateReceiptDirectionRequest) TrimSpaces() {
 	u.Description = strings.TrimSpace(u.Description)
 }
 
-type UpdateReceiptDirectionRequest struct {
-	CreateReceiptDirectionRequest
+// direction returns the step described by the request.
+func (u *UpdateReceiptDirectionRequest) direction() receipt.ReceiptDirection {
+	return receipt.ReceiptDirection{
+		Description: u.Description,
+		Duration: u.Duration,
+		Passive: u.Passive,
+		Temperature: u.Temperature,
+	}
+}
+
+type ReorderReceiptIngredientsRequest struct {
+	// all receipt ingredient ids of the receipt in the new order (required)
+	Ids []uint `json:"ids" binding:"required" validate:"required,min=1"`
+}
+
+type ReorderReceiptDirectionsRequest struct {
+	// all direction ids of the receipt in the new order (required)
+	Ids []uint `json:"ids" binding:"required" validate:"required,min=1"`
+}
+
+// checkETag fails when the client expects another version of the item, empty If-Match allows any.
+func checkETag(ifMatch string, item interface{ ETag() string }) error {
+	etag := item.ETag()
+	if len(ifMatch) == 0 || tools.MatchesStoredETag(ifMatch, etag) {
+		return nil
+	}
+	return tools.NewPreconditionFailedErr(fmt.Errorf("item was changed, its current version is %s", etag))
+}
+
+// checkMissingETag fails when the client expects a version of the item which does not exist.
+func checkMissingETag(ifMatch string) error {
+	if len(ifMatch) == 0 {
+		return nil
+	}
+	return tools.NewPreconditionFailedErr(fmt.Errorf("item does not exist"))
+}
+
+// insertPosition returns the requested position limited by the number of existing items.
+func insertPosition(requested *uint, count int) uint {
+	if requested != nil && *requested < uint(count) {
+		return *requested
+	}
+	return uint(count)
 }
 
 func (s *Receipt) CreateIngredient(ingredientRequest CreateIngredientRequest) (i ingredient.Ingredient, err error) {
@@ -140,7 +396,8 @@ func (s *Receipt) CreateIngredient(ingredientRequest CreateIngredientRequest) (i
 		err = tools.NewValidationErr(err)
 		return
 	}
-	i = ingredient.Ingredient{Name:ingredientRequest.Name}
+	i = ingredient.Ingredient{}
+	ingredientRequest.setIngredient(&i)
 	err = s.ingredientRepo.Create(&i)
 	return
 }
@@ -161,7 +418,8 @@ func (s *Receipt) UpdateIngredient(id uint, ingredientRequest UpdateIngredientRe
 	if err != nil {
 		return
 	}
-	i = ingredient.Ingredient{Id: oldItem.Id, Name: ingredientRequest.Name, CreatedAt: oldItem.CreatedAt}
+	i = ingredient.Ingredient{Id: oldItem.Id, CreatedAt: oldItem.CreatedAt}
+	ingredientRequest.setIngredient(&i)
 	err = s.ingredientRepo.Update(&i)
 	return
 }
@@ -173,15 +431,28 @@ func (s *Receipt) CreateReceipt(userId uint, request CreateReceiptRequest) (i re
 		err = tools.NewValidationErr(err)
 		return
 	}
-	i = receipt.Receipt{
-		Name: request.Name,
-		Description: request.Description,
-		Category: request.Category,
-		CookingTime: request.CookingTime,
-		UserId: userId,
-
+	err = checkTimes(request.PrepTime, request.CookTime, request.RestTime, request.CookingTime, 0)
+	if err != nil {
+		return
 	}
+	err = checkDifficulty(request.Difficulty)
+	if err != nil {
+		return
+	}
+	err = checkLanguage(request.Language)
+	if err != nil {
+		return
+	}
+
+	i = receipt.Receipt{UserId: userId}
+	request.setReceipt(&i)
 	err = s.receiptRepo.Create(&i)
+	if err != nil {
+		return
+	}
+
+	// stored times are less precise, the version is taken from the stored item
+	i, err = s.receiptRepo.GetById(i.Id)
 	return
 }
 
@@ -193,21 +464,11 @@ func (s *Receipt) CreateReceiptIngredient(receiptId, userId uint, request Create
 		return
 	}
 
-	r, err := s.receiptRepo.GetById(receiptId)
-	if gorm.IsRecordNotFoundError(err) {
-		err = tools.NewValidationErr(fmt.Errorf("item not found"))
-		return
-	}
-
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
 	if err != nil {
 		return
 	}
 
-	if r.UserId != userId {
-		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismached"))
-		return
-	}
-
 	_, err = s.ingredientRepo.GetById(request.IngredientId)
 	if gorm.IsRecordNotFoundError(err) {
 		err = tools.NewValidationErr(fmt.Errorf("item not found"))
@@ -218,44 +479,80 @@ func (s *Receipt) CreateReceiptIngredient(receiptId, userId uint, request Create
 		return
 	}
 
+	ingredients, err := s.receiptRepo.GetIngredientsById(receiptId)
+	if err != nil {
+		return
+	}
+
 	i = receipt.ReceiptIngredient{
 		Quantity: request.Quantity,
 		ReceiptId: receiptId,
 		IngredientId: request.IngredientId,
+		Position: insertPosition(request.Position, len(ingredients)),
 	}
 	err = s.receiptRepo.CreateIngredient(&i)
+	if err != nil {
+		return
+	}
+
+	i, err = s.receiptRepo.GetIngredientById(i.Id)
 	return
 }
 
-func (s *Receipt) UpdateReceiptIngredient(receiptId, rIngredientId, userId uint, request UpdateReceiptIngredientRequest) (i receipt.ReceiptIngredient, err error) {
-	request.TrimSpaces()
+func (s *Receipt) ReorderReceiptIngredients(receiptId, userId uint, request ReorderReceiptIngredientsRequest) (ingredients []receipt.ReceiptIngredient, err error) {
 	err = tools.Validator.Struct(request)
 	if err != nil {
 		err = tools.NewValidationErr(err)
 		return
 	}
 
-	r, err := s.receiptRepo.GetById(receiptId)
-	if gorm.IsRecordNotFoundError(err) {
-		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
 		return
 	}
 
+	ingredients, err = s.receiptRepo.GetIngredientsById(receiptId)
 	if err != nil {
 		return
 	}
 
-	if r.UserId != userId {
-		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismached"))
+	ids := make([]uint, 0, len(ingredients))
+	for _, item := range ingredients {
+		ids = append(ids, item.Id)
+	}
+	if !isPermutation(ids, request.Ids) {
+		err = tools.NewValidationErr(fmt.Errorf("ids should contain every ingredient of the receipt exactly once"))
 		return
 	}
 
-	_, err = s.receiptRepo.GetIngredientById(rIngredientId)
-	if gorm.IsRecordNotFoundError(err) {
-		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+	err = s.receiptRepo.ReorderIngredients(receiptId, request.Ids)
+	if err != nil {
+		return
+	}
+
+	ingredients, err = s.receiptRepo.GetIngredientsById(receiptId)
+	return
+}
+
+func (s *Receipt) UpdateReceiptIngredient(receiptId, rIngredientId, userId uint, request UpdateReceiptIngredientRequest, ifMatch string) (i receipt.ReceiptIngredient, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
 		return
 	}
 
+	oldIngredient, err := s.getReceiptIngredient(receiptId, rIngredientId)
+	if err != nil {
+		return
+	}
+
+	err = checkETag(ifMatch, oldIngredient)
 	if err != nil {
 		return
 	}
@@ -273,10 +570,15 @@ func (s *Receipt) UpdateReceiptIngredient(receiptId, rIngredientId, userId uint,
 	return
 }
 
-func (s *Receipt) DeleteReceiptIngredient(receiptId, rIngredientId, userId uint) (err error) {
-	r, err := s.receiptRepo.GetById(receiptId)
+func (s *Receipt) DeleteReceiptIngredient(receiptId, rIngredientId, userId uint, ifMatch string) (err error) {
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
+		return
+	}
+
+	oldIngredient, err := s.receiptRepo.GetIngredientById(rIngredientId)
 	if gorm.IsRecordNotFoundError(err) {
-		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		err = checkMissingETag(ifMatch)
 		return
 	}
 
@@ -284,8 +586,13 @@ func (s *Receipt) DeleteReceiptIngredient(receiptId, rIngredientId, userId uint)
 		return
 	}
 
-	if r.UserId != userId {
-		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismached"))
+	if oldIngredient.ReceiptId != receiptId {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+
+	err = checkETag(ifMatch, oldIngredient)
+	if err != nil {
 		return
 	}
 
@@ -311,83 +618,116 @@ func (s *Receipt) CreateReceiptDirection(receiptId, userId uint, request CreateR
 		return
 	}
 
-	r, err := s.receiptRepo.GetById(receiptId)
-	if gorm.IsRecordNotFoundError(err) {
-		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
 		return
 	}
 
+	directions, err := s.receiptRepo.GetDirectionsById(receiptId)
 	if err != nil {
 		return
 	}
 
-	if r.UserId != userId {
-		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismached"))
+	i = request.direction()
+	i.ReceiptId = receiptId
+	i.Position = insertPosition(request.Position, len(directions))
+	err = s.receiptRepo.CreateDirection(&i)
+	if err != nil {
 		return
 	}
 
+	err = s.receiptRepo.RefreshTimes(receiptId)
 	if err != nil {
 		return
 	}
 
-	i = receipt.ReceiptDirection{
-		ReceiptId: receiptId,
-		Description: request.Description,
-	}
-	err = s.receiptRepo.CreateDirection(&i)
+	i, err = s.receiptRepo.GetDirectionById(i.Id)
 	return
 }
 
-func (s *Receipt) UpdateReceiptDirection(receiptId, rDirectionId, userId uint, request UpdateReceiptDirectionRequest) (i receipt.ReceiptDirection, err error) {
-	request.TrimSpaces()
+func (s *Receipt) ReorderReceiptDirections(receiptId, userId uint, request ReorderReceiptDirectionsRequest) (directions []receipt.ReceiptDirection, err error) {
 	err = tools.Validator.Struct(request)
 	if err != nil {
 		err = tools.NewValidationErr(err)
 		return
 	}
 
-	r, err := s.receiptRepo.GetById(receiptId)
-	if gorm.IsRecordNotFoundError(err) {
-		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
 		return
 	}
 
+	directions, err = s.receiptRepo.GetDirectionsById(receiptId)
 	if err != nil {
 		return
 	}
 
-	if r.UserId != userId {
-		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismached"))
+	ids := make([]uint, 0, len(directions))
+	for _, item := range directions {
+		ids = append(ids, item.Id)
+	}
+	if !isPermutation(ids, request.Ids) {
+		err = tools.NewValidationErr(fmt.Errorf("ids should contain every direction of the receipt exactly once"))
 		return
 	}
 
-	_, err = s.receiptRepo.GetDirectionById(rDirectionId)
-	if gorm.IsRecordNotFoundError(err) {
-		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+	err = s.receiptRepo.ReorderDirections(receiptId, request.Ids)
+	if err != nil {
 		return
 	}
 
+	directions, err = s.receiptRepo.GetDirectionsById(receiptId)
+	return
+}
+
+func (s *Receipt) UpdateReceiptDirection(receiptId, rDirectionId, userId uint, request UpdateReceiptDirectionRequest, ifMatch string) (i receipt.ReceiptDirection, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
 	if err != nil {
+		err = tools.NewValidationErr(err)
 		return
 	}
 
-	i = receipt.ReceiptDirection{
-		Id:       rDirectionId,
-		Description: request.Description,
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
+		return
 	}
+
+	oldDirection, err := s.getReceiptDirection(receiptId, rDirectionId)
+	if err != nil {
+		return
+	}
+
+	err = checkETag(ifMatch, oldDirection)
+	if err != nil {
+		return
+	}
+
+	i = request.direction()
+	i.Id = rDirectionId
 	err = s.receiptRepo.UpdateDirection(&i)
 	if err != nil {
 		return
 	}
 
+	err = s.receiptRepo.RefreshTimes(receiptId)
+	if err != nil {
+		return
+	}
+
 	i, err = s.receiptRepo.GetDirectionById(i.Id)
 	return
 }
 
-func (s *Receipt) DeleteReceiptDirection(receiptId, rDirectionId, userId uint) (err error) {
-	r, err := s.receiptRepo.GetById(receiptId)
+func (s *Receipt) DeleteReceiptDirection(receiptId, rDirectionId, userId uint, ifMatch string) (err error) {
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
+		return
+	}
+
+	oldDirection, err := s.receiptRepo.GetDirectionById(rDirectionId)
 	if gorm.IsRecordNotFoundError(err) {
-		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		err = checkMissingETag(ifMatch)
 		return
 	}
 
@@ -395,8 +735,13 @@ func (s *Receipt) DeleteReceiptDirection(receiptId, rDirectionId, userId uint) (
 		return
 	}
 
-	if r.UserId != userId {
-		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismached"))
+	if oldDirection.ReceiptId != receiptId {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+
+	err = checkETag(ifMatch, oldDirection)
+	if err != nil {
 		return
 	}
 
@@ -409,82 +754,132 @@ func (s *Receipt) DeleteReceiptDirection(receiptId, rDirectionId, userId uint) (
 	if err != nil {
 		return
 	}
+
+	err = s.receiptRepo.RefreshTimes(receiptId)
 	return
 }
 
-func (s *Receipt) UpdateReceipt(id uint, userId uint, request UpdateReceiptRequest) (i receipt.Receipt, err error) {
-	request.TrimSpaces()
-	err = tools.Validator.Struct(request)
+func (s *Receipt) UpdateReceiptDirectionMedia(receiptId, rDirectionId, userId uint, formFile *multipart.FileHeader, opts Options) (i receipt.ReceiptDirection, err error) {
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
 	if err != nil {
-		err = tools.NewValidationErr(err)
 		return
 	}
 
-	oldItem, err := s.receiptRepo.GetById(id)
-	if gorm.IsRecordNotFoundError(err) {
-		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+	_, err = s.getReceiptDirection(receiptId, rDirectionId)
+	if err != nil {
 		return
 	}
+
+	newMedia, err := s.mediaSvc.ProcessMedia(formFile, opts)
 	if err != nil {
 		return
 	}
 
-	if oldItem.UserId != userId {
-		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismached"))
+	err = s.receiptRepo.UpdateDirectionMedia(rDirectionId, newMedia.Id)
+	if err != nil {
 		return
 	}
-	i = oldItem
-	i.Name = request.Name
-	i.Description = request.Description
-	i.Category = request.Category
-	i.CookingTime = request.CookingTime
-	err = s.receiptRepo.Update(&i)
+
+	i, err = s.receiptRepo.GetDirectionById(rDirectionId)
 	return
 }
 
-func (s *Receipt) UpdateReceiptMedia(id uint, userId uint, formFile *multipart.FileHeader, opts Options) (err error) {
+func (s *Receipt) UpdateReceipt(id uint, userId uint, request UpdateReceiptRequest, ifMatch string) (i receipt.Receipt, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
 
-	oldItem, err := s.receiptRepo.GetById(id)
-	if gorm.IsRecordNotFoundError(err) {
-		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+	oldItem, err := s.getPermittedReceipt(id, userId, receipt.EditorRole)
+	if err != nil {
+		return
+	}
+	err = checkETag(ifMatch, oldItem)
+	if err != nil {
 		return
 	}
+
+	return s.updateReceipt(oldItem, request, userId)
+}
+
+// updateReceipt saves the request fields to the existing receipt.
+func (s *Receipt) updateReceipt(oldItem receipt.Receipt, request UpdateReceiptRequest, userId uint) (i receipt.Receipt, err error) {
+	err = checkPrivacyChange(oldItem, request.Private, userId)
+	if err != nil {
+		return
+	}
+	err = checkTimes(request.PrepTime, request.CookTime, request.RestTime, request.CookingTime, oldItem.TotalTime)
+	if err != nil {
+		return
+	}
+	err = checkDifficulty(request.Difficulty)
+	if err != nil {
+		return
+	}
+	err = checkLanguage(request.Language)
 	if err != nil {
 		return
 	}
 
-	if oldItem.UserId != userId {
-		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismached"))
+	i = oldItem
+	request.setReceipt(&i)
+	err = s.receiptRepo.Update(&i)
+	if err != nil {
 		return
 	}
 
-	newMedia, err := s.mediaSvc.ProcessMedia(formFile, opts)
+	i, err = s.receiptRepo.GetById(oldItem.Id)
 	if err != nil {
 		return
 	}
 
-	oldItem.MediaId = &newMedia.Id
-	err = s.receiptRepo.Update(&oldItem)
+	receipts := []receipt.Receipt{i}
+	err = s.markReceipts(userId, receipts)
+	i = receipts[0]
+	return
+}
 
+// UpdateReceiptMedia adds the image to the receipt gallery and makes it the cover.
+func (s *Receipt) UpdateReceiptMedia(id uint, userId uint, formFile *multipart.FileHeader, opts Options) (err error) {
+	_, err = s.AddReceiptImage(id, userId, formFile, opts, AddReceiptImageRequest{Cover: true})
 	return
 }
 
-func (s *Receipt) DeleteReceipt(id uint, userId uint) (err error) {
-	oldItem, err := s.receiptRepo.GetById(id)
-	if gorm.IsRecordNotFoundError(err) {
-		err = nil
+// DeleteReceipt moves the receipt with its ingredients, directions and images to trash.
+// Permanent deletion removes them together with the media files and everything referencing
+// the receipt, the receipt can be in trash already.
+func (s *Receipt) DeleteReceipt(id uint, userId uint, permanent bool, ifMatch string) (err error) {
+	oldItem, err := s.getPermittedReceipt(id, userId, receipt.OwnerRole)
+	if _, ok := err.(*tools.ValidationErr); ok {
+		// receipt is already deleted
+		err = checkMissingETag(ifMatch)
+		if err == nil && permanent {
+			err = s.PurgeReceipt(id, userId)
+			if _, ok := err.(*tools.ValidationErr); ok {
+				err = nil
+			}
+		}
 		return
 	}
-
-	if oldItem.UserId != userId {
-		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismatch"))
+	if err != nil {
 		return
 	}
- 	if err != nil {
+	err = checkETag(ifMatch, oldItem)
+	if err != nil {
 		return
 	}
 
-	err = s.receiptRepo.Delete(oldItem.Id)
+	if !permanent {
+		err = s.receiptRepo.Delete(oldItem.Id)
+		return
+	}
 
+	links, err := s.receiptRepo.Purge(oldItem.Id)
+	if err != nil {
+		return
+	}
+	s.mediaSvc.RemoveFiles(links)
 	return
 }
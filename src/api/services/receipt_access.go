@@ -0,0 +1,141 @@
+package services
+
+import (
+	"fmt"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"github.com/jinzhu/gorm"
+)
+
+type GrantReceiptAccessRequest struct {
+	// viewer or editor (required)
+	Role receipt.AccessRole `json:"role" enums:"viewer,editor" binding:"required" validate:"required"`
+}
+
+// GetReceiptRole returns the role of the user for the given receipt.
+// Empty role means that the user has no access at all.
+func (s *Receipt) GetReceiptRole(r receipt.Receipt, userId uint) (role receipt.AccessRole, err error) {
+	if r.UserId == userId {
+		role = receipt.OwnerRole
+		return
+	}
+
+	access, err := s.receiptRepo.GetAccess(r.Id, userId)
+	if gorm.IsRecordNotFoundError(err) {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	role = access.Role
+	return
+}
+
+// getPermittedReceipt is the central permission check of the receipt service.
+// It loads the receipt and makes sure the user has at least the required role for it,
+// anyone can view public receipts.
+func (s *Receipt) getPermittedReceipt(id, userId uint, required receipt.AccessRole) (r receipt.Receipt, err error) {
+	r, err = s.receiptRepo.GetById(id)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if required == receipt.ViewerRole && !r.Private {
+		return
+	}
+
+	role, err := s.GetReceiptRole(r, userId)
+	if err != nil {
+		return
+	}
+
+	if !role.Allows(required) {
+		err = tools.NewNotPermittedErr(fmt.Errorf("user `%d` has no `%s` access to receipt `%d`", userId, required, id))
+		return
+	}
+	return
+}
+
+func (s *Receipt) GetReceiptAccesses(receiptId, userId uint) (accesses []receipt.ReceiptAccess, err error) {
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.OwnerRole)
+	if err != nil {
+		return
+	}
+
+	accesses, err = s.receiptRepo.GetAccessesById(receiptId)
+	return
+}
+
+func (s *Receipt) GrantReceiptAccess(receiptId, granteeId, userId uint, request GrantReceiptAccessRequest) (access receipt.ReceiptAccess, err error) {
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	if !request.Role.IsGrantable() {
+		err = tools.NewValidationErr(fmt.Errorf("role `%s` cannot be granted", request.Role))
+		return
+	}
+
+	r, err := s.getPermittedReceipt(receiptId, userId, receipt.OwnerRole)
+	if err != nil {
+		return
+	}
+
+	if r.UserId == granteeId {
+		err = tools.NewValidationErr(fmt.Errorf("owner cannot be granted access to own receipt"))
+		return
+	}
+
+	_, err = s.profileRepo.GetById(granteeId)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("user not found"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	access, err = s.receiptRepo.GetAccess(receiptId, granteeId)
+	if gorm.IsRecordNotFoundError(err) {
+		access = receipt.ReceiptAccess{
+			ReceiptId: receiptId,
+			UserId:    granteeId,
+			Role:      request.Role,
+		}
+		err = s.receiptRepo.CreateAccess(&access)
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	access.Role = request.Role
+	err = s.receiptRepo.UpdateAccess(&access)
+	return
+}
+
+// checkPrivacyChange makes sure only the owner makes the receipt private or public.
+func checkPrivacyChange(r receipt.Receipt, private *bool, userId uint) error {
+	if private == nil || *private == r.Private || r.UserId == userId {
+		return nil
+	}
+	return tools.NewNotPermittedErr(fmt.Errorf("user `%d` cannot change privacy of receipt `%d`", userId, r.Id))
+}
+
+func (s *Receipt) RevokeReceiptAccess(receiptId, granteeId, userId uint) (err error) {
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.OwnerRole)
+	if err != nil {
+		return
+	}
+
+	err = s.receiptRepo.DeleteAccess(receiptId, granteeId)
+	return
+}
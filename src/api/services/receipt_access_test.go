@@ -0,0 +1,138 @@
+package services
+
+import (
+	"testing"
+
+	"food/src/api/database/dbtest"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+)
+
+func newTestReceipt(t *testing.T, s *Receipt, userId uint, private bool) receipt.Receipt {
+	r, err := s.CreateReceipt(userId, CreateReceiptRequest{
+		Name:        "Borscht",
+		Description: "Beetroot soup",
+		Category:    "soups",
+		CookTime:    60,
+		Private:     &private,
+	})
+	if err != nil {
+		t.Fatalf("cannot create receipt: %v", err)
+	}
+	return r
+}
+
+func isNotPermitted(err error) bool {
+	_, ok := err.(*tools.NotPermittedErr)
+	return ok
+}
+
+func isValidationErr(err error) bool {
+	_, ok := err.(*tools.ValidationErr)
+	return ok
+}
+
+func TestReceiptAccessRoles(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	s := GetReceiptService(db)
+
+	ownerId := dbtest.CreateUser(t, db, "owner")
+	viewerId := dbtest.CreateUser(t, db, "viewer")
+	editorId := dbtest.CreateUser(t, db, "editor")
+	strangerId := dbtest.CreateUser(t, db, "stranger")
+
+	r := newTestReceipt(t, s, ownerId, true)
+	_, err := s.GrantReceiptAccess(r.Id, viewerId, ownerId, GrantReceiptAccessRequest{Role: receipt.ViewerRole})
+	if err != nil {
+		t.Fatalf("cannot grant viewer access: %v", err)
+	}
+	_, err = s.GrantReceiptAccess(r.Id, editorId, ownerId, GrantReceiptAccessRequest{Role: receipt.EditorRole})
+	if err != nil {
+		t.Fatalf("cannot grant editor access: %v", err)
+	}
+
+	update := UpdateReceiptRequest(newCreateReceiptRequest(r))
+	update.Name = "Green borscht"
+	tests := []struct {
+		name      string
+		userId    uint
+		canView   bool
+		canEdit   bool
+		canManage bool
+	}{
+		{"owner", ownerId, true, true, true},
+		{"editor", editorId, true, true, false},
+		{"viewer", viewerId, true, false, false},
+		{"stranger", strangerId, false, false, false},
+	}
+	for _, test := range tests {
+		_, err = s.GetReceipt(r.Id, test.userId)
+		if (err == nil) != test.canView || (err != nil && !isNotPermitted(err)) {
+			t.Errorf("%s: view error %v, expected view %t", test.name, err, test.canView)
+		}
+		_, err = s.UpdateReceipt(r.Id, test.userId, update, "")
+		if (err == nil) != test.canEdit || (err != nil && !isNotPermitted(err)) {
+			t.Errorf("%s: update error %v, expected edit %t", test.name, err, test.canEdit)
+		}
+		_, err = s.GetReceiptAccesses(r.Id, test.userId)
+		if (err == nil) != test.canManage || (err != nil && !isNotPermitted(err)) {
+			t.Errorf("%s: access list error %v, expected manage %t", test.name, err, test.canManage)
+		}
+	}
+
+	_, err = s.GrantReceiptAccess(r.Id, strangerId, editorId, GrantReceiptAccessRequest{Role: receipt.ViewerRole})
+	if !isNotPermitted(err) {
+		t.Errorf("editor granted access, error %v", err)
+	}
+
+	public := false
+	update.Private = &public
+	_, err = s.UpdateReceipt(r.Id, editorId, update, "")
+	if !isNotPermitted(err) {
+		t.Errorf("editor made receipt public, error %v", err)
+	}
+
+	err = s.RevokeReceiptAccess(r.Id, viewerId, ownerId)
+	if err != nil {
+		t.Fatalf("cannot revoke access: %v", err)
+	}
+	_, err = s.GetReceipt(r.Id, viewerId)
+	if !isNotPermitted(err) {
+		t.Errorf("revoked viewer can see receipt, error %v", err)
+	}
+}
+
+func TestPublicReceiptVisibility(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	s := GetReceiptService(db)
+
+	ownerId := dbtest.CreateUser(t, db, "owner")
+	strangerId := dbtest.CreateUser(t, db, "stranger")
+	public := newTestReceipt(t, s, ownerId, false)
+	private := newTestReceipt(t, s, ownerId, true)
+
+	receipts, err := s.GetAllReceipts(strangerId, receipt.ListFilter{})
+	if err != nil {
+		t.Fatalf("cannot list receipts: %v", err)
+	}
+	if len(receipts) != 1 || receipts[0].Id != public.Id {
+		t.Errorf("stranger sees %v, expected public receipt %d only", receipts, public.Id)
+	}
+
+	_, err = s.GetAllReceiptIngredientsById(public.Id, strangerId)
+	if err != nil {
+		t.Errorf("stranger cannot see ingredients of public receipt: %v", err)
+	}
+	_, err = s.GetAllReceiptDirectionsById(private.Id, strangerId)
+	if !isNotPermitted(err) {
+		t.Errorf("stranger sees directions of private receipt, error %v", err)
+	}
+
+	update := UpdateReceiptRequest(newCreateReceiptRequest(public))
+	_, err = s.UpdateReceipt(public.Id, strangerId, update, "")
+	if !isNotPermitted(err) {
+		t.Errorf("stranger updated public receipt, error %v", err)
+	}
+}
@@ -0,0 +1,550 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"food/src/api/models/ingredient"
+	"food/src/api/models/media"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	MaxBulkImportRecords       = 1000
+	DefaultBulkImportBatchSize = 50
+	MaxBulkImportBatchSize     = 500
+
+	// files of bulk archives
+	bulkArchiveDocument = "receipts.json"
+	bulkArchiveSheet    = "receipts.csv"
+	bulkArchiveImages   = "images"
+
+	BulkExportZip  = "zip"
+	BulkExportJSON = "json"
+	BulkExportCSV  = "csv"
+)
+
+// statuses of bulk import records
+const (
+	BulkRecordValid    = "valid"
+	BulkRecordImported = "imported"
+	BulkRecordInvalid  = "invalid"
+	BulkRecordFailed   = "failed"
+)
+
+func GetReceiptBulkService(db *gorm.DB) *ReceiptBulk {
+	return &ReceiptBulk{
+		receiptRepo: receipt.GetReceiptRepository(db),
+		receiptSvc:  GetReceiptService(db),
+		mediaSvc:    GetMediaService(db),
+	}
+}
+
+type ReceiptBulk struct {
+	receiptRepo *receipt.ReceiptRepository
+	receiptSvc  *Receipt
+	mediaSvc    *Media
+}
+
+type BulkReceiptImage struct {
+	// path of the image file in the zip archive (required)
+	File    string `json:"file" example:"images/12/1.jpg" validate:"required"`
+	Caption string `json:"caption" maxLength:"255" validate:"max=255"`
+}
+
+// BulkReceiptRecord is the receipt of bulk import and export documents.
+// Ingredients are referenced by name, so records can be moved between accounts.
+type BulkReceiptRecord struct {
+	FullReceiptRequest
+	// gallery of zip archives in its order, the first image is the cover
+	Images []BulkReceiptImage `json:"images" validate:"dive"`
+}
+
+type BulkImportOptions struct {
+	// only validate the records
+	DryRun bool
+	// number of receipts saved in one transaction
+	BatchSize int
+}
+
+type BulkImportRecordResult struct {
+	// zero based index of the record in the document
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	// valid, imported, invalid or failed
+	Status    string   `json:"status" example:"imported"`
+	ReceiptId uint     `json:"receipt_id,omitempty"`
+	Errors    []string `json:"errors,omitempty"`
+	// likely duplicates of receipts in the account or of previous records, they are imported anyway
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type BulkImportResult struct {
+	DryRun bool `json:"dry_run"`
+	Total  int  `json:"total"`
+	// number of valid records for dry runs
+	Imported int                      `json:"imported"`
+	Failed   int                      `json:"failed"`
+	Records  []BulkImportRecordResult `json:"records"`
+}
+
+// ImportArchive imports the zip archive with receipts.json and the image files it references.
+func (s *ReceiptBulk) ImportArchive(userId uint, data []byte, options BulkImportOptions) (result BulkImportResult, err error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		err = tools.NewValidationErr(fmt.Errorf("archive cannot be read: %s", err))
+		return
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[path.Clean(file.Name)] = file
+	}
+
+	document, ok := files[bulkArchiveDocument]
+	if !ok {
+		err = tools.NewValidationErr(fmt.Errorf("archive should contain %s", bulkArchiveDocument))
+		return
+	}
+	content, err := readArchiveFile(document, maxImportPageSize)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	var records []BulkReceiptRecord
+	err = json.Unmarshal(content, &records)
+	if err != nil {
+		err = tools.NewValidationErr(fmt.Errorf("%s should be an array of receipts: %s", bulkArchiveDocument, err))
+		return
+	}
+
+	return s.Import(userId, records, files, options)
+}
+
+// Import validates all records and saves valid ones in batches, every batch in one transaction.
+// Images are added after their batch is saved, failed images are reported but keep the receipt.
+// files are archive entries by their path, records of JSON documents have no files.
+func (s *ReceiptBulk) Import(userId uint, records []BulkReceiptRecord, files map[string]*zip.File, options BulkImportOptions) (result BulkImportResult, err error) {
+	if len(records) == 0 {
+		err = tools.NewValidationErr(fmt.Errorf("there are no receipts to import"))
+		return
+	}
+	if len(records) > MaxBulkImportRecords {
+		err = tools.NewValidationErr(fmt.Errorf("at most %d receipts can be imported at once", MaxBulkImportRecords))
+		return
+	}
+	if options.BatchSize == 0 {
+		options.BatchSize = DefaultBulkImportBatchSize
+	}
+	if options.BatchSize < 1 || options.BatchSize > MaxBulkImportBatchSize {
+		err = tools.NewValidationErr(fmt.Errorf("batch size should be between 1 and %d", MaxBulkImportBatchSize))
+		return
+	}
+
+	// receipts of the account are read before the import, so imported ones are not compared to themselves
+	existing, _, err := s.receiptSvc.getFingerprints(userId)
+	if err != nil {
+		return
+	}
+
+	result = BulkImportResult{DryRun: options.DryRun, Total: len(records)}
+	for k := range records {
+		result.Records = append(result.Records, BulkImportRecordResult{Index: k, Name: records[k].Name})
+	}
+
+	fingerprints := make([]*receipt.Fingerprint, len(records))
+	for start := 0; start < len(records); start += options.BatchSize {
+		end := start + options.BatchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		err = s.importBatch(userId, records, files, options.DryRun, start, end, &result, fingerprints)
+		if err != nil {
+			return
+		}
+	}
+	warnDuplicates(&result, existing, fingerprints)
+
+	for _, item := range result.Records {
+		switch item.Status {
+		case BulkRecordValid, BulkRecordImported:
+			result.Imported++
+		default:
+			result.Failed++
+		}
+	}
+	return
+}
+
+// warnDuplicates adds warnings to the records which are likely duplicates of existing receipts
+// or of valid records before them.
+func warnDuplicates(result *BulkImportResult, existing []receipt.Fingerprint, fingerprints []*receipt.Fingerprint) {
+	for k, f := range fingerprints {
+		if f == nil {
+			continue
+		}
+		item := &result.Records[k]
+		for _, duplicate := range findDuplicates(*f, existing) {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("likely duplicate of receipt `%d` `%s`", duplicate.ReceiptId, duplicate.Name))
+		}
+		for j := 0; j < k; j++ {
+			if fingerprints[j] != nil && f.Similarity(*fingerprints[j]) >= receipt.DuplicateThreshold {
+				item.Warnings = append(item.Warnings, fmt.Sprintf("likely duplicate of record %d `%s`", j, fingerprints[j].Name))
+			}
+		}
+	}
+}
+
+// importBatch saves valid records of the batch, fingerprints of the valid records are set
+// so duplicates can be found once all batches are done.
+func (s *ReceiptBulk) importBatch(userId uint, records []BulkReceiptRecord, files map[string]*zip.File, dryRun bool, start, end int, result *BulkImportResult, fingerprints []*receipt.Fingerprint) (err error) {
+	created := map[string]*ingredient.Ingredient{}
+	batch := []receipt.FullReceipt{}
+	indexes := []int{}
+	for k := start; k < end; k++ {
+		record := &records[k]
+		item := &result.Records[k]
+
+		ingredients, directions, prepareErr := s.receiptSvc.prepareFullReceipt(&record.FullReceiptRequest, 0, created)
+		if _, ok := errors.Cause(prepareErr).(*tools.ValidationErr); ok {
+			item.Errors = append(item.Errors, prepareErr.Error())
+		} else if prepareErr != nil {
+			return prepareErr
+		}
+		item.Errors = append(item.Errors, validateBulkImages(record.Images, files)...)
+
+		if len(item.Errors) > 0 {
+			item.Status = BulkRecordInvalid
+			continue
+		}
+
+		full := receipt.FullReceipt{
+			Receipt:     receipt.Receipt{UserId: userId},
+			Ingredients: ingredients,
+			Directions:  directions,
+		}
+		record.setReceipt(&full.Receipt)
+		f := receipt.NewFingerprint(full)
+		fingerprints[k] = &f
+		if dryRun {
+			item.Status = BulkRecordValid
+			continue
+		}
+
+		batch = append(batch, full)
+		indexes = append(indexes, k)
+	}
+	if len(batch) == 0 {
+		return
+	}
+
+	saveErr := s.receiptRepo.SaveFullBatch(batch)
+	if saveErr != nil {
+		log.Printf("bulk import batch error: `%s`", saveErr)
+		for _, k := range indexes {
+			result.Records[k].Status = BulkRecordFailed
+			fingerprints[k] = nil
+			result.Records[k].Errors = append(result.Records[k].Errors, "receipts of the batch cannot be saved")
+		}
+		return
+	}
+
+	for n, k := range indexes {
+		item := &result.Records[k]
+		item.Status = BulkRecordImported
+		item.ReceiptId = batch[n].Id
+		for _, image := range records[k].Images {
+			imageErr := s.importImage(batch[n].Id, files[path.Clean(image.File)], image.Caption)
+			if imageErr != nil {
+				log.Printf("bulk import image `%s` error: `%s`", image.File, imageErr)
+				item.Errors = append(item.Errors, fmt.Sprintf("image `%s` cannot be saved", image.File))
+			}
+		}
+	}
+	return
+}
+
+// validateBulkImages checks that image files are in the archive and are not too large.
+func validateBulkImages(images []BulkReceiptImage, files map[string]*zip.File) (errs []string) {
+	if len(images) > 0 && files == nil {
+		return []string{"images can be imported with zip archives only"}
+	}
+	for _, image := range images {
+		err := tools.Validator.Struct(image)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		file, ok := files[path.Clean(image.File)]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("image `%s` is not in the archive", image.File))
+			continue
+		}
+		if file.UncompressedSize64 > maxImportImageSize {
+			errs = append(errs, fmt.Sprintf("image `%s` is larger than %d bytes", image.File, maxImportImageSize))
+			continue
+		}
+		if !strings.HasPrefix(mime.TypeByExtension(strings.ToLower(path.Ext(file.Name))), "image/") {
+			errs = append(errs, fmt.Sprintf("image `%s` should have an image extension", image.File))
+		}
+	}
+	return
+}
+
+func (s *ReceiptBulk) importImage(receiptId uint, file *zip.File, caption string) (err error) {
+	data, err := readArchiveFile(file, maxImportImageSize)
+	if err != nil {
+		return
+	}
+	format := http.DetectContentType(data)
+	if !strings.HasPrefix(format, "image/") {
+		err = fmt.Errorf("`%s` is not an image", file.Name)
+		return
+	}
+
+	newMedia, err := s.mediaSvc.StoreMedia(bytes.NewReader(data), strings.ToLower(path.Ext(file.Name)), format, Options{Filename: "dish"})
+	if err != nil {
+		err = errors.Wrap(err, "Error occurred when store imported image")
+		return
+	}
+
+	// the receipt is reloaded since the first image becomes its cover
+	r, err := s.receiptRepo.GetById(receiptId)
+	if err != nil {
+		return
+	}
+	_, err = s.receiptSvc.createReceiptImage(r, newMedia.Id, AddReceiptImageRequest{Caption: tools.Truncate(caption, tools.MaxRegularStringLength)})
+	return
+}
+
+func readArchiveFile(file *zip.File, limit int64) (data []byte, err error) {
+	if file.UncompressedSize64 > uint64(limit) {
+		err = fmt.Errorf("`%s` is larger than %d bytes", file.Name, limit)
+		return
+	}
+	fd, err := file.Open()
+	if err != nil {
+		return
+	}
+	defer fd.Close()
+	return readLimited(fd, limit)
+}
+
+// Export writes all receipts of the user as zip archive with images, JSON document or CSV sheet.
+// Zip archives can be imported back.
+func (s *ReceiptBulk) Export(w io.Writer, userId uint, format string) (err error) {
+	if format != BulkExportZip && format != BulkExportJSON && format != BulkExportCSV {
+		err = tools.NewValidationErr(fmt.Errorf("export format `%s` is not supported", format))
+		return
+	}
+
+	receipts, err := s.receiptRepo.GetAllByUserId(userId)
+	if err != nil {
+		return
+	}
+	full := make([]receipt.FullReceipt, 0, len(receipts))
+	for _, r := range receipts {
+		var i receipt.FullReceipt
+		i, err = s.receiptSvc.loadFullReceipt(r, userId)
+		if err != nil {
+			return
+		}
+		full = append(full, i)
+	}
+
+	switch format {
+	case BulkExportJSON:
+		err = json.NewEncoder(w).Encode(newBulkReceiptRecords(full, nil))
+	case BulkExportCSV:
+		err = writeBulkSheet(w, full)
+	default:
+		err = writeBulkArchive(w, full)
+	}
+	return
+}
+
+// newBulkReceiptRecords maps receipts to import records, images are referenced
+// by their paths when they are among the archive files.
+func newBulkReceiptRecords(receipts []receipt.FullReceipt, files map[string]bool) []BulkReceiptRecord {
+	records := make([]BulkReceiptRecord, 0, len(receipts))
+	for _, r := range receipts {
+		record := BulkReceiptRecord{
+			FullReceiptRequest: FullReceiptRequest{
+				CreateReceiptRequest: newCreateReceiptRequest(r.Receipt),
+				Ingredients: []FullReceiptIngredientRequest{},
+				Directions:  []FullReceiptDirectionRequest{},
+			},
+			Images: []BulkReceiptImage{},
+		}
+		for _, item := range r.Ingredients {
+			if item.Ingredient == nil {
+				continue
+			}
+			record.Ingredients = append(record.Ingredients, FullReceiptIngredientRequest{
+				Quantity: item.Quantity,
+				Name:     item.Ingredient.Name,
+				Category: item.Ingredient.Category,
+			})
+		}
+		for _, item := range r.Directions {
+			record.Directions = append(record.Directions, FullReceiptDirectionRequest{
+				UpdateReceiptDirectionRequest: UpdateReceiptDirectionRequest{
+					Description: item.Description,
+					Duration:    item.Duration,
+					Passive:     item.Passive,
+					Temperature: item.Temperature,
+				},
+			})
+		}
+		for _, image := range bulkImages(r) {
+			if files[image.file] {
+				record.Images = append(record.Images, BulkReceiptImage{File: image.file, Caption: image.caption})
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+type bulkImage struct {
+	file    string
+	caption string
+	link    string
+}
+
+// bulkImages returns the gallery with the cover first and archive paths of its files.
+func bulkImages(r receipt.FullReceipt) (images []bulkImage) {
+	ordered := make([]receipt.ReceiptImage, 0, len(r.Images))
+	for _, image := range r.Images {
+		if image.Cover {
+			ordered = append([]receipt.ReceiptImage{image}, ordered...)
+		} else {
+			ordered = append(ordered, image)
+		}
+	}
+	for k, image := range ordered {
+		if image.Media == nil {
+			continue
+		}
+		images = append(images, bulkImage{
+			file:    path.Join(bulkArchiveImages, strconv.Itoa(int(r.Id)), strconv.Itoa(k+1)+path.Ext(image.Media.Link)),
+			caption: image.Caption,
+			link:    image.Media.Link,
+		})
+	}
+	return
+}
+
+func writeBulkArchive(w io.Writer, receipts []receipt.FullReceipt) (err error) {
+	archive := zip.NewWriter(w)
+
+	files := map[string]bool{}
+	for _, r := range receipts {
+		for _, image := range bulkImages(r) {
+			data, readErr := ioutil.ReadFile(path.Join(media.MediaFolderRoot, image.link))
+			if readErr != nil {
+				// the receipt is exported without the missing image
+				log.Printf("bulk export image `%s` error: `%s`", image.link, readErr)
+				continue
+			}
+			file, createErr := archive.Create(image.file)
+			if createErr != nil {
+				return createErr
+			}
+			_, err = file.Write(data)
+			if err != nil {
+				return
+			}
+			files[image.file] = true
+		}
+	}
+
+	document, err := archive.Create(bulkArchiveDocument)
+	if err != nil {
+		return
+	}
+	encoder := json.NewEncoder(document)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(newBulkReceiptRecords(receipts, files))
+	if err != nil {
+		return
+	}
+
+	sheet, err := archive.Create(bulkArchiveSheet)
+	if err != nil {
+		return
+	}
+	err = writeBulkSheet(sheet, receipts)
+	if err != nil {
+		return
+	}
+
+	err = archive.Close()
+	return
+}
+
+// writeBulkSheet writes one receipt per row, ingredients and directions are joined into single cells.
+func writeBulkSheet(w io.Writer, receipts []receipt.FullReceipt) (err error) {
+	sheet := csv.NewWriter(w)
+	err = sheet.Write([]string{"id", "name", "description", "category", "prep_time", "cook_time", "rest_time", "total_time", "difficulty", "cuisine", "course", "servings", "ingredients", "directions", "images", "created_at"})
+	if err != nil {
+		return
+	}
+
+	for _, r := range receipts {
+		ingredients := make([]string, 0, len(r.Ingredients))
+		for _, item := range r.Ingredients {
+			if item.Ingredient != nil {
+				ingredients = append(ingredients, strings.TrimSpace(item.Quantity+" "+item.Ingredient.Name))
+			}
+		}
+		directions := make([]string, 0, len(r.Directions))
+		for k, item := range r.Directions {
+			directions = append(directions, fmt.Sprintf("%d. %s", k+1, item.Description))
+		}
+		images := []string{}
+		for _, image := range bulkImages(r) {
+			images = append(images, image.file)
+		}
+
+		err = sheet.Write([]string{
+			strconv.Itoa(int(r.Id)),
+			r.Name,
+			r.Description,
+			r.Category,
+			strconv.Itoa(int(r.PrepTime)),
+			strconv.Itoa(int(r.CookTime)),
+			strconv.Itoa(int(r.RestTime)),
+			strconv.Itoa(int(r.TotalTime)),
+			r.Difficulty,
+			r.Cuisine,
+			r.Course,
+			strconv.Itoa(int(r.Servings)),
+			strings.Join(ingredients, "; "),
+			strings.Join(directions, "\n"),
+			strings.Join(images, "; "),
+			r.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	sheet.Flush()
+	err = sheet.Error()
+	return
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"fmt"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"github.com/jinzhu/gorm"
+	"strings"
+)
+
+type CreateReceiptCommentRequest struct {
+	// (required)
+	Body string `json:"body" minLength:"1" maxLength:"4096" binding:"required" validate:"max=4096,min=1"`
+	// id of the comment to reply to
+	ParentId *uint `json:"parent_id"`
+}
+
+func (u *CreateReceiptCommentRequest) TrimSpaces() {
+	u.Body = strings.TrimSpace(u.Body)
+}
+
+type UpdateReceiptCommentRequest struct {
+	// (required)
+	Body string `json:"body" minLength:"1" maxLength:"4096" binding:"required" validate:"max=4096,min=1"`
+}
+
+func (u *UpdateReceiptCommentRequest) TrimSpaces() {
+	u.Body = strings.TrimSpace(u.Body)
+}
+
+// GetReceiptCommentThreads returns a page of comment threads of the receipt with all nested replies.
+func (s *Receipt) GetReceiptCommentThreads(receiptId, userId uint, pagination tools.Pagination) (threads []*receipt.ReceiptComment, page tools.Pagination, err error) {
+	page = pagination
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+
+	page.Total, err = s.receiptRepo.CountRootComments(receiptId)
+	if err != nil {
+		return
+	}
+
+	roots, err := s.receiptRepo.GetRootComments(receiptId, page.Offset(), page.PerPage)
+	if err != nil {
+		return
+	}
+
+	rootIds := make([]uint, 0, len(roots))
+	for _, root := range roots {
+		rootIds = append(rootIds, root.Id)
+	}
+	replies, err := s.receiptRepo.GetCommentReplies(rootIds)
+	if err != nil {
+		return
+	}
+
+	threads = receipt.BuildCommentThreads(roots, replies)
+	return
+}
+
+func (s *Receipt) CreateReceiptComment(receiptId, userId uint, request CreateReceiptCommentRequest) (i receipt.ReceiptComment, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+
+	i = receipt.ReceiptComment{
+		ReceiptId: receiptId,
+		UserId:    userId,
+		Body:      request.Body,
+	}
+
+	if request.ParentId != nil {
+		var parent receipt.ReceiptComment
+		parent, err = s.receiptRepo.GetCommentById(*request.ParentId)
+		if gorm.IsRecordNotFoundError(err) {
+			err = tools.NewValidationErr(fmt.Errorf("parent comment not found"))
+			return
+		}
+		if err != nil {
+			return
+		}
+		if parent.ReceiptId != receiptId {
+			err = tools.NewValidationErr(fmt.Errorf("parent comment not found"))
+			return
+		}
+
+		rootId := parent.Id
+		if parent.RootId != nil {
+			rootId = *parent.RootId
+		}
+		i.ParentId = &parent.Id
+		i.RootId = &rootId
+	}
+
+	err = s.receiptRepo.CreateComment(&i)
+	if err != nil {
+		return
+	}
+	i.Replies = []*receipt.ReceiptComment{}
+	return
+}
+
+func (s *Receipt) UpdateReceiptComment(receiptId, commentId, userId uint, request UpdateReceiptCommentRequest) (i receipt.ReceiptComment, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+
+	i, err = s.getReceiptComment(receiptId, commentId)
+	if err != nil {
+		return
+	}
+
+	if i.UserId != userId {
+		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismatch"))
+		return
+	}
+
+	i.Body = request.Body
+	err = s.receiptRepo.UpdateComment(&i)
+	if err != nil {
+		return
+	}
+
+	i, err = s.receiptRepo.GetCommentById(i.Id)
+	i.Replies = []*receipt.ReceiptComment{}
+	return
+}
+
+// DeleteReceiptComment soft deletes the comment. It is permitted to the comment author and the receipt owner.
+func (s *Receipt) DeleteReceiptComment(receiptId, commentId, userId uint) (err error) {
+	r, err := s.getPermittedReceipt(receiptId, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+
+	comment, err := s.getReceiptComment(receiptId, commentId)
+	if _, ok := err.(*tools.ValidationErr); ok {
+		// comment is already deleted
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if comment.UserId != userId && r.UserId != userId {
+		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismatch"))
+		return
+	}
+
+	err = s.receiptRepo.DeleteCommentById(comment.Id)
+	return
+}
+
+func (s *Receipt) getReceiptComment(receiptId, commentId uint) (comment receipt.ReceiptComment, err error) {
+	comment, err = s.receiptRepo.GetCommentById(commentId)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if comment.ReceiptId != receiptId {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	return
+}
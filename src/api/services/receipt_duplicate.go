@@ -0,0 +1,192 @@
+package services
+
+import (
+	"fmt"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"sort"
+)
+
+type MergeReceiptsRequest struct {
+	// receipts merged into the receipt and moved to trash
+	DuplicateIds []uint `json:"duplicate_ids" validate:"required,min=1,max=50"`
+}
+
+// getFingerprints returns fingerprints of all receipts of the user with the receipts by their id.
+func (s *Receipt) getFingerprints(userId uint) (fingerprints []receipt.Fingerprint, receipts map[uint]receipt.Receipt, err error) {
+	items, err := s.receiptRepo.GetAllByUserId(userId)
+	if err != nil {
+		return
+	}
+
+	ids := make([]uint, 0, len(items))
+	full := make(map[uint]*receipt.FullReceipt, len(items))
+	receipts = make(map[uint]receipt.Receipt, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Id)
+		full[item.Id] = &receipt.FullReceipt{Receipt: item}
+		receipts[item.Id] = item
+	}
+
+	ingredients, err := s.receiptRepo.GetIngredientsByIds(ids)
+	if err != nil {
+		return
+	}
+	for _, item := range ingredients {
+		full[item.ReceiptId].Ingredients = append(full[item.ReceiptId].Ingredients, item)
+	}
+	directions, err := s.receiptRepo.GetDirectionsByIds(ids)
+	if err != nil {
+		return
+	}
+	for _, item := range directions {
+		full[item.ReceiptId].Directions = append(full[item.ReceiptId].Directions, item)
+	}
+
+	for _, id := range ids {
+		fingerprints = append(fingerprints, receipt.NewFingerprint(*full[id]))
+	}
+	return
+}
+
+// findDuplicates compares the fingerprint with the others, the most similar duplicates go first.
+func findDuplicates(f receipt.Fingerprint, others []receipt.Fingerprint) (duplicates []receipt.Duplicate) {
+	for _, other := range others {
+		if other.ReceiptId == f.ReceiptId && f.ReceiptId != 0 {
+			continue
+		}
+		score := f.Similarity(other)
+		if score >= receipt.DuplicateThreshold {
+			duplicates = append(duplicates, receipt.Duplicate{ReceiptId: other.ReceiptId, Name: other.Name, Score: score})
+		}
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Score > duplicates[j].Score
+	})
+	return
+}
+
+// FindDuplicates returns receipts of the user which are likely duplicates of the given one.
+func (s *Receipt) FindDuplicates(userId uint, r receipt.FullReceipt) (duplicates []receipt.Duplicate, err error) {
+	fingerprints, _, err := s.getFingerprints(userId)
+	if err != nil {
+		return
+	}
+
+	duplicates = findDuplicates(receipt.NewFingerprint(r), fingerprints)
+	return
+}
+
+// GetDuplicateClusters groups receipts of the user which are likely duplicates of each other.
+// Receipts are in one cluster when they are linked through duplicates, most similar clusters go first.
+func (s *Receipt) GetDuplicateClusters(userId uint) (clusters []receipt.DuplicateCluster, err error) {
+	fingerprints, receipts, err := s.getFingerprints(userId)
+	if err != nil {
+		return
+	}
+
+	// union-find over pairs of duplicates
+	parents := make([]int, len(fingerprints))
+	scores := make([]float64, len(fingerprints))
+	for k := range parents {
+		parents[k] = k
+		scores[k] = 1
+	}
+	var root func(k int) int
+	root = func(k int) int {
+		if parents[k] != k {
+			parents[k] = root(parents[k])
+		}
+		return parents[k]
+	}
+	for i := range fingerprints {
+		for j := i + 1; j < len(fingerprints); j++ {
+			score := fingerprints[i].Similarity(fingerprints[j])
+			if score < receipt.DuplicateThreshold {
+				continue
+			}
+			a, b := root(i), root(j)
+			if scores[b] < scores[a] {
+				scores[a] = scores[b]
+			}
+			if score < scores[a] {
+				scores[a] = score
+			}
+			parents[b] = a
+		}
+	}
+
+	byRoot := map[int]*receipt.DuplicateCluster{}
+	for k, f := range fingerprints {
+		r := root(k)
+		cluster, ok := byRoot[r]
+		if !ok {
+			cluster = &receipt.DuplicateCluster{Score: scores[r]}
+			byRoot[r] = cluster
+		}
+		cluster.Receipts = append(cluster.Receipts, receipts[f.ReceiptId])
+	}
+
+	clusters = []receipt.DuplicateCluster{}
+	for _, cluster := range byRoot {
+		if len(cluster.Receipts) > 1 {
+			clusters = append(clusters, *cluster)
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Score != clusters[j].Score {
+			return clusters[i].Score > clusters[j].Score
+		}
+		return clusters[i].Receipts[0].Id < clusters[j].Receipts[0].Id
+	})
+
+	for k := range clusters {
+		err = s.markReceipts(userId, clusters[k].Receipts)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// MergeReceipts keeps the receipt and moves everything users added to the duplicates to it,
+// like favorites, cookbook and meal plan entries, reviews, comments and images.
+// Duplicates are moved to trash, all receipts should be owned by the user.
+func (s *Receipt) MergeReceipts(id, userId uint, request MergeReceiptsRequest) (i receipt.Receipt, err error) {
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	_, err = s.getPermittedReceipt(id, userId, receipt.OwnerRole)
+	if err != nil {
+		return
+	}
+
+	ids := []uint{}
+	seen := map[uint]bool{}
+	for _, duplicateId := range request.DuplicateIds {
+		if duplicateId == id {
+			err = tools.NewValidationErr(fmt.Errorf("receipt cannot be merged into itself"))
+			return
+		}
+		if seen[duplicateId] {
+			continue
+		}
+		seen[duplicateId] = true
+
+		_, err = s.getPermittedReceipt(duplicateId, userId, receipt.OwnerRole)
+		if err != nil {
+			return
+		}
+		ids = append(ids, duplicateId)
+	}
+
+	err = s.receiptRepo.Merge(id, ids)
+	if err != nil {
+		return
+	}
+
+	return s.GetReceipt(id, userId)
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"testing"
+
+	"food/src/api/database/dbtest"
+	"food/src/api/models/tools"
+)
+
+func isPreconditionFailed(err error) bool {
+	_, ok := err.(*tools.PreconditionFailedErr)
+	return ok
+}
+
+func TestReceiptIfMatch(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	s := GetReceiptService(db)
+
+	ownerId := dbtest.CreateUser(t, db, "owner")
+	reviewerId := dbtest.CreateUser(t, db, "reviewer")
+	r := newTestFullReceipt(t, s, ownerId)
+
+	read, err := s.GetReceipt(r.Id, ownerId)
+	if err != nil {
+		t.Fatalf("cannot get receipt: %v", err)
+	}
+	etag := read.ETag()
+	fullRead, err := s.GetFullReceipt(r.Id, ownerId)
+	if err != nil {
+		t.Fatalf("cannot get receipt: %v", err)
+	}
+	fullETag := fullRead.ETag()
+
+	// favorites and reviews do not change the receipt the client edits
+	err = s.AddFavoriteReceipt(r.Id, ownerId)
+	if err != nil {
+		t.Fatalf("cannot add favorite: %v", err)
+	}
+	_, err = s.CreateReceiptReview(r.Id, reviewerId, CreateReceiptReviewRequest{Rating: 4})
+	if err != nil {
+		t.Fatalf("cannot review receipt: %v", err)
+	}
+	read, err = s.GetReceipt(r.Id, ownerId)
+	if err != nil {
+		t.Fatalf("cannot get receipt: %v", err)
+	}
+	if read.ETag() != etag {
+		t.Errorf("version changed from %s to %s", etag, read.ETag())
+	}
+
+	request := newFullReceiptRequest(r)
+	request.Name = "Crepes"
+	_, err = s.ReplaceFullReceipt(r.Id, ownerId, request, fullETag)
+	if err != nil {
+		t.Fatalf("cannot replace receipt with the version read before: %v", err)
+	}
+
+	update := UpdateReceiptRequest(newCreateReceiptRequest(read))
+	_, err = s.UpdateReceipt(r.Id, ownerId, update, etag)
+	if !isPreconditionFailed(err) {
+		t.Errorf("changed receipt is updated, error %v", err)
+	}
+	_, err = s.ReplaceFullReceipt(r.Id, ownerId, request, fullETag)
+	if !isPreconditionFailed(err) {
+		t.Errorf("changed receipt is replaced, error %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"food/src/api/models/receipt"
+)
+
+func (s *Receipt) GetFavoriteReceipts(userId uint) (receipts []receipt.Receipt, err error) {
+	receipts, err = s.receiptRepo.GetFavorites(userId)
+	if err != nil {
+		return
+	}
+	for i := range receipts {
+		receipts[i].Favorited = true
+	}
+	err = s.markLabels(receipts)
+	return
+}
+
+func (s *Receipt) AddFavoriteReceipt(receiptId, userId uint) (err error) {
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+
+	err = s.receiptRepo.AddFavorite(&receipt.ReceiptFavorite{UserId: userId, ReceiptId: receiptId})
+	return
+}
+
+func (s *Receipt) RemoveFavoriteReceipt(receiptId, userId uint) (err error) {
+	err = s.receiptRepo.RemoveFavorite(userId, receiptId)
+	return
+}
+
+// markFavorites fills the favorited flag of the receipts for the given user.
+func (s *Receipt) markFavorites(userId uint, receipts []receipt.Receipt) (err error) {
+	ids := make([]uint, 0, len(receipts))
+	for _, r := range receipts {
+		ids = append(ids, r.Id)
+	}
+
+	favoriteIds, err := s.receiptRepo.GetFavoriteIds(userId, ids)
+	if err != nil {
+		return
+	}
+
+	favorites := make(map[uint]bool, len(favoriteIds))
+	for _, id := range favoriteIds {
+		favorites[id] = true
+	}
+	for i := range receipts {
+		receipts[i].Favorited = favorites[receipts[i].Id]
+	}
+	return
+}
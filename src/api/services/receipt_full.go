@@ -0,0 +1,294 @@
+package services
+
+import (
+	"fmt"
+	"food/src/api/models/cooklang"
+	"food/src/api/models/ingredient"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"github.com/jinzhu/gorm"
+	"io"
+	"strings"
+)
+
+type FullReceiptIngredientRequest struct {
+	// existing receipt ingredient to update, when no ingredient has id they replace the ones at their positions
+	Id uint `json:"id,omitempty"`
+	// (required)
+	Quantity string `json:"quantity" minLength:"1" maxLength:"255" binding:"required" validate:"max=255,min=1"`
+	// existing ingredient, either it or the name is required
+	IngredientId uint `json:"ingredient_id"`
+	// ingredient is created when there is no ingredient with such name yet
+	Name string `json:"name" maxLength:"255" validate:"max=255"`
+	// category of the created ingredient
+	Category string `json:"category" maxLength:"255" validate:"max=255"`
+}
+
+func (u *FullReceiptIngredientRequest) TrimSpaces() {
+	u.Quantity = strings.TrimSpace(u.Quantity)
+	u.Name = strings.TrimSpace(u.Name)
+	u.Category = strings.ToLower(strings.TrimSpace(u.Category))
+}
+
+type FullReceiptDirectionRequest struct {
+	// existing step to update, when no step has id they replace the ones at their positions
+	Id uint `json:"id,omitempty"`
+	UpdateReceiptDirectionRequest
+}
+
+type FullReceiptRequest struct {
+	CreateReceiptRequest
+	// ingredients in their order
+	Ingredients []FullReceiptIngredientRequest `json:"ingredients" validate:"dive"`
+	// steps in their order
+	Directions []FullReceiptDirectionRequest `json:"directions" validate:"dive"`
+}
+
+func (u *FullReceiptRequest) TrimSpaces() {
+	u.CreateReceiptRequest.TrimSpaces()
+	for i := range u.Ingredients {
+		u.Ingredients[i].TrimSpaces()
+	}
+	for i := range u.Directions {
+		u.Directions[i].TrimSpaces()
+	}
+}
+
+func (s *Receipt) GetFullReceipt(id, userId uint) (i receipt.FullReceipt, err error) {
+	r, err := s.getPermittedReceipt(id, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+
+	return s.loadFullReceipt(r, userId)
+}
+
+// WriteReceiptPDF writes printable document of the receipt.
+func (s *Receipt) WriteReceiptPDF(w io.Writer, id, userId uint) (err error) {
+	i, err := s.GetFullReceipt(id, userId)
+	if err != nil {
+		return
+	}
+
+	err = receipt.WritePDF(w, i.Name, []receipt.FullReceipt{i})
+	return
+}
+
+// WriteReceiptCooklang writes the receipt in plain-text markup.
+func (s *Receipt) WriteReceiptCooklang(w io.Writer, id, userId uint) (err error) {
+	i, err := s.GetFullReceipt(id, userId)
+	if err != nil {
+		return
+	}
+
+	err = cooklang.Render(w, cooklang.NewRecipe(i))
+	return
+}
+
+// CreateFullReceipt creates the receipt with all its ingredients and directions at once.
+func (s *Receipt) CreateFullReceipt(userId uint, request FullReceiptRequest) (i receipt.FullReceipt, err error) {
+	ingredients, directions, err := s.prepareFullReceipt(&request, 0, map[string]*ingredient.Ingredient{})
+	if err != nil {
+		return
+	}
+
+	r := receipt.Receipt{UserId: userId}
+	request.setReceipt(&r)
+	err = s.receiptRepo.SaveFull(&r, ingredients, directions)
+	if err != nil {
+		return
+	}
+
+	r, err = s.receiptRepo.GetById(r.Id)
+	if err != nil {
+		return
+	}
+	return s.loadFullReceipt(r, userId)
+}
+
+// ReplaceFullReceipt replaces the receipt fields, ingredients and directions at once.
+// Empty ifMatch replaces any version, otherwise it should match the version of the full receipt.
+func (s *Receipt) ReplaceFullReceipt(id, userId uint, request FullReceiptRequest, ifMatch string) (i receipt.FullReceipt, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	r, err := s.getPermittedReceipt(id, userId, receipt.EditorRole)
+	if err != nil {
+		return
+	}
+
+	if len(ifMatch) > 0 {
+		var current receipt.FullReceipt
+		current, err = s.loadFullReceipt(r, userId)
+		if err != nil {
+			return
+		}
+		err = checkETag(ifMatch, current)
+		if err != nil {
+			return
+		}
+	}
+
+	return s.saveFullReceipt(r, request, userId)
+}
+
+// saveFullReceipt replaces the existing receipt with the request.
+func (s *Receipt) saveFullReceipt(r receipt.Receipt, request FullReceiptRequest, userId uint) (i receipt.FullReceipt, err error) {
+	err = checkPrivacyChange(r, request.Private, userId)
+	if err != nil {
+		return
+	}
+	ingredients, directions, err := s.prepareFullReceipt(&request, r.TotalTime, map[string]*ingredient.Ingredient{})
+	if err != nil {
+		return
+	}
+	err = s.checkFullReceiptIds(r.Id, ingredients, directions)
+	if err != nil {
+		return
+	}
+
+	request.setReceipt(&r)
+	err = s.receiptRepo.SaveFull(&r, ingredients, directions)
+	if err != nil {
+		return
+	}
+
+	r, err = s.receiptRepo.GetById(r.Id)
+	if err != nil {
+		return
+	}
+	return s.loadFullReceipt(r, userId)
+}
+
+// prepareFullReceipt validates the request and resolves ingredient names.
+// Ingredients which do not exist yet are returned without id to be created with the receipt,
+// created holds them by lowercase name so receipts saved together share new ingredients,
+// total is the current total time of the receipt, zero for new ones.
+func (s *Receipt) prepareFullReceipt(request *FullReceiptRequest, total uint, created map[string]*ingredient.Ingredient) (ingredients []receipt.ReceiptIngredient, directions []receipt.ReceiptDirection, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+	err = checkTimes(request.PrepTime, request.CookTime, request.RestTime, request.CookingTime, total)
+	if err != nil {
+		return
+	}
+	err = checkDifficulty(request.Difficulty)
+	if err != nil {
+		return
+	}
+	err = checkLanguage(request.Language)
+	if err != nil {
+		return
+	}
+
+	for _, item := range request.Ingredients {
+		ri := receipt.ReceiptIngredient{Id: item.Id, Quantity: item.Quantity, IngredientId: item.IngredientId}
+		switch {
+		case item.IngredientId != 0:
+			_, err = s.ingredientRepo.GetById(item.IngredientId)
+			if gorm.IsRecordNotFoundError(err) {
+				err = tools.NewValidationErr(fmt.Errorf("ingredient `%d` not found", item.IngredientId))
+				return
+			}
+			if err != nil {
+				return
+			}
+		case len(item.Name) >= 3:
+			var existing ingredient.Ingredient
+			existing, err = s.ingredientRepo.GetByName(item.Name)
+			if err == nil {
+				ri.IngredientId = existing.Id
+				break
+			}
+			if !gorm.IsRecordNotFoundError(err) {
+				return
+			}
+			err = nil
+
+			key := strings.ToLower(item.Name)
+			if _, ok := created[key]; !ok {
+				created[key] = &ingredient.Ingredient{Name: item.Name, Category: item.Category}
+			}
+			ri.Ingredient = created[key]
+		default:
+			err = tools.NewValidationErr(fmt.Errorf("ingredient id or name of at least 3 characters should be given"))
+			return
+		}
+		ingredients = append(ingredients, ri)
+	}
+
+	for _, item := range request.Directions {
+		direction := item.direction()
+		direction.Id = item.Id
+		directions = append(directions, direction)
+	}
+	return
+}
+
+// checkFullReceiptIds makes sure the ingredients and directions with id belong to the receipt,
+// each of them can be given once.
+func (s *Receipt) checkFullReceiptIds(receiptId uint, ingredients []receipt.ReceiptIngredient, directions []receipt.ReceiptDirection) (err error) {
+	currentIngredients, err := s.receiptRepo.GetIngredientsById(receiptId)
+	if err != nil {
+		return
+	}
+	known := make(map[uint]bool, len(currentIngredients))
+	for _, item := range currentIngredients {
+		known[item.Id] = true
+	}
+	for _, item := range ingredients {
+		if item.Id == 0 {
+			continue
+		}
+		if !known[item.Id] {
+			return tools.NewValidationErr(fmt.Errorf("receipt ingredient `%d` not found or given twice", item.Id))
+		}
+		delete(known, item.Id)
+	}
+
+	currentDirections, err := s.receiptRepo.GetDirectionsById(receiptId)
+	if err != nil {
+		return
+	}
+	known = make(map[uint]bool, len(currentDirections))
+	for _, item := range currentDirections {
+		known[item.Id] = true
+	}
+	for _, item := range directions {
+		if item.Id == 0 {
+			continue
+		}
+		if !known[item.Id] {
+			return tools.NewValidationErr(fmt.Errorf("direction `%d` not found or given twice", item.Id))
+		}
+		delete(known, item.Id)
+	}
+	return
+}
+
+func (s *Receipt) loadFullReceipt(r receipt.Receipt, userId uint) (i receipt.FullReceipt, err error) {
+	receipts := []receipt.Receipt{r}
+	err = s.markReceipts(userId, receipts)
+	if err != nil {
+		return
+	}
+
+	i.Receipt = receipts[0]
+	i.Ingredients, err = s.receiptRepo.GetIngredientsById(r.Id)
+	if err != nil {
+		return
+	}
+	i.Directions, err = s.receiptRepo.GetDirectionsById(r.Id)
+	if err != nil {
+		return
+	}
+	i.Images, err = s.receiptRepo.GetImagesById(r.Id)
+	return
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"testing"
+
+	"food/src/api/database/dbtest"
+	"food/src/api/models/media"
+	"food/src/api/models/receipt"
+	"github.com/jinzhu/gorm"
+)
+
+func newTestFullReceipt(t *testing.T, s *Receipt, userId uint) receipt.FullReceipt {
+	r, err := s.CreateFullReceipt(userId, FullReceiptRequest{
+		CreateReceiptRequest: CreateReceiptRequest{
+			Name:        "Pancakes",
+			Description: "Thin pancakes",
+			Category:    "breakfast",
+			CookTime:    20,
+		},
+		Ingredients: []FullReceiptIngredientRequest{
+			{Quantity: "200 g", Name: "flour"},
+			{Quantity: "300 ml", Name: "milk"},
+		},
+		Directions: []FullReceiptDirectionRequest{
+			{UpdateReceiptDirectionRequest: UpdateReceiptDirectionRequest{Description: "Whisk everything"}},
+			{UpdateReceiptDirectionRequest: UpdateReceiptDirectionRequest{Description: "Fry on a hot pan"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("cannot create receipt: %v", err)
+	}
+	return r
+}
+
+// addDirectionMedia attaches the picture to the step like an upload does.
+func addDirectionMedia(t *testing.T, s *Receipt, db *gorm.DB, direction receipt.ReceiptDirection) uint {
+	m := media.Media{Link: "step.png", Format: "image/png"}
+	err := db.Create(&m).Error
+	if err != nil {
+		t.Fatalf("cannot create media: %v", err)
+	}
+	err = s.receiptRepo.UpdateDirectionMedia(direction.Id, m.Id)
+	if err != nil {
+		t.Fatalf("cannot attach media: %v", err)
+	}
+	return m.Id
+}
+
+// withoutIds returns the document of the receipt like clients which do not know ids send it.
+func withoutIds(r receipt.FullReceipt) FullReceiptRequest {
+	request := newFullReceiptRequest(r)
+	for i := range request.Ingredients {
+		request.Ingredients[i].Id = 0
+	}
+	for i := range request.Directions {
+		request.Directions[i].Id = 0
+	}
+	return request
+}
+
+func TestReplaceFullReceiptKeepsRows(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	s := GetReceiptService(db)
+
+	userId := dbtest.CreateUser(t, db, "cook")
+	r := newTestFullReceipt(t, s, userId)
+	mediaId := addDirectionMedia(t, s, db, r.Directions[1])
+	_, err := s.SaveDirectionTranslation(r.Id, r.Directions[1].Id, userId, "uk", SaveDirectionTranslationRequest{Description: "Смажити на сковороді"})
+	if err != nil {
+		t.Fatalf("cannot translate step: %v", err)
+	}
+
+	// clients which do not know ids replace rows at the same positions
+	request := withoutIds(r)
+	request.Ingredients[0].Quantity = "250 g"
+	request.Directions[1].Description = "Fry on a very hot pan"
+	request.Directions = append(request.Directions, FullReceiptDirectionRequest{
+		UpdateReceiptDirectionRequest: UpdateReceiptDirectionRequest{Description: "Serve with jam"},
+	})
+
+	replaced, err := s.ReplaceFullReceipt(r.Id, userId, request, "")
+	if err != nil {
+		t.Fatalf("cannot replace receipt: %v", err)
+	}
+	if len(replaced.Ingredients) != 2 || replaced.Ingredients[0].Id != r.Ingredients[0].Id || replaced.Ingredients[0].Quantity != "250 g" {
+		t.Errorf("ingredients are not updated in place: %+v", replaced.Ingredients)
+	}
+	if len(replaced.Directions) != 3 {
+		t.Fatalf("got %d directions, expected 3", len(replaced.Directions))
+	}
+	step := replaced.Directions[1]
+	if step.Id != r.Directions[1].Id || step.Description != "Fry on a very hot pan" {
+		t.Errorf("direction is not updated in place: %+v", step)
+	}
+	if step.MediaId == nil || *step.MediaId != mediaId {
+		t.Errorf("direction media is lost: %v", step.MediaId)
+	}
+
+	translated, err := s.GetFullReceipt(r.Id, userId)
+	if err != nil {
+		t.Fatalf("cannot get receipt: %v", err)
+	}
+	err = s.TranslateFullReceipt("uk", &translated)
+	if err != nil {
+		t.Fatalf("cannot translate receipt: %v", err)
+	}
+	if translated.Directions[1].Description != "Смажити на сковороді" {
+		t.Errorf("direction translation is lost: %s", translated.Directions[1].Description)
+	}
+}
+
+func TestReplaceFullReceiptMovesRowsById(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	s := GetReceiptService(db)
+
+	userId := dbtest.CreateUser(t, db, "cook")
+	r := newTestFullReceipt(t, s, userId)
+	mediaId := addDirectionMedia(t, s, db, r.Directions[0])
+
+	request := newFullReceiptRequest(r)
+	request.Directions[0], request.Directions[1] = request.Directions[1], request.Directions[0]
+	request.Ingredients = request.Ingredients[1:]
+
+	replaced, err := s.ReplaceFullReceipt(r.Id, userId, request, "")
+	if err != nil {
+		t.Fatalf("cannot replace receipt: %v", err)
+	}
+	if len(replaced.Ingredients) != 1 || replaced.Ingredients[0].Id != r.Ingredients[1].Id || replaced.Ingredients[0].Position != 0 {
+		t.Errorf("ingredient is not kept: %+v", replaced.Ingredients)
+	}
+	if replaced.Directions[0].Id != r.Directions[1].Id || replaced.Directions[1].Id != r.Directions[0].Id {
+		t.Errorf("directions are not moved: %+v", replaced.Directions)
+	}
+	if moved := replaced.Directions[1]; moved.MediaId == nil || *moved.MediaId != mediaId {
+		t.Errorf("moved direction media is lost: %v", moved.MediaId)
+	}
+
+	other := newTestFullReceipt(t, s, userId)
+	request = newFullReceiptRequest(r)
+	request.Directions[0].Id = other.Directions[0].Id
+	_, err = s.ReplaceFullReceipt(r.Id, userId, request, "")
+	if !isValidationErr(err) {
+		t.Errorf("direction of another receipt is accepted, error %v", err)
+	}
+}
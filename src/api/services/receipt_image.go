@@ -0,0 +1,193 @@
+package services
+
+import (
+	"fmt"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"github.com/jinzhu/gorm"
+	"mime/multipart"
+	"strings"
+)
+
+type AddReceiptImageRequest struct {
+	Caption string `json:"caption" maxLength:"255" validate:"max=255"`
+	// zero based position, image is appended to the end when omitted
+	Position *uint `json:"position"`
+	// make the image the cover, the first image always becomes the cover
+	Cover bool `json:"cover"`
+}
+
+func (u *AddReceiptImageRequest) TrimSpaces() {
+	u.Caption = strings.TrimSpace(u.Caption)
+}
+
+type UpdateReceiptImageRequest struct {
+	Caption string `json:"caption" maxLength:"255" validate:"max=255"`
+	// make the image the cover, the cover can be changed only by choosing another one
+	Cover bool `json:"cover"`
+}
+
+func (u *UpdateReceiptImageRequest) TrimSpaces() {
+	u.Caption = strings.TrimSpace(u.Caption)
+}
+
+type ReorderReceiptImagesRequest struct {
+	// all image ids of the receipt in the new order (required)
+	Ids []uint `json:"ids" binding:"required" validate:"required,min=1"`
+}
+
+func (s *Receipt) GetReceiptImages(receiptId, userId uint) (images []receipt.ReceiptImage, err error) {
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+
+	images, err = s.receiptRepo.GetImagesById(receiptId)
+	return
+}
+
+func (s *Receipt) AddReceiptImage(receiptId, userId uint, formFile *multipart.FileHeader, opts Options, request AddReceiptImageRequest) (i receipt.ReceiptImage, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	r, err := s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
+		return
+	}
+
+	newMedia, err := s.mediaSvc.ProcessMedia(formFile, opts)
+	if err != nil {
+		return
+	}
+
+	i, err = s.createReceiptImage(r, newMedia.Id, request)
+	return
+}
+
+// createReceiptImage adds the stored media to the gallery of the receipt.
+func (s *Receipt) createReceiptImage(r receipt.Receipt, mediaId uint, request AddReceiptImageRequest) (i receipt.ReceiptImage, err error) {
+	images, err := s.receiptRepo.GetImagesById(r.Id)
+	if err != nil {
+		return
+	}
+
+	i = receipt.ReceiptImage{
+		ReceiptId: r.Id,
+		MediaId:   mediaId,
+		Caption:   request.Caption,
+		Position:  insertPosition(request.Position, len(images)),
+		Cover:     request.Cover || r.MediaId == nil,
+	}
+	err = s.receiptRepo.CreateImage(&i)
+	if err != nil {
+		return
+	}
+
+	i, err = s.receiptRepo.GetImageById(i.Id)
+	return
+}
+
+func (s *Receipt) UpdateReceiptImage(receiptId, imageId, userId uint, request UpdateReceiptImageRequest) (i receipt.ReceiptImage, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	i, err = s.getReceiptImage(receiptId, imageId, userId)
+	if err != nil {
+		return
+	}
+
+	i.Caption = request.Caption
+	// the cover cannot be unset, otherwise the gallery would have no cover
+	i.Cover = request.Cover && !i.Cover
+	err = s.receiptRepo.UpdateImage(&i)
+	if err != nil {
+		return
+	}
+
+	i, err = s.receiptRepo.GetImageById(i.Id)
+	return
+}
+
+func (s *Receipt) DeleteReceiptImage(receiptId, imageId, userId uint) (err error) {
+	_, err = s.getReceiptImage(receiptId, imageId, userId)
+	if _, ok := err.(*tools.ValidationErr); ok {
+		// image is already deleted
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	err = s.receiptRepo.DeleteImageById(imageId)
+	if gorm.IsRecordNotFoundError(err) {
+		err = nil
+	}
+	return
+}
+
+func (s *Receipt) ReorderReceiptImages(receiptId, userId uint, request ReorderReceiptImagesRequest) (images []receipt.ReceiptImage, err error) {
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
+		return
+	}
+
+	images, err = s.receiptRepo.GetImagesById(receiptId)
+	if err != nil {
+		return
+	}
+
+	ids := make([]uint, 0, len(images))
+	for _, item := range images {
+		ids = append(ids, item.Id)
+	}
+	if !isPermutation(ids, request.Ids) {
+		err = tools.NewValidationErr(fmt.Errorf("ids should contain every image of the receipt exactly once"))
+		return
+	}
+
+	err = s.receiptRepo.ReorderImages(receiptId, request.Ids)
+	if err != nil {
+		return
+	}
+
+	images, err = s.receiptRepo.GetImagesById(receiptId)
+	return
+}
+
+// getReceiptImage checks the editor access and makes sure the image belongs to the receipt.
+func (s *Receipt) getReceiptImage(receiptId, imageId, userId uint) (i receipt.ReceiptImage, err error) {
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
+		return
+	}
+
+	i, err = s.receiptRepo.GetImageById(imageId)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if i.ReceiptId != receiptId {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	return
+}
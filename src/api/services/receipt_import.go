@@ -0,0 +1,486 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"food/src/api/config"
+	"food/src/api/models/cooklang"
+	"food/src/api/models/ingredient"
+	"food/src/api/models/receipt"
+	"food/src/api/models/schemaorg"
+	"food/src/api/models/tools"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"math"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	maxImportPageSize  = 5 << 20
+	maxImportImageSize = 10 << 20
+	importTimeout      = 20 * time.Second
+	importMaxRedirects = 5
+	importUserAgent    = "FoodBot/1.0 (+receipt import)"
+
+	// used when the recipe has no category
+	importDefaultCategory = "other"
+	// used for ingredients listed without amount, like `salt`
+	importDefaultQuantity = "as needed"
+	// cuisine and course
+	maxImportLabelLength = 50
+	maxImportServings    = 1000
+)
+
+var servingsPattern = regexp.MustCompile(`\d+`)
+
+var privateNetworks = mustParseCIDRs(
+	"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "169.254.0.0/16",
+	"127.0.0.0/8", "0.0.0.0/8", "::1/128", "fc00::/7", "fe80::/10",
+)
+
+func mustParseCIDRs(cidrs ...string) (networks []*net.IPNet) {
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return
+}
+
+func isPrivateIP(ip net.IP) bool {
+	if ip.IsUnspecified() || ip.IsMulticast() {
+		return true
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewImportHTTPClient returns the client used to fetch imported pages and images.
+// Connections to loopback and private networks are refused unless allowed,
+// the check is done on resolved addresses so DNS cannot point the import inside.
+func NewImportHTTPClient(allowPrivateHosts bool) *http.Client {
+	dialer := &net.Dialer{Timeout: importTimeout}
+	if !allowPrivateHosts {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isPrivateIP(ip) {
+				return fmt.Errorf("address `%s` is not allowed", host)
+			}
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Timeout: importTimeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, address)
+			},
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= importMaxRedirects {
+				return fmt.Errorf("too many redirects")
+			}
+			return checkImportURL(req.URL)
+		},
+	}
+}
+
+func GetReceiptImportService(db *gorm.DB) *ReceiptImport {
+	return NewReceiptImportService(db, NewImportHTTPClient(config.GetConfig().ImportAllowPrivateHosts))
+}
+
+// NewReceiptImportService creates the import service with the given HTTP client,
+// for example the one allowed to reach a local server.
+func NewReceiptImportService(db *gorm.DB, client *http.Client) *ReceiptImport {
+	return &ReceiptImport{
+		client:         client,
+		ingredientRepo: ingredient.GetMediaRepository(db),
+		receiptSvc:     GetReceiptService(db),
+		mediaSvc:       GetMediaService(db),
+	}
+}
+
+type ReceiptImport struct {
+	client         *http.Client
+	ingredientRepo *ingredient.IngredientRepository
+	receiptSvc     *Receipt
+	mediaSvc       *Media
+}
+
+type ImportReceiptRequest struct {
+	// page with schema.org Recipe, either it or html is required
+	Url string `json:"url" example:"https://example.com/recipes/borsch" maxLength:"2048" validate:"max=2048"`
+	// raw page, relative links are resolved against url when both are given
+	Html string `json:"html"`
+	// receipt in Cooklang-style plain-text markup, it cannot be combined with url or html
+	Text string `json:"text"`
+	// save the receipt with its image instead of returning the preview
+	Save bool `json:"save"`
+}
+
+func (u *ImportReceiptRequest) TrimSpaces() {
+	u.Url = strings.TrimSpace(u.Url)
+}
+
+type ReceiptImportPreview struct {
+	// receipt document, it can be edited and created with POST /v1/receipts/full
+	Receipt FullReceiptRequest `json:"receipt"`
+	// main image of the recipe, it is downloaded when the receipt is saved
+	ImageUrl  string `json:"image_url,omitempty"`
+	SourceUrl string `json:"source_url,omitempty"`
+}
+
+func checkImportURL(link *url.URL) error {
+	if link.Scheme != "http" && link.Scheme != "https" {
+		return tools.NewValidationErr(fmt.Errorf("only http and https links can be imported"))
+	}
+	if len(link.Hostname()) == 0 {
+		return tools.NewValidationErr(fmt.Errorf("link host cannot be empty"))
+	}
+	return nil
+}
+
+// Preview extracts the recipe of the page and maps it to the receipt document.
+func (s *ReceiptImport) Preview(request ImportReceiptRequest) (preview ReceiptImportPreview, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+	if len(request.Text) > 0 {
+		if len(request.Url) > 0 || len(request.Html) > 0 {
+			err = tools.NewValidationErr(fmt.Errorf("text cannot be combined with url or html"))
+			return
+		}
+		recipe, parseErr := cooklang.Parse(request.Text)
+		if parseErr != nil {
+			err = tools.NewValidationErr(parseErr)
+			return
+		}
+		preview, err = s.mapCooklangRecipe(recipe)
+		return
+	}
+	if len(request.Url) == 0 && len(request.Html) == 0 {
+		err = tools.NewValidationErr(fmt.Errorf("url, html or text should be given"))
+		return
+	}
+
+	var base *url.URL
+	if len(request.Url) > 0 {
+		base, err = url.Parse(request.Url)
+		if err != nil {
+			err = tools.NewValidationErr(fmt.Errorf("url is invalid"))
+			return
+		}
+		err = checkImportURL(base)
+		if err != nil {
+			return
+		}
+	}
+
+	page := []byte(request.Html)
+	if len(page) == 0 {
+		page, err = s.fetchPage(base)
+		if err != nil {
+			return
+		}
+	}
+
+	recipe, err := schemaorg.Extract(page, base)
+	if err == schemaorg.ErrNoRecipe {
+		err = tools.NewValidationErr(err)
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	preview, err = s.mapRecipe(recipe)
+	if base != nil {
+		preview.SourceUrl = base.String()
+	}
+	return
+}
+
+// Import saves the previewed receipt and downloads its image into the gallery.
+// Failed image download does not cancel the import.
+func (s *ReceiptImport) Import(userId uint, request ImportReceiptRequest) (i receipt.FullReceipt, err error) {
+	preview, err := s.Preview(request)
+	if err != nil {
+		return
+	}
+
+	i, err = s.receiptSvc.CreateFullReceipt(userId, preview.Receipt)
+	if err != nil {
+		return
+	}
+
+	if len(preview.ImageUrl) == 0 {
+		return
+	}
+
+	imageErr := s.importImage(i.Receipt, preview.ImageUrl)
+	if imageErr != nil {
+		log.Printf("receipt `%d` image import error: `%s`", i.Id, imageErr)
+		return
+	}
+
+	r, err := s.receiptSvc.receiptRepo.GetById(i.Id)
+	if err != nil {
+		return
+	}
+	i, err = s.receiptSvc.loadFullReceipt(r, userId)
+	return
+}
+
+func (s *ReceiptImport) mapRecipe(recipe schemaorg.Recipe) (preview ReceiptImportPreview, err error) {
+	request := FullReceiptRequest{}
+	request.Name = tools.Truncate(recipe.Name, tools.MaxRegularStringLength)
+	request.Description = tools.Truncate(recipe.Description, tools.MaxRegularStringLength)
+	if len(request.Description) == 0 {
+		request.Description = request.Name
+	}
+	request.Category = importDefaultCategory
+	if len(recipe.Categories) > 0 {
+		request.Category = tools.Truncate(recipe.Categories[0], tools.MaxRegularStringLength)
+	}
+
+	if len(recipe.Cuisines) > 0 {
+		request.Cuisine = tools.Truncate(recipe.Cuisines[0], maxImportLabelLength)
+	}
+	request.PrepTime = importMinutes(recipe.PrepTime)
+	request.CookTime = importMinutes(recipe.CookTime)
+	setImportTotalTime(&request.CreateReceiptRequest, importMinutes(recipe.TotalTime))
+	request.Servings = importServings(recipe.Yield)
+
+	for _, line := range recipe.Ingredients {
+		quantity, name := ingredient.SplitLine(line)
+		if len(name) == 0 {
+			continue
+		}
+		item, itemErr := s.ingredientItem(quantity, name)
+		if itemErr != nil {
+			err = itemErr
+			return
+		}
+		request.Ingredients = append(request.Ingredients, item)
+	}
+
+	for _, step := range recipe.Instructions {
+		if len([]rune(step)) < 3 {
+			continue
+		}
+		request.Directions = append(request.Directions, FullReceiptDirectionRequest{
+			UpdateReceiptDirectionRequest: UpdateReceiptDirectionRequest{
+				Description: tools.Truncate(step, tools.MaxRegularStringLength),
+			},
+		})
+	}
+
+	preview.Receipt = request
+	if len(recipe.Images) > 0 {
+		preview.ImageUrl = recipe.Images[0]
+	}
+	return
+}
+
+// mapCooklangRecipe maps the plain-text recipe, its steps keep durations and temperatures.
+func (s *ReceiptImport) mapCooklangRecipe(recipe cooklang.Recipe) (preview ReceiptImportPreview, err error) {
+	request := FullReceiptRequest{}
+	request.Name = recipe.Name
+	request.Description = recipe.Description
+	if len(request.Description) == 0 {
+		request.Description = request.Name
+	}
+	request.Category = recipe.Category
+	if len(request.Category) == 0 {
+		request.Category = importDefaultCategory
+	}
+	request.Cuisine = tools.Truncate(recipe.Cuisine, maxImportLabelLength)
+	request.Course = tools.Truncate(recipe.Course, maxImportLabelLength)
+	request.Difficulty = recipe.Difficulty
+	request.PrepTime = recipe.PrepTime
+	request.CookTime = recipe.CookTime
+	request.RestTime = recipe.RestTime
+	setImportTotalTime(&request.CreateReceiptRequest, recipe.TotalTime)
+	if recipe.Servings <= maxImportServings {
+		request.Servings = recipe.Servings
+	}
+
+	for _, ingredient := range recipe.Ingredients {
+		item, itemErr := s.ingredientItem(ingredient.Quantity, ingredient.Name)
+		if itemErr != nil {
+			err = itemErr
+			return
+		}
+		request.Ingredients = append(request.Ingredients, item)
+	}
+
+	for _, step := range recipe.Steps {
+		request.Directions = append(request.Directions, FullReceiptDirectionRequest{
+			UpdateReceiptDirectionRequest: UpdateReceiptDirectionRequest{
+				Description: step.Text,
+				Duration:    step.Duration,
+				Passive:     step.Passive,
+				Temperature: step.Temperature,
+			},
+		})
+	}
+
+	preview.Receipt = request
+	return
+}
+
+func importMinutes(d time.Duration) uint {
+	return uint(math.Ceil(d.Minutes()))
+}
+
+// setImportTotalTime keeps the total time given next to its parts, the time which is not
+// a part of prep or cook time is the rest time. Total time alone is the cook time.
+func setImportTotalTime(request *CreateReceiptRequest, total uint) {
+	parts := request.PrepTime + request.CookTime + request.RestTime
+	switch {
+	case parts == 0:
+		request.CookTime = total
+	case total > parts:
+		request.RestTime += total - parts
+	}
+}
+
+// importServings reads the number of servings from yields like `4 servings` or `Serves 4`,
+// yields like `1 loaf` are taken as they are.
+func importServings(yield string) uint {
+	servings, err := strconv.Atoi(servingsPattern.FindString(yield))
+	if err != nil || servings > maxImportServings {
+		return 0
+	}
+	return uint(servings)
+}
+
+// ingredientItem links the ingredient with the existing one of the same name,
+// otherwise it is created with the receipt.
+func (s *ReceiptImport) ingredientItem(quantity, name string) (item FullReceiptIngredientRequest, err error) {
+	if len(quantity) == 0 {
+		quantity = importDefaultQuantity
+	}
+
+	item = FullReceiptIngredientRequest{
+		Quantity: tools.Truncate(quantity, tools.MaxRegularStringLength),
+		Name:     tools.Truncate(name, tools.MaxRegularStringLength),
+	}
+	existing, err := s.ingredientRepo.GetByName(item.Name)
+	if err == nil {
+		item.IngredientId = existing.Id
+	} else if gorm.IsRecordNotFoundError(err) {
+		err = nil
+	}
+	return
+}
+
+func (s *ReceiptImport) get(link *url.URL, accept string) (resp *http.Response, err error) {
+	req, err := http.NewRequest(http.MethodGet, link.String(), nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("User-Agent", importUserAgent)
+	req.Header.Set("Accept", accept)
+
+	resp, err = s.client.Do(req)
+	if err != nil {
+		err = tools.NewValidationErr(fmt.Errorf("cannot fetch `%s`: %s", link, err))
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		err = tools.NewValidationErr(fmt.Errorf("cannot fetch `%s`: status %d", link, resp.StatusCode))
+		return
+	}
+	return
+}
+
+func readLimited(r io.Reader, limit int64) (data []byte, err error) {
+	data, err = ioutil.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return
+	}
+	if int64(len(data)) > limit {
+		err = tools.NewValidationErr(fmt.Errorf("content is bigger than %d bytes", limit))
+	}
+	return
+}
+
+func (s *ReceiptImport) fetchPage(link *url.URL) (page []byte, err error) {
+	resp, err := s.get(link, "text/html,application/xhtml+xml")
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	page, err = readLimited(resp.Body, maxImportPageSize)
+	return
+}
+
+func (s *ReceiptImport) importImage(r receipt.Receipt, imageUrl string) (err error) {
+	link, err := url.Parse(imageUrl)
+	if err != nil {
+		return
+	}
+	err = checkImportURL(link)
+	if err != nil {
+		return
+	}
+
+	resp, err := s.get(link, "image/*")
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	format, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(format, "image/") {
+		err = fmt.Errorf("`%s` is not an image", imageUrl)
+		return
+	}
+
+	data, err := readLimited(resp.Body, maxImportImageSize)
+	if err != nil {
+		return
+	}
+
+	ext := ""
+	if extensions, _ := mime.ExtensionsByType(format); len(extensions) > 0 {
+		ext = extensions[0]
+	}
+	newMedia, err := s.mediaSvc.StoreMedia(bytes.NewReader(data), ext, format, Options{Filename: "dish"})
+	if err != nil {
+		err = errors.Wrap(err, "Error occurred when store imported image")
+		return
+	}
+
+	_, err = s.receiptSvc.createReceiptImage(r, newMedia.Id, AddReceiptImageRequest{Cover: true})
+	return
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"food/src/api/database/dbtest"
+)
+
+const jsonLDPage = `<html><head>
+<script type="application/ld+json">
+{
+  "@context": "https://schema.org",
+  "@graph": [
+    {"@type": "WebPage", "name": "Recipes"},
+    {
+      "@type": "Recipe",
+      "name": "Borscht",
+      "description": "Beetroot soup",
+      "recipeCategory": "soups",
+      "recipeCuisine": "ukrainian",
+      "recipeYield": "6 servings",
+      "prepTime": "PT20M",
+      "cookTime": "PT1H",
+      "totalTime": "PT1H30M",
+      "image": "/images/borscht.jpg",
+      "recipeIngredient": ["500 g beetroot", "2 potatoes", "salt"],
+      "recipeInstructions": [
+        {"@type": "HowToStep", "text": "Boil the beetroot"},
+        {"@type": "HowToStep", "text": "Add potatoes"}
+      ]
+    }
+  ]
+}
+</script></head><body></body></html>`
+
+const microdataPage = `<html><body>
+<div itemscope itemtype="http://schema.org/Recipe">
+  <h1 itemprop="name">Pancakes</h1>
+  <p itemprop="description">Thin pancakes</p>
+  <span itemprop="recipeCategory">breakfast</span>
+  <meta itemprop="cookTime" content="PT30M">
+  <span itemprop="recipeYield">Serves 4</span>
+  <ul>
+    <li itemprop="recipeIngredient">200 g flour</li>
+    <li itemprop="recipeIngredient">2 eggs</li>
+  </ul>
+  <div itemprop="recipeInstructions">Whisk everything and fry</div>
+</div>
+</body></html>`
+
+// newPageServer serves the page at every path.
+func newPageServer(page string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, page)
+	}))
+}
+
+func TestImportPreviewJSONLD(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	server := newPageServer(jsonLDPage)
+	defer server.Close()
+
+	s := NewReceiptImportService(db, NewImportHTTPClient(true))
+	preview, err := s.Preview(ImportReceiptRequest{Url: server.URL + "/recipes/borscht"})
+	if err != nil {
+		t.Fatalf("cannot preview: %v", err)
+	}
+
+	r := preview.Receipt
+	if r.Name != "Borscht" || r.Category != "soups" || r.Cuisine != "ukrainian" || r.Servings != 6 {
+		t.Errorf("receipt fields are not mapped: %+v", r.CreateReceiptRequest)
+	}
+	if r.PrepTime != 20 || r.CookTime != 60 || r.RestTime != 10 {
+		t.Errorf("times are %d, %d, %d, expected 20, 60, 10", r.PrepTime, r.CookTime, r.RestTime)
+	}
+	if len(r.Ingredients) != 3 || r.Ingredients[0].Quantity != "500 g" || r.Ingredients[0].Name != "beetroot" ||
+		r.Ingredients[2].Quantity != importDefaultQuantity {
+		t.Errorf("ingredients are not mapped: %+v", r.Ingredients)
+	}
+	if len(r.Directions) != 2 || r.Directions[1].Description != "Add potatoes" {
+		t.Errorf("directions are not mapped: %+v", r.Directions)
+	}
+	if preview.ImageUrl != server.URL+"/images/borscht.jpg" {
+		t.Errorf("image url %s is not resolved", preview.ImageUrl)
+	}
+}
+
+func TestImportPreviewMicrodata(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	server := newPageServer(microdataPage)
+	defer server.Close()
+
+	s := NewReceiptImportService(db, NewImportHTTPClient(true))
+	preview, err := s.Preview(ImportReceiptRequest{Url: server.URL})
+	if err != nil {
+		t.Fatalf("cannot preview: %v", err)
+	}
+
+	r := preview.Receipt
+	if r.Name != "Pancakes" || r.Description != "Thin pancakes" || r.CookTime != 30 || r.Servings != 4 {
+		t.Errorf("receipt fields are not mapped: %+v", r.CreateReceiptRequest)
+	}
+	if len(r.Ingredients) != 2 || r.Ingredients[1].Quantity != "2" || r.Ingredients[1].Name != "eggs" {
+		t.Errorf("ingredients are not mapped: %+v", r.Ingredients)
+	}
+	if len(r.Directions) != 1 {
+		t.Errorf("directions are not mapped: %+v", r.Directions)
+	}
+}
+
+func TestImportRejectsPages(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		fmt.Fprint(w, jsonLDPage)
+	}))
+	defer slow.Close()
+	big := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, strings.Repeat(" ", maxImportPageSize+1))
+	}))
+	defer big.Close()
+	missing := httptest.NewServer(http.NotFoundHandler())
+	defer missing.Close()
+	article := newPageServer(`<html><body><article><h1>Not a recipe</h1></article></body></html>`)
+	defer article.Close()
+
+	client := NewImportHTTPClient(true)
+	client.Timeout = 50 * time.Millisecond
+	s := NewReceiptImportService(nil, client)
+
+	tests := []struct {
+		name    string
+		request ImportReceiptRequest
+	}{
+		{"slow page", ImportReceiptRequest{Url: slow.URL}},
+		{"big page", ImportReceiptRequest{Url: big.URL}},
+		{"missing page", ImportReceiptRequest{Url: missing.URL}},
+		{"page without recipe", ImportReceiptRequest{Url: article.URL}},
+		{"html without recipe", ImportReceiptRequest{Html: "<p>Hello</p>"}},
+		{"other scheme", ImportReceiptRequest{Url: "ftp://example.com/recipe"}},
+		{"text with url", ImportReceiptRequest{Url: article.URL, Text: ">> title: Tea"}},
+	}
+	for _, test := range tests {
+		_, err := s.Preview(test.request)
+		if !isValidationErr(err) {
+			t.Errorf("%s: error %v, expected validation error", test.name, err)
+		}
+	}
+}
+
+func mustParseURL(t *testing.T, link string) *url.URL {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("cannot parse url: %v", err)
+	}
+	return parsed
+}
+
+// newPublicHostClient returns the client refusing private hosts which resolves
+// the public host to the local server, like DNS would.
+func newPublicHostClient(host string, server *httptest.Server) *http.Client {
+	client := NewImportHTTPClient(false)
+	dial := client.Transport.(*http.Transport).DialContext
+	client.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			if address == host+":80" {
+				return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+			}
+			return dial(ctx, network, address)
+		},
+	}
+	return client
+}
+
+func TestImportRejectsPrivateHosts(t *testing.T) {
+	private := newPageServer(jsonLDPage)
+	defer private.Close()
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target := private.URL
+		if r.URL.Path == "/scheme" {
+			target = "file:///etc/passwd"
+		}
+		http.Redirect(w, r, target, http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	s := NewReceiptImportService(nil, newPublicHostClient("recipes.example", redirect))
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"private host", private.URL},
+		{"redirect to private host", "http://recipes.example/borscht"},
+		{"redirect to other scheme", "http://recipes.example/scheme"},
+	}
+	for _, test := range tests {
+		_, err := s.Preview(ImportReceiptRequest{Url: test.url})
+		if !isValidationErr(err) {
+			t.Errorf("%s: error %v, expected validation error", test.name, err)
+		}
+	}
+
+	// the local server is reachable when private hosts are allowed
+	s = NewReceiptImportService(nil, NewImportHTTPClient(true))
+	_, err := s.fetchPage(mustParseURL(t, private.URL))
+	if err != nil {
+		t.Errorf("allowed private host is refused: %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import "food/src/api/models/receipt"
+
+// markReceipts fills the read only fields of the receipts which are not stored with them,
+// the favorited flag for the given user and diet labels.
+func (s *Receipt) markReceipts(userId uint, receipts []receipt.Receipt) (err error) {
+	err = s.markFavorites(userId, receipts)
+	if err != nil {
+		return
+	}
+	err = s.markLabels(receipts)
+	return
+}
+
+// markLabels fills diet labels of the receipts from flags of their ingredients.
+func (s *Receipt) markLabels(receipts []receipt.Receipt) (err error) {
+	ids := make([]uint, 0, len(receipts))
+	for _, r := range receipts {
+		ids = append(ids, r.Id)
+	}
+
+	labels, err := s.receiptRepo.GetLabels(ids)
+	if err != nil {
+		return
+	}
+	for i := range receipts {
+		receipts[i].Labels = labels[receipts[i].Id]
+	}
+	return
+}
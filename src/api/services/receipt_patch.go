@@ -0,0 +1,271 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"food/src/api/models/patch"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"github.com/jinzhu/gorm"
+	"reflect"
+	"strings"
+)
+
+// PatchRequest is a merge patch or JSON Patch document, told apart by its media type.
+type PatchRequest struct {
+	MediaType string
+	Body      []byte
+}
+
+// applyPatch applies the patch to the JSON document of the request and decodes the result
+// back into it. Struct names of the changed fields are returned, only they are validated.
+func applyPatch(request interface{}, p PatchRequest) (changed []string, err error) {
+	before, after, err := patchDocument(request, p)
+	if err != nil {
+		return
+	}
+	changed, err = changedFields(reflect.TypeOf(request).Elem(), before, after)
+	if err != nil || len(changed) == 0 {
+		return
+	}
+
+	err = tools.Validator.StructPartial(request, changed...)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+	}
+	return
+}
+
+// patchDocument applies the patch to the request, the documents before and after are returned.
+func patchDocument(request interface{}, p PatchRequest) (before, after []byte, err error) {
+	before, err = json.Marshal(request)
+	if err != nil {
+		return
+	}
+	patched, err := patch.ApplyMediaType(p.MediaType, before, p.Body)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	value := reflect.ValueOf(request).Elem()
+	value.Set(reflect.Zero(value.Type()))
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(request)
+	if err != nil {
+		err = tools.NewValidationErr(fmt.Errorf("patched item is invalid: %s", err))
+		return
+	}
+	if trimmer, ok := request.(interface{ TrimSpaces() }); ok {
+		trimmer.TrimSpaces()
+	}
+
+	after, err = json.Marshal(request)
+	return
+}
+
+// changedFields compares top level members of the documents and returns struct names of the changed ones.
+func changedFields(t reflect.Type, before, after []byte) (fields []string, err error) {
+	var old, updated map[string]json.RawMessage
+	err = json.Unmarshal(before, &old)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(after, &updated)
+	if err != nil {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if len(name) == 0 || name == "-" {
+			continue
+		}
+		if !bytes.Equal(old[name], updated[name]) {
+			fields = append(fields, field.Name)
+		}
+	}
+	return
+}
+
+// PatchReceipt changes the receipt fields given in the patch.
+func (s *Receipt) PatchReceipt(id, userId uint, p PatchRequest, ifMatch string) (i receipt.Receipt, err error) {
+	oldItem, err := s.getPermittedReceipt(id, userId, receipt.EditorRole)
+	if err != nil {
+		return
+	}
+	err = checkETag(ifMatch, oldItem)
+	if err != nil {
+		return
+	}
+
+	request := UpdateReceiptRequest(newCreateReceiptRequest(oldItem))
+	changed, err := applyPatch(&request, p)
+	if err != nil {
+		return
+	}
+	if len(changed) == 0 {
+		return s.GetReceipt(id, userId)
+	}
+
+	return s.updateReceipt(oldItem, request, userId)
+}
+
+// PatchReceiptIngredient changes the quantity of the receipt ingredient.
+func (s *Receipt) PatchReceiptIngredient(receiptId, rIngredientId, userId uint, p PatchRequest, ifMatch string) (i receipt.ReceiptIngredient, err error) {
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
+		return
+	}
+
+	oldIngredient, err := s.getReceiptIngredient(receiptId, rIngredientId)
+	if err != nil {
+		return
+	}
+	err = checkETag(ifMatch, oldIngredient)
+	if err != nil {
+		return
+	}
+
+	request := UpdateReceiptIngredientRequest{Quantity: oldIngredient.Quantity}
+	changed, err := applyPatch(&request, p)
+	if err != nil || len(changed) == 0 {
+		i = oldIngredient
+		return
+	}
+
+	i = receipt.ReceiptIngredient{
+		Id:       rIngredientId,
+		Quantity: request.Quantity,
+	}
+	err = s.receiptRepo.UpdateIngredient(&i)
+	if err != nil {
+		return
+	}
+
+	i, err = s.receiptRepo.GetIngredientById(i.Id)
+	return
+}
+
+// PatchReceiptDirection changes the step fields given in the patch, null clears duration and temperature.
+func (s *Receipt) PatchReceiptDirection(receiptId, rDirectionId, userId uint, p PatchRequest, ifMatch string) (i receipt.ReceiptDirection, err error) {
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
+		return
+	}
+
+	oldDirection, err := s.getReceiptDirection(receiptId, rDirectionId)
+	if err != nil {
+		return
+	}
+	err = checkETag(ifMatch, oldDirection)
+	if err != nil {
+		return
+	}
+
+	request := UpdateReceiptDirectionRequest{
+		Description: oldDirection.Description,
+		Duration:    oldDirection.Duration,
+		Passive:     oldDirection.Passive,
+		Temperature: oldDirection.Temperature,
+	}
+	changed, err := applyPatch(&request, p)
+	if err != nil || len(changed) == 0 {
+		i = oldDirection
+		return
+	}
+
+	i = request.direction()
+	i.Id = rDirectionId
+	err = s.receiptRepo.UpdateDirection(&i)
+	if err != nil {
+		return
+	}
+
+	err = s.receiptRepo.RefreshTimes(receiptId)
+	if err != nil {
+		return
+	}
+
+	i, err = s.receiptRepo.GetDirectionById(i.Id)
+	return
+}
+
+// PatchFullReceipt edits the receipt together with its ingredient and direction lists,
+// like adding, removing or moving items with JSON Patch. The whole result is validated.
+func (s *Receipt) PatchFullReceipt(id, userId uint, p PatchRequest, ifMatch string) (i receipt.FullReceipt, err error) {
+	r, err := s.getPermittedReceipt(id, userId, receipt.EditorRole)
+	if err != nil {
+		return
+	}
+	current, err := s.loadFullReceipt(r, userId)
+	if err != nil {
+		return
+	}
+	err = checkETag(ifMatch, current)
+	if err != nil {
+		return
+	}
+
+	request := newFullReceiptRequest(current)
+	before, after, err := patchDocument(&request, p)
+	if err != nil {
+		return
+	}
+	if bytes.Equal(before, after) {
+		i = current
+		return
+	}
+
+	return s.saveFullReceipt(r, request, userId)
+}
+
+// newFullReceiptRequest returns the editable document of the receipt,
+// its rows keep their ids, so the patched ones are updated in place.
+func newFullReceiptRequest(r receipt.FullReceipt) FullReceiptRequest {
+	request := FullReceiptRequest{
+		CreateReceiptRequest: newCreateReceiptRequest(r.Receipt),
+		Ingredients: []FullReceiptIngredientRequest{},
+		Directions:  []FullReceiptDirectionRequest{},
+	}
+	for _, item := range r.Ingredients {
+		request.Ingredients = append(request.Ingredients, FullReceiptIngredientRequest{
+			Id:           item.Id,
+			Quantity:     item.Quantity,
+			IngredientId: item.IngredientId,
+		})
+	}
+	for _, item := range r.Directions {
+		request.Directions = append(request.Directions, FullReceiptDirectionRequest{
+			Id: item.Id,
+			UpdateReceiptDirectionRequest: UpdateReceiptDirectionRequest{
+				Description: item.Description,
+				Duration:    item.Duration,
+				Passive:     item.Passive,
+				Temperature: item.Temperature,
+			},
+		})
+	}
+	return request
+}
+
+// getReceiptIngredient returns the ingredient which belongs to the receipt.
+func (s *Receipt) getReceiptIngredient(receiptId, rIngredientId uint) (i receipt.ReceiptIngredient, err error) {
+	i, err = s.receiptRepo.GetIngredientById(rIngredientId)
+	if gorm.IsRecordNotFoundError(err) || (err == nil && i.ReceiptId != receiptId) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+	}
+	return
+}
+
+// getReceiptDirection returns the step which belongs to the receipt.
+func (s *Receipt) getReceiptDirection(receiptId, rDirectionId uint) (i receipt.ReceiptDirection, err error) {
+	i, err = s.receiptRepo.GetDirectionById(rDirectionId)
+	if gorm.IsRecordNotFoundError(err) || (err == nil && i.ReceiptId != receiptId) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+	}
+	return
+}
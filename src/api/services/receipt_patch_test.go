@@ -0,0 +1,102 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"food/src/api/database/dbtest"
+	"food/src/api/models/patch"
+)
+
+func TestPatchFullReceiptKeepsRows(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	s := GetReceiptService(db)
+
+	userId := dbtest.CreateUser(t, db, "cook")
+	r := newTestFullReceipt(t, s, userId)
+	removedMediaId := addDirectionMedia(t, s, db, r.Directions[0])
+	mediaId := addDirectionMedia(t, s, db, r.Directions[1])
+	_, err := s.SaveDirectionTranslation(r.Id, r.Directions[1].Id, userId, "uk", SaveDirectionTranslationRequest{Description: "Смажити на сковороді"})
+	if err != nil {
+		t.Fatalf("cannot translate step: %v", err)
+	}
+	current, err := s.GetFullReceipt(r.Id, userId)
+	if err != nil {
+		t.Fatalf("cannot get receipt: %v", err)
+	}
+	etag := current.ETag()
+
+	p := PatchRequest{MediaType: patch.JSONPatchMediaType, Body: []byte(`[
+		{"op": "replace", "path": "/directions/1/description", "value": "Fry on a very hot pan"},
+		{"op": "remove", "path": "/directions/0"},
+		{"op": "add", "path": "/directions/0", "value": {"description": "Sift the flour"}},
+		{"op": "move", "from": "/ingredients/1", "path": "/ingredients/0"},
+		{"op": "replace", "path": "/ingredients/1/quantity", "value": "250 g"}
+	]`)}
+	patched, err := s.PatchFullReceipt(r.Id, userId, p, etag)
+	if err != nil {
+		t.Fatalf("cannot patch receipt: %v", err)
+	}
+
+	if len(patched.Ingredients) != 2 || patched.Ingredients[0].Id != r.Ingredients[1].Id ||
+		patched.Ingredients[1].Id != r.Ingredients[0].Id || patched.Ingredients[1].Quantity != "250 g" {
+		t.Errorf("ingredients are not moved in place: %+v", patched.Ingredients)
+	}
+	if len(patched.Directions) != 2 {
+		t.Fatalf("got %d directions, expected 2", len(patched.Directions))
+	}
+	added, step := patched.Directions[0], patched.Directions[1]
+	if added.Id == r.Directions[0].Id || added.MediaId != nil {
+		t.Errorf("added step takes the row of the removed one: %+v, media %v of %d", added, added.MediaId, removedMediaId)
+	}
+	if step.Id != r.Directions[1].Id || step.Description != "Fry on a very hot pan" {
+		t.Errorf("direction is not updated in place: %+v", step)
+	}
+	if step.MediaId == nil || *step.MediaId != mediaId {
+		t.Errorf("direction media is lost: %v", step.MediaId)
+	}
+
+	translated, err := s.GetFullReceipt(r.Id, userId)
+	if err != nil {
+		t.Fatalf("cannot get receipt: %v", err)
+	}
+	err = s.TranslateFullReceipt("uk", &translated)
+	if err != nil {
+		t.Fatalf("cannot translate receipt: %v", err)
+	}
+	if translated.Directions[1].Description != "Смажити на сковороді" {
+		t.Errorf("direction translation is lost: %s", translated.Directions[1].Description)
+	}
+
+	_, err = s.PatchFullReceipt(r.Id, userId, p, etag)
+	if !isPreconditionFailed(err) {
+		t.Errorf("patch of the changed receipt is applied, error %v", err)
+	}
+}
+
+func TestPatchFullReceiptRejectsForeignRows(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	s := GetReceiptService(db)
+
+	userId := dbtest.CreateUser(t, db, "cook")
+	r := newTestFullReceipt(t, s, userId)
+	other := newTestFullReceipt(t, s, userId)
+
+	p := PatchRequest{MediaType: patch.MergePatchMediaType, Body: []byte(`{"name": "Crepes"}`)}
+	patched, err := s.PatchFullReceipt(r.Id, userId, p, "")
+	if err != nil {
+		t.Fatalf("cannot patch receipt: %v", err)
+	}
+	if patched.Name != "Crepes" || patched.Directions[0].Id != r.Directions[0].Id || patched.Ingredients[1].Id != r.Ingredients[1].Id {
+		t.Errorf("rows are not kept: %+v", patched)
+	}
+
+	p = PatchRequest{MediaType: patch.JSONPatchMediaType,
+		Body: []byte(fmt.Sprintf(`[{"op": "replace", "path": "/directions/0/id", "value": %d}]`, other.Directions[0].Id))}
+	_, err = s.PatchFullReceipt(r.Id, userId, p, "")
+	if !isValidationErr(err) {
+		t.Errorf("direction of another receipt is accepted, error %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"fmt"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"github.com/jinzhu/gorm"
+	"strings"
+)
+
+type CreateReceiptReviewRequest struct {
+	// from 1 to 5 (required)
+	Rating uint   `json:"rating" minimum:"1" maximum:"5" binding:"required" validate:"min=1,max=5"`
+	Review string `json:"review" maxLength:"4096" validate:"max=4096"`
+}
+
+func (u *CreateReceiptReviewRequest) TrimSpaces() {
+	u.Review = strings.TrimSpace(u.Review)
+}
+
+type UpdateReceiptReviewRequest struct {
+	CreateReceiptReviewRequest
+}
+
+func (s *Receipt) GetAllReceiptReviewsById(id, userId uint) (reviews []receipt.ReceiptReview, err error) {
+	_, err = s.getPermittedReceipt(id, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+	reviews, err = s.receiptRepo.GetReviewsById(id)
+	return
+}
+
+func (s *Receipt) CreateReceiptReview(receiptId, userId uint, request CreateReceiptReviewRequest) (i receipt.ReceiptReview, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	r, err := s.getPermittedReceipt(receiptId, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+
+	if r.UserId == userId {
+		err = tools.NewNotPermittedErr(fmt.Errorf("user cannot rate own receipt"))
+		return
+	}
+
+	_, err = s.receiptRepo.GetReviewByUser(receiptId, userId)
+	if err == nil {
+		err = tools.NewValidationErr(fmt.Errorf("receipt is already reviewed by user"))
+		return
+	}
+	if !gorm.IsRecordNotFoundError(err) {
+		return
+	}
+
+	i = receipt.ReceiptReview{
+		ReceiptId: receiptId,
+		UserId:    userId,
+		Rating:    request.Rating,
+		Review:    request.Review,
+	}
+	err = s.receiptRepo.CreateReview(&i)
+	if err != nil {
+		return
+	}
+
+	err = s.receiptRepo.RefreshRating(receiptId)
+	return
+}
+
+func (s *Receipt) UpdateReceiptReview(receiptId, reviewId, userId uint, request UpdateReceiptReviewRequest) (i receipt.ReceiptReview, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	i, err = s.getOwnReview(receiptId, reviewId, userId)
+	if err != nil {
+		return
+	}
+
+	i.Rating = request.Rating
+	i.Review = request.Review
+	err = s.receiptRepo.UpdateReview(&i)
+	if err != nil {
+		return
+	}
+
+	err = s.receiptRepo.RefreshRating(receiptId)
+	if err != nil {
+		return
+	}
+
+	i, err = s.receiptRepo.GetReviewById(i.Id)
+	return
+}
+
+func (s *Receipt) DeleteReceiptReview(receiptId, reviewId, userId uint) (err error) {
+	_, err = s.getOwnReview(receiptId, reviewId, userId)
+	if err != nil {
+		return
+	}
+
+	err = s.receiptRepo.DeleteReviewById(reviewId)
+	if err != nil {
+		return
+	}
+
+	err = s.receiptRepo.RefreshRating(receiptId)
+	return
+}
+
+// getOwnReview loads the review of the receipt and checks that it was written by the user.
+func (s *Receipt) getOwnReview(receiptId, reviewId, userId uint) (review receipt.ReceiptReview, err error) {
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+
+	review, err = s.receiptRepo.GetReviewById(reviewId)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if review.ReceiptId != receiptId {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+
+	if review.UserId != userId {
+		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismatch"))
+		return
+	}
+	return
+}
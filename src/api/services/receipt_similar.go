@@ -0,0 +1,185 @@
+package services
+
+import (
+	"fmt"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"sort"
+	"sync"
+)
+
+const (
+	DefaultSimilarLimit = 10
+	MaxSimilarLimit     = 50
+	// cached rankings are dropped all at once when there are more of them
+	maxSimilarCacheSize = 10000
+)
+
+type similarKey struct {
+	receiptId uint
+	userId    uint
+}
+
+type similarItem struct {
+	receiptId  uint
+	similarity float64
+}
+
+// similarCache keeps rankings of similar receipts until receipts, their ingredients,
+// ingredient flags or accesses change, then everything is recomputed.
+var similarCache = struct {
+	sync.Mutex
+	version  string
+	rarity   *receipt.RarityIndex
+	rankings map[similarKey][]similarItem
+}{}
+
+// GetSimilarReceipts returns receipts visible to the user which are the most similar
+// to the given one by ingredients, category, tags and total time.
+func (s *Receipt) GetSimilarReceipts(id, userId uint, limit int) (items []receipt.SimilarReceipt, err error) {
+	if limit == 0 {
+		limit = DefaultSimilarLimit
+	}
+	if limit < 1 || limit > MaxSimilarLimit {
+		err = tools.NewValidationErr(fmt.Errorf("limit should be between 1 and %d", MaxSimilarLimit))
+		return
+	}
+
+	_, err = s.getPermittedReceipt(id, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+
+	ranking, err := s.getSimilarRanking(id, userId)
+	if err != nil {
+		return
+	}
+	if len(ranking) > limit {
+		ranking = ranking[:limit]
+	}
+
+	ids := make([]uint, 0, len(ranking))
+	for _, item := range ranking {
+		ids = append(ids, item.receiptId)
+	}
+	receipts, err := s.receiptRepo.GetByIds(ids)
+	if err != nil {
+		return
+	}
+	err = s.markReceipts(userId, receipts)
+	if err != nil {
+		return
+	}
+
+	byId := make(map[uint]receipt.Receipt, len(receipts))
+	for _, r := range receipts {
+		byId[r.Id] = r
+	}
+	items = []receipt.SimilarReceipt{}
+	for _, item := range ranking {
+		if r, ok := byId[item.receiptId]; ok {
+			items = append(items, receipt.SimilarReceipt{Receipt: r, Similarity: item.similarity})
+		}
+	}
+	return
+}
+
+// getSimilarRanking returns the cached ranking when nothing changed since it was computed.
+func (s *Receipt) getSimilarRanking(id, userId uint) (ranking []similarItem, err error) {
+	version, err := s.receiptRepo.GetVersion()
+	if err != nil {
+		return
+	}
+
+	key := similarKey{receiptId: id, userId: userId}
+	similarCache.Lock()
+	if similarCache.version != version || len(similarCache.rankings) >= maxSimilarCacheSize {
+		similarCache.version = version
+		similarCache.rarity = nil
+		similarCache.rankings = map[similarKey][]similarItem{}
+	}
+	ranking, ok := similarCache.rankings[key]
+	rarity := similarCache.rarity
+	similarCache.Unlock()
+	if ok {
+		return
+	}
+
+	if rarity == nil {
+		var total int
+		var counts map[uint]int
+		total, counts, err = s.receiptRepo.GetIngredientCounts()
+		if err != nil {
+			return
+		}
+		index := receipt.NewRarityIndex(total, counts)
+		rarity = &index
+	}
+
+	ranking, err = s.rankSimilar(id, userId, *rarity)
+	if err != nil {
+		return
+	}
+
+	similarCache.Lock()
+	if similarCache.version == version {
+		similarCache.rarity = rarity
+		similarCache.rankings[key] = ranking
+	}
+	similarCache.Unlock()
+	return
+}
+
+// rankSimilar compares the receipt with all other receipts visible to the user.
+func (s *Receipt) rankSimilar(id, userId uint, rarity receipt.RarityIndex) (ranking []similarItem, err error) {
+	receipts, err := s.receiptRepo.GetAllVisible(userId, receipt.ListFilter{})
+	if err != nil {
+		return
+	}
+
+	ids := make([]uint, 0, len(receipts))
+	full := make(map[uint]*receipt.FullReceipt, len(receipts))
+	for _, r := range receipts {
+		ids = append(ids, r.Id)
+		full[r.Id] = &receipt.FullReceipt{Receipt: r}
+	}
+	current, ok := full[id]
+	if !ok {
+		return
+	}
+
+	ingredients, err := s.receiptRepo.GetIngredientsByIds(ids)
+	if err != nil {
+		return
+	}
+	for _, item := range ingredients {
+		full[item.ReceiptId].Ingredients = append(full[item.ReceiptId].Ingredients, item)
+	}
+	labels, err := s.receiptRepo.GetLabels(ids)
+	if err != nil {
+		return
+	}
+	for receiptId, receiptLabels := range labels {
+		full[receiptId].Labels = receiptLabels
+	}
+
+	for _, other := range full {
+		if other.Id == id {
+			continue
+		}
+		similarity := rarity.Similarity(*current, *other)
+		if similarity > 0 {
+			ranking = append(ranking, similarItem{receiptId: other.Id, similarity: similarity})
+		}
+	}
+	sort.Slice(ranking, func(i, j int) bool {
+		if ranking[i].similarity != ranking[j].similarity {
+			return ranking[i].similarity > ranking[j].similarity
+		}
+		return ranking[i].receiptId < ranking[j].receiptId
+	})
+	if len(ranking) > MaxSimilarLimit {
+		ranking = ranking[:MaxSimilarLimit]
+	}
+	return
+}
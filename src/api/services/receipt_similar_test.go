@@ -0,0 +1,50 @@
+package services
+
+import (
+	"testing"
+
+	"food/src/api/database/dbtest"
+)
+
+func TestSimilarReceiptsCache(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	s := GetReceiptService(db)
+
+	userId := dbtest.CreateUser(t, db, "cook")
+	var ids []uint
+	for _, cuisine := range []string{"italian", "italian", "thai"} {
+		r, err := s.CreateReceipt(userId, CreateReceiptRequest{
+			Name:        "Dinner",
+			Description: "Dinner of the day",
+			Category:    "dinner " + cuisine,
+			Cuisine:     cuisine,
+			CookTime:    30,
+		})
+		if err != nil {
+			t.Fatalf("cannot create receipt: %v", err)
+		}
+		ids = append(ids, r.Id)
+	}
+
+	similar, err := s.GetSimilarReceipts(ids[0], userId, 0)
+	if err != nil {
+		t.Fatalf("cannot get similar receipts: %v", err)
+	}
+	if len(similar) != 1 || similar[0].Id != ids[1] {
+		t.Errorf("similar receipts are %+v, expected receipt %d of the same cuisine", similar, ids[1])
+	}
+
+	// changes which keep updated_at are seen too
+	err = db.Exec("UPDATE receipts SET cuisine = 'italian' WHERE id = ?", ids[2]).Error
+	if err != nil {
+		t.Fatalf("cannot change cuisine: %v", err)
+	}
+	similar, err = s.GetSimilarReceipts(ids[0], userId, 0)
+	if err != nil {
+		t.Fatalf("cannot get similar receipts: %v", err)
+	}
+	if len(similar) != 2 {
+		t.Errorf("similar receipts are %+v, expected receipts %d and %d", similar, ids[1], ids[2])
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"food/src/api/database/dbtest"
+	"food/src/api/models/patch"
+)
+
+func TestReceiptCookingTime(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	s := GetReceiptService(db)
+	userId := dbtest.CreateUser(t, db, "cook")
+
+	// older clients send the single cooking time
+	var request CreateReceiptRequest
+	err := json.Unmarshal([]byte(`{"name": "Borscht", "description": "Beetroot soup", "category": "soups", "cooking_time": 90}`), &request)
+	if err != nil {
+		t.Fatalf("cannot decode request: %v", err)
+	}
+	r, err := s.CreateReceipt(userId, request)
+	if err != nil {
+		t.Fatalf("cannot create receipt: %v", err)
+	}
+	if r.CookTime != 90 || r.TotalTime != 90 || r.CookingTime != 90 {
+		t.Errorf("times are %d cook, %d total, %d cooking, expected 90", r.CookTime, r.TotalTime, r.CookingTime)
+	}
+	body, err := json.Marshal(r)
+	if err != nil || !strings.Contains(string(body), `"cooking_time":90`) {
+		t.Errorf("cooking time is not sent: %s, error %v", body, err)
+	}
+
+	update := UpdateReceiptRequest(newCreateReceiptRequest(r))
+	update.PrepTime = 20
+	update.CookTime = 40
+	update.CookingTime = 90
+	r, err = s.UpdateReceipt(r.Id, userId, update, "")
+	if err != nil {
+		t.Fatalf("cannot update receipt: %v", err)
+	}
+	if r.CookTime != 40 || r.TotalTime != 60 || r.CookingTime != 60 {
+		t.Errorf("times are %d cook, %d total, %d cooking, expected 40, 60 and 60", r.CookTime, r.TotalTime, r.CookingTime)
+	}
+	read, err := s.GetReceipt(r.Id, userId)
+	if err != nil || read.CookingTime != 60 {
+		t.Errorf("cooking time of the read receipt is %d, expected 60, error %v", read.CookingTime, err)
+	}
+
+	request.CookingTime = 0
+	_, err = s.CreateReceipt(userId, request)
+	if !isValidationErr(err) {
+		t.Errorf("receipt without time is created, error %v", err)
+	}
+	update.PrepTime, update.CookTime, update.CookingTime = 0, 0, 0
+	_, err = s.UpdateReceipt(r.Id, userId, update, "")
+	if !isValidationErr(err) {
+		t.Errorf("receipt times are removed, error %v", err)
+	}
+}
+
+func TestPatchReceiptCookingTime(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	s := GetReceiptService(db)
+	userId := dbtest.CreateUser(t, db, "cook")
+
+	r, err := s.CreateReceipt(userId, CreateReceiptRequest{
+		Name:        "Borscht",
+		Description: "Beetroot soup",
+		Category:    "soups",
+		PrepTime:    10,
+		CookTime:    20,
+	})
+	if err != nil {
+		t.Fatalf("cannot create receipt: %v", err)
+	}
+
+	// older clients change just the cooking time, the cook time makes up the new total
+	p := PatchRequest{MediaType: patch.MergePatchMediaType, Body: []byte(`{"cooking_time": 45}`)}
+	r, err = s.PatchReceipt(r.Id, userId, p, "")
+	if err != nil {
+		t.Fatalf("cannot patch receipt: %v", err)
+	}
+	if r.PrepTime != 10 || r.CookTime != 35 || r.TotalTime != 45 || r.CookingTime != 45 {
+		t.Errorf("times are %d prep, %d cook, %d total, %d cooking, expected 10, 35, 45 and 45", r.PrepTime, r.CookTime, r.TotalTime, r.CookingTime)
+	}
+
+	// the unchanged cooking time of the document does not override the patched times
+	p.Body = []byte(`{"prep_time": 15}`)
+	r, err = s.PatchReceipt(r.Id, userId, p, "")
+	if err != nil {
+		t.Fatalf("cannot patch receipt: %v", err)
+	}
+	if r.PrepTime != 15 || r.CookTime != 35 || r.TotalTime != 50 {
+		t.Errorf("times are %d prep, %d cook, %d total, expected 15, 35 and 50", r.PrepTime, r.CookTime, r.TotalTime)
+	}
+
+	p.Body = []byte(`{"cooking_time": 5}`)
+	_, err = s.PatchReceipt(r.Id, userId, p, "")
+	if !isValidationErr(err) {
+		t.Errorf("cooking time shorter than prep time is accepted, error %v", err)
+	}
+
+	created, err := s.CreateReceipt(userId, CreateReceiptRequest{
+		Name:        "Borscht",
+		Description: "Beetroot soup",
+		Category:    "soups",
+		PrepTime:    10,
+		CookTime:    20,
+		CookingTime: 60,
+	})
+	if err != nil {
+		t.Fatalf("cannot create receipt: %v", err)
+	}
+	if created.CookTime != 50 || created.TotalTime != 60 {
+		t.Errorf("times are %d cook, %d total, expected 50 and 60", created.CookTime, created.TotalTime)
+	}
+}
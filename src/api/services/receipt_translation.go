@@ -0,0 +1,306 @@
+package services
+
+import (
+	"fmt"
+	"food/src/api/models/ingredient"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"github.com/jinzhu/gorm"
+	"strings"
+)
+
+type SaveReceiptTranslationRequest struct {
+	// (required)
+	Name        string `json:"name" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
+	Description string `json:"description" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
+}
+
+func (u *SaveReceiptTranslationRequest) TrimSpaces() {
+	u.Name = strings.TrimSpace(u.Name)
+	u.Description = strings.TrimSpace(u.Description)
+}
+
+type SaveDirectionTranslationRequest struct {
+	// (required)
+	Description string `json:"description" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
+}
+
+func (u *SaveDirectionTranslationRequest) TrimSpaces() {
+	u.Description = strings.TrimSpace(u.Description)
+}
+
+type SaveIngredientTranslationRequest struct {
+	// (required)
+	Name string `json:"name" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
+}
+
+func (u *SaveIngredientTranslationRequest) TrimSpaces() {
+	u.Name = strings.TrimSpace(u.Name)
+}
+
+// checkTranslationLanguage tells whether the language of the translation is supported.
+func checkTranslationLanguage(language string) error {
+	if !tools.IsValidLanguage(language) {
+		return tools.NewValidationErr(fmt.Errorf("language `%s` is not supported", language))
+	}
+	return nil
+}
+
+// TranslateReceipts replaces names and descriptions of the receipts with their translations to the language,
+// receipts in that language or without its translation keep the original ones.
+func (s *Receipt) TranslateReceipts(language string, receipts []receipt.Receipt) (err error) {
+	if len(language) == 0 {
+		return
+	}
+
+	ids := make([]uint, 0, len(receipts))
+	for _, r := range receipts {
+		if r.Language != language {
+			ids = append(ids, r.Id)
+		}
+	}
+	translations, err := s.receiptRepo.GetTranslations(ids, language)
+	if err != nil {
+		return
+	}
+
+	byReceipt := make(map[uint]receipt.ReceiptTranslation, len(translations))
+	for _, t := range translations {
+		byReceipt[t.ReceiptId] = t
+	}
+	for i := range receipts {
+		if t, ok := byReceipt[receipts[i].Id]; ok {
+			receipts[i].Name = t.Name
+			receipts[i].Description = t.Description
+			receipts[i].Language = language
+		}
+	}
+	return
+}
+
+// TranslateReceipt replaces the name and description of the receipt with its translation to the language.
+func (s *Receipt) TranslateReceipt(language string, i *receipt.Receipt) (err error) {
+	receipts := []receipt.Receipt{*i}
+	err = s.TranslateReceipts(language, receipts)
+	if err != nil {
+		return
+	}
+	*i = receipts[0]
+	return
+}
+
+// TranslateReceiptDirections replaces descriptions of the steps with their translations to the language.
+func (s *Receipt) TranslateReceiptDirections(language string, directions []receipt.ReceiptDirection) (err error) {
+	if len(language) == 0 {
+		return
+	}
+
+	ids := make([]uint, 0, len(directions))
+	for _, d := range directions {
+		ids = append(ids, d.Id)
+	}
+	translations, err := s.receiptRepo.GetDirectionTranslations(ids, language)
+	if err != nil {
+		return
+	}
+
+	byDirection := make(map[uint]string, len(translations))
+	for _, t := range translations {
+		byDirection[t.DirectionId] = t.Description
+	}
+	for i := range directions {
+		if description, ok := byDirection[directions[i].Id]; ok {
+			directions[i].Description = description
+		}
+	}
+	return
+}
+
+// TranslateReceiptIngredients replaces names of the receipt ingredients with their translations to the language.
+func (s *Receipt) TranslateReceiptIngredients(language string, ingredients []receipt.ReceiptIngredient) (err error) {
+	if len(language) == 0 {
+		return
+	}
+
+	items := make([]ingredient.Ingredient, 0, len(ingredients))
+	for _, item := range ingredients {
+		if item.Ingredient != nil {
+			items = append(items, *item.Ingredient)
+		}
+	}
+	err = s.TranslateIngredients(language, items)
+	if err != nil {
+		return
+	}
+
+	names := make(map[uint]string, len(items))
+	for _, item := range items {
+		names[item.Id] = item.Name
+	}
+	for i := range ingredients {
+		if ingredients[i].Ingredient == nil {
+			continue
+		}
+		// preloaded ingredients can be shared, so they are copied
+		translated := *ingredients[i].Ingredient
+		translated.Name = names[translated.Id]
+		ingredients[i].Ingredient = &translated
+	}
+	return
+}
+
+// TranslateIngredients replaces names of the ingredients with their translations to the language.
+func (s *Receipt) TranslateIngredients(language string, ingredients []ingredient.Ingredient) (err error) {
+	if len(language) == 0 {
+		return
+	}
+
+	ids := make([]uint, 0, len(ingredients))
+	for _, i := range ingredients {
+		ids = append(ids, i.Id)
+	}
+	translations, err := s.ingredientRepo.GetTranslations(ids, language)
+	if err != nil {
+		return
+	}
+
+	names := make(map[uint]string, len(translations))
+	for _, t := range translations {
+		names[t.IngredientId] = t.Name
+	}
+	for i := range ingredients {
+		if name, ok := names[ingredients[i].Id]; ok {
+			ingredients[i].Name = name
+		}
+	}
+	return
+}
+
+// TranslateFullReceipt translates the receipt with its ingredients and directions,
+// directions are kept when the receipt is in the language already.
+func (s *Receipt) TranslateFullReceipt(language string, i *receipt.FullReceipt) (err error) {
+	if len(language) == 0 {
+		return
+	}
+
+	// ingredients are shared by receipts in any language
+	err = s.TranslateReceiptIngredients(language, i.Ingredients)
+	if err != nil || i.Language == language {
+		return
+	}
+
+	err = s.TranslateReceipt(language, &i.Receipt)
+	if err != nil {
+		return
+	}
+	err = s.TranslateReceiptDirections(language, i.Directions)
+	return
+}
+
+func (s *Receipt) GetReceiptTranslations(receiptId, userId uint) (translations receipt.Translations, err error) {
+	_, err = s.getPermittedReceipt(receiptId, userId, receipt.ViewerRole)
+	if err != nil {
+		return
+	}
+	translations, err = s.receiptRepo.GetTranslationsById(receiptId)
+	return
+}
+
+// SaveReceiptTranslation adds the translation of the receipt name and description or replaces the existing one.
+func (s *Receipt) SaveReceiptTranslation(receiptId, userId uint, language string, request SaveReceiptTranslationRequest) (t receipt.ReceiptTranslation, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+	err = checkTranslationLanguage(language)
+	if err != nil {
+		return
+	}
+
+	r, err := s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
+		return
+	}
+	if r.Language == language {
+		err = tools.NewValidationErr(fmt.Errorf("receipt is in `%s` already, it should be updated instead", language))
+		return
+	}
+
+	t = receipt.ReceiptTranslation{ReceiptId: r.Id, Language: language, Name: request.Name, Description: request.Description}
+	err = s.receiptRepo.SaveTranslation(&t)
+	return
+}
+
+// SaveDirectionTranslation adds the translation of the step description or replaces the existing one.
+func (s *Receipt) SaveDirectionTranslation(receiptId, rDirectionId, userId uint, language string, request SaveDirectionTranslationRequest) (t receipt.DirectionTranslation, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+	err = checkTranslationLanguage(language)
+	if err != nil {
+		return
+	}
+
+	r, err := s.getPermittedReceipt(receiptId, userId, receipt.EditorRole)
+	if err != nil {
+		return
+	}
+	if r.Language == language {
+		err = tools.NewValidationErr(fmt.Errorf("receipt is in `%s` already, the step should be updated instead", language))
+		return
+	}
+
+	direction, err := s.getReceiptDirection(receiptId, rDirectionId)
+	if err != nil {
+		return
+	}
+
+	t = receipt.DirectionTranslation{ReceiptId: r.Id, DirectionId: direction.Id, Language: language, Description: request.Description}
+	err = s.receiptRepo.SaveDirectionTranslation(&t)
+	return
+}
+
+func (s *Receipt) GetIngredientTranslations(id uint) (translations []ingredient.Translation, err error) {
+	_, err = s.ingredientRepo.GetById(id)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	if err != nil {
+		return
+	}
+	translations, err = s.ingredientRepo.GetTranslationsById(id)
+	return
+}
+
+// SaveIngredientTranslation adds the translation of the ingredient name or replaces the existing one.
+func (s *Receipt) SaveIngredientTranslation(id uint, language string, request SaveIngredientTranslationRequest) (t ingredient.Translation, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+	err = checkTranslationLanguage(language)
+	if err != nil {
+		return
+	}
+
+	i, err := s.ingredientRepo.GetById(id)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	t = ingredient.Translation{IngredientId: i.Id, Language: language, Name: request.Name}
+	err = s.ingredientRepo.SaveTranslation(&t)
+	return
+}
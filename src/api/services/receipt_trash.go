@@ -0,0 +1,152 @@
+package services
+
+import (
+	"fmt"
+	"food/src/api/config"
+	"food/src/api/models/receipt"
+	"food/src/api/models/tools"
+	"github.com/jinzhu/gorm"
+	"log"
+	"time"
+)
+
+// trashPurgeInterval is how often expired receipts are looked for
+const trashPurgeInterval = time.Hour
+
+// GetTrash returns receipts of the user which are in trash, recently deleted first.
+func (s *Receipt) GetTrash(userId uint) (items []receipt.TrashItem, err error) {
+	receipts, err := s.receiptRepo.GetDeletedByUserId(userId)
+	if err != nil {
+		return
+	}
+
+	retention := config.GetConfig().TrashRetention
+	items = make([]receipt.TrashItem, 0, len(receipts))
+	for _, r := range receipts {
+		items = append(items, receipt.TrashItem{
+			Receipt:   r,
+			DeletedAt: *r.DeletedAt,
+			PurgeAt:   r.DeletedAt.Add(retention),
+		})
+	}
+	return
+}
+
+// getTrashedReceipt returns the deleted receipt, only its owner can restore or purge it.
+func (s *Receipt) getTrashedReceipt(id, userId uint) (r receipt.Receipt, err error) {
+	r, err = s.receiptRepo.GetDeletedById(id)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found in trash"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if r.UserId != userId {
+		err = tools.NewNotPermittedErr(fmt.Errorf("user `%d` is not the owner of receipt `%d`", userId, id))
+		return
+	}
+	return
+}
+
+// RestoreReceipt takes the receipt out of trash with its ingredients, directions and images.
+func (s *Receipt) RestoreReceipt(id, userId uint) (i receipt.Receipt, err error) {
+	_, err = s.getTrashedReceipt(id, userId)
+	if err != nil {
+		return
+	}
+
+	err = s.receiptRepo.Restore(id)
+	if err != nil {
+		return
+	}
+
+	i, err = s.receiptRepo.GetById(id)
+	if err != nil {
+		return
+	}
+
+	receipts := []receipt.Receipt{i}
+	err = s.markReceipts(userId, receipts)
+	i = receipts[0]
+	return
+}
+
+// PurgeReceipt deletes the receipt in trash permanently.
+func (s *Receipt) PurgeReceipt(id, userId uint) (err error) {
+	_, err = s.getTrashedReceipt(id, userId)
+	if err != nil {
+		return
+	}
+
+	links, err := s.receiptRepo.Purge(id)
+	if err != nil {
+		return
+	}
+
+	s.mediaSvc.RemoveFiles(links)
+	return
+}
+
+// PurgeExpiredReceipts permanently deletes receipts which are in trash longer than the retention.
+// A receipt which cannot be purged does not stop the others, the first error is returned.
+func (s *Receipt) PurgeExpiredReceipts(retention time.Duration) (purged int, err error) {
+	ids, err := s.receiptRepo.GetDeletedIdsBefore(time.Now().UTC().Add(-retention))
+	if err != nil {
+		return
+	}
+
+	for _, id := range ids {
+		links, purgeErr := s.receiptRepo.Purge(id)
+		if purgeErr != nil {
+			log.Printf("purge receipt `%d` error: `%s`", id, purgeErr)
+			if err == nil {
+				err = purgeErr
+			}
+			continue
+		}
+		s.mediaSvc.RemoveFiles(links)
+		purged++
+	}
+	return
+}
+
+// StartTrashPurge runs PurgeExpiredReceipts now and then every hour until stop is called.
+func StartTrashPurge(db *gorm.DB, retention time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+		purgeTrash(db, retention)
+		for {
+			select {
+			case <-ticker.C:
+				purgeTrash(db, retention)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+	}
+}
+
+// purgeTrash runs PurgeExpiredReceipts once, a failed run is logged and does not stop the next ones.
+func purgeTrash(db *gorm.DB, retention time.Duration) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("purge trash panic: `%v`", r)
+		}
+	}()
+
+	purged, err := GetReceiptService(db).PurgeExpiredReceipts(retention)
+	if err != nil {
+		log.Printf("purge trash error: `%s`", err)
+	}
+	if purged > 0 {
+		log.Printf("purged %d receipts from trash", purged)
+	}
+}
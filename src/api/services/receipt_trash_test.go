@@ -0,0 +1,75 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"food/src/api/database/dbtest"
+)
+
+func TestReceiptTrash(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	s := GetReceiptService(db)
+
+	userId := dbtest.CreateUser(t, db, "cook")
+	r := newTestFullReceipt(t, s, userId)
+	kept := newTestFullReceipt(t, s, userId)
+
+	// the step deleted before the receipt is not restored with it
+	err := s.DeleteReceiptDirection(r.Id, r.Directions[0].Id, userId, "")
+	if err != nil {
+		t.Fatalf("cannot delete direction: %v", err)
+	}
+	err = db.Table("receipt_directions").Where("id = ?", r.Directions[0].Id).
+		UpdateColumn("deleted_at", time.Now().UTC().Add(-time.Hour)).Error
+	if err != nil {
+		t.Fatalf("cannot move deletion time: %v", err)
+	}
+	err = s.DeleteReceipt(r.Id, userId, false, "")
+	if err != nil {
+		t.Fatalf("cannot delete receipt: %v", err)
+	}
+	_, err = s.GetFullReceipt(r.Id, userId)
+	if err == nil {
+		t.Errorf("deleted receipt is found")
+	}
+	ingredients, err := s.receiptRepo.GetIngredientsById(r.Id)
+	if err != nil || len(ingredients) != 0 {
+		t.Errorf("ingredients of deleted receipt are %v, error %v", ingredients, err)
+	}
+
+	_, err = s.RestoreReceipt(r.Id, userId)
+	if err != nil {
+		t.Fatalf("cannot restore receipt: %v", err)
+	}
+	restored, err := s.GetFullReceipt(r.Id, userId)
+	if err != nil {
+		t.Fatalf("cannot get restored receipt: %v", err)
+	}
+	if len(restored.Ingredients) != 2 || len(restored.Directions) != 1 || restored.Directions[0].Id != r.Directions[1].Id {
+		t.Errorf("restored receipt has ingredients %v and directions %v", restored.Ingredients, restored.Directions)
+	}
+
+	err = s.DeleteReceipt(r.Id, userId, false, "")
+	if err != nil {
+		t.Fatalf("cannot delete receipt: %v", err)
+	}
+	purged, err := s.PurgeExpiredReceipts(0)
+	if err != nil || purged != 1 {
+		t.Errorf("purged %d receipts, error %v, expected 1", purged, err)
+	}
+	_, err = s.RestoreReceipt(r.Id, userId)
+	if !isValidationErr(err) {
+		t.Errorf("purged receipt is restored, error %v", err)
+	}
+	_, err = s.GetFullReceipt(kept.Id, userId)
+	if err != nil {
+		t.Errorf("receipt which is not deleted is purged: %v", err)
+	}
+}
+
+func TestPurgeTrashRecovers(t *testing.T) {
+	// the service without a database panics, the next runs should not be stopped
+	purgeTrash(nil, time.Hour)
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"fmt"
+	"food/src/api/models/ingredient"
+	"food/src/api/models/mealplan"
+	"food/src/api/models/pantry"
+	"food/src/api/models/receipt"
+	"food/src/api/models/shopping"
+	"food/src/api/models/tools"
+	"github.com/jinzhu/gorm"
+	"strings"
+	"time"
+)
+
+func GetShoppingListService(db *gorm.DB) *ShoppingList {
+	return &ShoppingList{
+		listRepo:    shopping.GetListRepository(db),
+		receiptRepo: receipt.GetReceiptRepository(db),
+		entryRepo:   mealplan.GetEntryRepository(db),
+		pantryRepo:  pantry.GetItemRepository(db),
+		receiptSvc:  GetReceiptService(db),
+	}
+}
+
+type ShoppingList struct {
+	listRepo    *shopping.ListRepository
+	receiptRepo *receipt.ReceiptRepository
+	entryRepo   *mealplan.EntryRepository
+	pantryRepo  *pantry.ItemRepository
+	receiptSvc  *Receipt
+}
+
+type CreateShoppingListRequest struct {
+	// (required)
+	Name string `json:"name" minLength:"3" maxLength:"255" binding:"required" validate:"max=255,min=3"`
+	// receipts to buy ingredients for, repeated ids are counted several times
+	ReceiptIds []uint `json:"receipt_ids"`
+	// start of the meal plan range, YYYY-MM-DD
+	From string `json:"from" example:"2019-07-01"`
+	// end of the meal plan range, YYYY-MM-DD
+	To string `json:"to" example:"2019-07-07"`
+	// list ingredients available in the pantry too
+	IgnorePantry bool `json:"ignore_pantry"`
+}
+
+func (u *CreateShoppingListRequest) TrimSpaces() {
+	u.Name = strings.TrimSpace(u.Name)
+}
+
+type CreateShoppingListItemRequest struct {
+	// (required)
+	Name     string `json:"name" minLength:"1" maxLength:"255" binding:"required" validate:"max=255,min=1"`
+	Quantity string `json:"quantity" maxLength:"255" validate:"max=255"`
+	Category string `json:"category" maxLength:"255" validate:"max=255"`
+}
+
+func (u *CreateShoppingListItemRequest) TrimSpaces() {
+	u.Name = strings.TrimSpace(u.Name)
+	u.Quantity = strings.TrimSpace(u.Quantity)
+	u.Category = strings.ToLower(strings.TrimSpace(u.Category))
+}
+
+type UpdateShoppingListItemRequest struct {
+	// (required)
+	Checked *bool `json:"checked" binding:"required"`
+	// keeps current quantity when omitted
+	Quantity *string `json:"quantity" maxLength:"255"`
+}
+
+func (s *ShoppingList) GetLists(userId uint) (lists []shopping.List, err error) {
+	lists, err = s.listRepo.GetAllByUserId(userId)
+	return
+}
+
+func (s *ShoppingList) GetList(id, userId uint) (list shopping.List, err error) {
+	list, err = s.getOwnList(id, userId)
+	if err != nil {
+		return
+	}
+
+	list.Groups = shopping.GroupItems(list.Items)
+	return
+}
+
+// CreateList generates the shopping list from the given receipts and the meal plan range.
+func (s *ShoppingList) CreateList(userId uint, request CreateShoppingListRequest) (list shopping.List, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	if len(request.ReceiptIds) == 0 && len(request.From) == 0 && len(request.To) == 0 {
+		err = tools.NewValidationErr(fmt.Errorf("receipt ids or meal plan range should be given"))
+		return
+	}
+
+	portions, err := s.collectPortions(userId, request)
+	if err != nil {
+		return
+	}
+
+	receiptIngredients, err := s.getReceiptIngredients(portions)
+	if err != nil {
+		return
+	}
+
+	var stocks []pantry.Item
+	if !request.IgnorePantry {
+		stocks, err = s.pantryRepo.GetByIngredientIds(userId, ingredientIds(receiptIngredients))
+		if err != nil {
+			return
+		}
+	}
+
+	list = shopping.List{
+		UserId: userId,
+		Name:   request.Name,
+		Items:  shopping.BuildItems(receiptIngredients, stocks, time.Now()),
+	}
+	err = s.listRepo.Create(&list)
+	if err != nil {
+		return
+	}
+
+	list, err = s.GetList(list.Id, userId)
+	return
+}
+
+func (s *ShoppingList) DeleteList(id, userId uint) (err error) {
+	_, err = s.getOwnList(id, userId)
+	if _, ok := err.(*tools.ValidationErr); ok {
+		// list is already deleted
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	err = s.listRepo.Delete(id)
+	return
+}
+
+func (s *ShoppingList) CreateItem(listId, userId uint, request CreateShoppingListItemRequest) (item shopping.Item, err error) {
+	request.TrimSpaces()
+	err = tools.Validator.Struct(request)
+	if err != nil {
+		err = tools.NewValidationErr(err)
+		return
+	}
+
+	_, err = s.getOwnList(listId, userId)
+	if err != nil {
+		return
+	}
+
+	item = shopping.Item{
+		ShoppingListId: listId,
+		Name:           request.Name,
+		Quantity:       request.Quantity,
+		Category:       request.Category,
+		Manual:         true,
+	}
+	err = s.listRepo.CreateItem(&item)
+	return
+}
+
+// UpdateItem ticks the item off or back and optionally corrects its quantity.
+func (s *ShoppingList) UpdateItem(listId, itemId, userId uint, request UpdateShoppingListItemRequest) (item shopping.Item, err error) {
+	if request.Checked == nil {
+		err = tools.NewValidationErr(fmt.Errorf("checked cannot be empty"))
+		return
+	}
+	if request.Quantity != nil && len(*request.Quantity) > tools.MaxRegularStringLength {
+		err = tools.NewValidationErr(fmt.Errorf("quantity is too long"))
+		return
+	}
+
+	item, err = s.getOwnItem(listId, itemId, userId)
+	if err != nil {
+		return
+	}
+
+	item.Checked = *request.Checked
+	if request.Quantity != nil {
+		item.Quantity = strings.TrimSpace(*request.Quantity)
+	}
+	err = s.listRepo.UpdateItem(&item)
+	if err != nil {
+		return
+	}
+
+	item, err = s.listRepo.GetItemById(item.Id)
+	return
+}
+
+func (s *ShoppingList) DeleteItem(listId, itemId, userId uint) (err error) {
+	_, err = s.getOwnItem(listId, itemId, userId)
+	if _, ok := err.(*tools.ValidationErr); ok {
+		// item is already deleted
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	err = s.listRepo.DeleteItemById(itemId)
+	return
+}
+
+// portion is one occurrence of the receipt in the shopping list.
+type portion struct {
+	receiptId uint
+	// zero servings keep quantities of the receipt as they are
+	servings uint
+}
+
+// collectPortions returns receipts of the request and the meal plan, one per occurrence.
+// Meal plan entries are cooked for their servings.
+func (s *ShoppingList) collectPortions(userId uint, request CreateShoppingListRequest) (portions []portion, err error) {
+	checked := make(map[uint]bool)
+	for _, id := range request.ReceiptIds {
+		if !checked[id] {
+			_, err = s.receiptSvc.getPermittedReceipt(id, userId, receipt.ViewerRole)
+			if err != nil {
+				return
+			}
+			checked[id] = true
+		}
+		portions = append(portions, portion{receiptId: id})
+	}
+
+	if len(request.From) == 0 && len(request.To) == 0 {
+		return
+	}
+
+	from, to, err := ParseMealPlanRange(request.From, request.To)
+	if err != nil {
+		return
+	}
+
+	// entries of receipts the user cannot see anymore are skipped by the repository
+	entries, err := s.entryRepo.GetByDateRange(userId, from, to)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		portions = append(portions, portion{receiptId: entry.ReceiptId, servings: entry.Servings})
+	}
+	return
+}
+
+// getReceiptIngredients loads ingredients of every receipt occurrence. Quantities are scaled
+// to the servings of the occurrence when the receipt tells its own servings.
+func (s *ShoppingList) getReceiptIngredients(portions []portion) (receiptIngredients []receipt.ReceiptIngredient, err error) {
+	byReceipt := make(map[uint][]portion)
+	uniqueIds := make([]uint, 0, len(portions))
+	for _, p := range portions {
+		if len(byReceipt[p.receiptId]) == 0 {
+			uniqueIds = append(uniqueIds, p.receiptId)
+		}
+		byReceipt[p.receiptId] = append(byReceipt[p.receiptId], p)
+	}
+
+	receipts, err := s.receiptRepo.GetByIds(uniqueIds)
+	if err != nil {
+		return
+	}
+	servings := make(map[uint]uint, len(receipts))
+	for _, r := range receipts {
+		servings[r.Id] = r.Servings
+	}
+
+	ingredients, err := s.receiptRepo.GetIngredientsByIds(uniqueIds)
+	if err != nil {
+		return
+	}
+
+	for _, ri := range ingredients {
+		quantity := ri.Quantity
+		for _, p := range byReceipt[ri.ReceiptId] {
+			if p.servings > 0 && servings[ri.ReceiptId] > 0 {
+				ri.Quantity = ingredient.ScaleQuantity(quantity, float64(p.servings)/float64(servings[ri.ReceiptId]))
+			} else {
+				ri.Quantity = quantity
+			}
+			receiptIngredients = append(receiptIngredients, ri)
+		}
+	}
+	return
+}
+
+func ingredientIds(receiptIngredients []receipt.ReceiptIngredient) (ids []uint) {
+	seen := make(map[uint]bool)
+	for _, ri := range receiptIngredients {
+		if !seen[ri.IngredientId] {
+			seen[ri.IngredientId] = true
+			ids = append(ids, ri.IngredientId)
+		}
+	}
+	return
+}
+
+func (s *ShoppingList) getOwnList(id, userId uint) (list shopping.List, err error) {
+	list, err = s.listRepo.GetById(id)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if list.UserId != userId {
+		err = tools.NewNotPermittedErr(fmt.Errorf("user id mismatch"))
+		return
+	}
+	return
+}
+
+func (s *ShoppingList) getOwnItem(listId, itemId, userId uint) (item shopping.Item, err error) {
+	_, err = s.getOwnList(listId, userId)
+	if err != nil {
+		return
+	}
+
+	item, err = s.listRepo.GetItemById(itemId)
+	if gorm.IsRecordNotFoundError(err) {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if item.ShoppingListId != listId {
+		err = tools.NewValidationErr(fmt.Errorf("item not found"))
+		return
+	}
+	return
+}
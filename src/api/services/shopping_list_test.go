@@ -0,0 +1,113 @@
+package services
+
+import (
+	"testing"
+
+	"food/src/api/database/dbtest"
+)
+
+func TestCreateListScalesServings(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	receiptSvc := GetReceiptService(db)
+	planSvc := GetMealPlanService(db)
+	s := GetShoppingListService(db)
+
+	userId := dbtest.CreateUser(t, db, "cook")
+	r, err := receiptSvc.CreateFullReceipt(userId, FullReceiptRequest{
+		CreateReceiptRequest: CreateReceiptRequest{
+			Name:        "Pancakes",
+			Description: "Thin pancakes",
+			Category:    "breakfast",
+			CookTime:    20,
+			Servings:    2,
+		},
+		Ingredients: []FullReceiptIngredientRequest{
+			{Quantity: "200 g", Name: "flour"},
+			{Quantity: "to taste", Name: "salt"},
+		},
+		Directions: []FullReceiptDirectionRequest{
+			{UpdateReceiptDirectionRequest: UpdateReceiptDirectionRequest{Description: "Mix and fry"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("cannot create receipt: %v", err)
+	}
+
+	_, err = planSvc.CreateEntry(userId, CreateMealPlanEntryRequest{ReceiptId: r.Id, Date: "2019-07-01", Slot: "breakfast", Servings: 4})
+	if err != nil {
+		t.Fatalf("cannot plan receipt: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		request  CreateShoppingListRequest
+		expected map[string]string
+	}{
+		{
+			"receipt as written",
+			CreateShoppingListRequest{Name: "Receipt", ReceiptIds: []uint{r.Id}},
+			map[string]string{"flour": "200 g", "salt": "to taste"},
+		},
+		{
+			"meal plan servings",
+			CreateShoppingListRequest{Name: "Meal plan", From: "2019-07-01", To: "2019-07-01"},
+			map[string]string{"flour": "400 g", "salt": "to taste"},
+		},
+		{
+			"both",
+			CreateShoppingListRequest{Name: "Both", ReceiptIds: []uint{r.Id}, From: "2019-07-01", To: "2019-07-01"},
+			map[string]string{"flour": "600 g", "salt": "to taste + to taste"},
+		},
+	}
+	for _, test := range tests {
+		list, err := s.CreateList(userId, test.request)
+		if err != nil {
+			t.Fatalf("%s: cannot create list: %v", test.name, err)
+		}
+		if len(list.Items) != len(test.expected) {
+			t.Errorf("%s: got %d items, expected %d", test.name, len(list.Items), len(test.expected))
+		}
+		for _, item := range list.Items {
+			if expected := test.expected[item.Name]; item.Quantity != expected {
+				t.Errorf("%s: %s quantity is %q, expected %q", test.name, item.Name, item.Quantity, expected)
+			}
+		}
+	}
+}
+
+func TestCreateListSkipsHiddenReceipts(t *testing.T) {
+	db := dbtest.Open(t)
+	defer db.Close()
+	receiptSvc := GetReceiptService(db)
+	planSvc := GetMealPlanService(db)
+	s := GetShoppingListService(db)
+
+	ownerId := dbtest.CreateUser(t, db, "owner")
+	userId := dbtest.CreateUser(t, db, "cook")
+	r := newTestFullReceipt(t, receiptSvc, ownerId)
+	_, err := planSvc.CreateEntry(userId, CreateMealPlanEntryRequest{ReceiptId: r.Id, Date: "2019-07-01", Slot: "breakfast", Servings: 2})
+	if err != nil {
+		t.Fatalf("cannot plan receipt: %v", err)
+	}
+
+	private := true
+	update := UpdateReceiptRequest(newCreateReceiptRequest(r.Receipt))
+	update.Private = &private
+	_, err = receiptSvc.UpdateReceipt(r.Id, ownerId, update, "")
+	if err != nil {
+		t.Fatalf("cannot make receipt private: %v", err)
+	}
+
+	list, err := s.CreateList(userId, CreateShoppingListRequest{Name: "Meal plan", From: "2019-07-01", To: "2019-07-01"})
+	if err != nil {
+		t.Fatalf("cannot create list: %v", err)
+	}
+	if len(list.Items) != 0 {
+		t.Errorf("list has ingredients of the private receipt: %+v", list.Items)
+	}
+	_, err = s.CreateList(userId, CreateShoppingListRequest{Name: "Receipt", ReceiptIds: []uint{r.Id}})
+	if !isNotPermitted(err) {
+		t.Errorf("list of the private receipt is created, error %v", err)
+	}
+}